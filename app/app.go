@@ -122,7 +122,7 @@ func (app *IrisApp) ResetOrReplay(replayHeight int64) (replay bool, height int64
 		cmn.Exit("Reset operation aborted.")
 	}
 
-	if lastBlockHeight-replayHeight <= DefaultCacheSize {
+	if lastBlockHeight-replayHeight <= app.pruning.KeepRecent {
 		err := app.LoadVersion(replayHeight, protocol.KeyMain, true)
 
 		if err != nil {
@@ -174,11 +174,25 @@ func (app *IrisApp) LoadHeight(height int64) error {
 	return app.LoadVersion(height, protocol.KeyMain, false)
 }
 
+// LoadHeightWithProtocol loads to the specified height and the protocol of this height,
+// so that the following blocks can be executed on it
+func (app *IrisApp) LoadHeightWithProtocol(height int64) error {
+	if err := app.LoadVersion(height, protocol.KeyMain, false); err != nil {
+		return err
+	}
+	loaded, current := app.Engine.LoadCurrentProtocol(app.GetKVStore(protocol.KeyMain))
+	if !loaded {
+		return fmt.Errorf("your software doesn't support the required protocol (version %d)", current)
+	}
+	app.BaseApp.txDecoder = auth.DefaultTxDecoder(app.Engine.GetCurrentProtocol().GetCodec())
+	return nil
+}
+
 func (app *IrisApp) replayToHeight(replayHeight int64, logger log.Logger) int64 {
 	loadHeight := int64(0)
 	logger.Info("Please make sure the replay height is smaller than the latest block height.")
-	if replayHeight >= DefaultSyncableHeight {
-		loadHeight = replayHeight - replayHeight%DefaultSyncableHeight
+	if keepEvery := app.pruning.KeepEvery; keepEvery > 0 && replayHeight >= keepEvery {
+		loadHeight = replayHeight - replayHeight%keepEvery
 	} else {
 		// version 1 will always be kept
 		loadHeight = 1
@@ -7,6 +7,7 @@ import (
 	"runtime/debug"
 	"strconv"
 	"strings"
+	"sync"
 
 	"github.com/gogo/protobuf/proto"
 	"github.com/NPC-Chain/npcchub/app/protocol"
@@ -70,12 +71,33 @@ type BaseApp struct {
 	// minimum fees for spam prevention
 	minimumFees sdk.Coins
 
+	// limit of the txs of a sender pending in the mempool, 0 for no limit
+	maxPendingTxs uint64
+
 	// enable invariant check
 	checkInvariant bool
 
 	// enable track coin flow
 	trackCoinFlow bool
 
+	// pruning strategy of the multistore
+	pruning sdk.PruningOptions
+
+	// genesis export at the system halt height
+	haltExport        haltExportConfig
+	haltExportPending bool
+
+	// change feed of the committed blocks
+	streaming *StreamingService
+
+	// custom queries served concurrently against the last committed version
+	queryPool  *queryPool
+	queryMtx   sync.RWMutex
+	queryState *queryState
+
+	// gas limit of a query, 0 means no limit
+	queryGasLimit uint64
+
 	// flag for sealing
 	sealed bool
 }
@@ -89,10 +111,11 @@ var _ abci.Application = (*BaseApp)(nil)
 // Accepts variable number of option functions, which act on the BaseApp to set configuration choices
 func NewBaseApp(name string, logger log.Logger, db dbm.DB, options ...func(*BaseApp)) *BaseApp {
 	app := &BaseApp{
-		Logger: logger,
-		name:   name,
-		db:     db,
-		cms:    store.NewCommitMultiStore(db),
+		Logger:  logger,
+		name:    name,
+		db:      db,
+		cms:     store.NewCommitMultiStore(db),
+		pruning: sdk.PruneSyncable.Options(),
 	}
 
 	for _, option := range options {
@@ -210,12 +233,29 @@ func (app *BaseApp) SetProtocolEngine(pe *protocol.ProtocolEngine) {
 // SetMinimumFees sets the minimum fees.
 func (app *BaseApp) SetMinimumFees(fees sdk.Coins) { app.minimumFees = fees }
 
+// SetMaxPendingTxs sets the limit of the txs of a sender pending in the mempool.
+func (app *BaseApp) SetMaxPendingTxs(limit uint64) { app.maxPendingTxs = limit }
+
 // SetInvariantCheck sets the invariant check config.
 func (app *BaseApp) SetCheckInvariant(check bool) { app.checkInvariant = check }
 
 // SetTrackCoinFlow sets the config about track coin flow
 func (app *BaseApp) SetTrackCoinFlow(enable bool) { app.trackCoinFlow = enable }
 
+// SetHaltExport sets the config about the genesis export at the system halt height
+func (app *BaseApp) SetHaltExport(genesisFile, exportFile string) {
+	app.haltExport = haltExportConfig{genesisFile: genesisFile, exportFile: exportFile}
+}
+
+// SetStreamingService sets the service streaming the committed blocks and listens to the writes of the multistore
+func (app *BaseApp) SetStreamingService(streaming *StreamingService) {
+	if app.sealed {
+		panic("SetStreamingService() on sealed BaseApp")
+	}
+	app.streaming = streaming
+	app.cms.AddListeners(streaming)
+}
+
 // NewContext returns a new Context with the correct store, the given header, and nil txBytes.
 func (app *BaseApp) NewContext(isCheckTx bool, header abci.Header) sdk.Context {
 	if isCheckTx {
@@ -240,8 +280,10 @@ func (st *state) Context() sdk.Context {
 func (app *BaseApp) setCheckState(header abci.Header) {
 	ms := app.cms.CacheMultiStore()
 	app.checkState = &state{
-		ms:  ms,
-		ctx: sdk.NewContext(ms, header, true, app.Logger).WithMinimumFees(app.minimumFees),
+		ms: ms,
+		ctx: sdk.NewContext(ms, header, true, app.Logger).
+			WithMinimumFees(app.minimumFees).
+			WithPendingTxs(sdk.NewPendingTxCounter(app.maxPendingTxs)),
 	}
 }
 
@@ -432,7 +474,13 @@ func handleQueryStore(app *BaseApp, path []string, req abci.RequestQuery) (res a
 		return sdk.ErrUnknownRequest(msg).QueryResult()
 	}
 	req.Path = "/" + strings.Join(path[1:], "/")
-	return queryable.Query(req)
+	gasQueryable, ok := queryable.(sdk.GasQueryable)
+	if !ok {
+		return queryable.Query(req)
+	}
+	return app.meterQuery(req, func(meter sdk.GasMeter) abci.ResponseQuery {
+		return gasQueryable.QueryWithGas(req, meter)
+	})
 }
 
 // nolint: unparam
@@ -464,28 +512,19 @@ func handleQueryCustom(app *BaseApp, path []string, req abci.RequestQuery) (res
 	if len(path) < 2 || path[1] == "" {
 		return sdk.ErrUnknownRequest("No route for custom query specified").QueryResult()
 	}
+	if qs := app.getQueryState(); app.queryPool != nil && qs != nil {
+		return app.runConcurrentQuery(qs, path, req)
+	}
+
 	querier := app.Engine.GetCurrentProtocol().GetQueryRouter().Route(path[1])
 	if querier == nil {
 		return sdk.ErrUnknownRequest(fmt.Sprintf("no custom querier found for route %s", path[1])).QueryResult()
 	}
 
-	// Cache wrap the commit-multistore for safety.
-	ctx := sdk.NewContext(app.cms.CacheMultiStore(), app.checkState.ctx.BlockHeader(), true, app.Logger).
-		WithMinimumFees(app.minimumFees)
-	// Passes the rest of the path as an argument to the querier.
-	// For example, in the path "custom/gov/proposal/test", the gov querier gets []string{"proposal", "test"} as the path
-	resBytes, err := querier(ctx, path[2:], req)
-	if err != nil {
-		return abci.ResponseQuery{
-			Code:      uint32(err.Code()),
-			Codespace: string(err.Codespace()),
-			Log:       err.ABCILog(),
-		}
-	}
-	return abci.ResponseQuery{
-		Code:  uint32(sdk.CodeOK),
-		Value: resBytes,
-	}
+	return app.meterQuery(req, func(meter sdk.GasMeter) abci.ResponseQuery {
+		// Cache wrap the commit-multistore for safety.
+		return app.runQuerier(app.cms.CacheMultiStore(), app.checkState.ctx.BlockHeader(), meter, querier, path, req)
+	})
 }
 
 // BeginBlock implements the ABCI application interface.
@@ -529,6 +568,9 @@ func (app *BaseApp) BeginBlock(req abci.RequestBeginBlock) (res abci.ResponseBeg
 	// TODO: communicate this result to the address to pubkey map in slashing
 	app.voteInfos = req.LastCommitInfo.GetVotes()
 
+	if app.streaming != nil {
+		app.streaming.ListenBeginBlock(req, res)
+	}
 	return
 }
 
@@ -575,7 +617,7 @@ func (app *BaseApp) DeliverTx(txBytes []byte) (res abci.ResponseDeliverTx) {
 	// namely fee deductions and sequence incrementing.
 
 	// Tell the blockchain Engine (i.e. Tendermint).
-	return abci.ResponseDeliverTx{
+	res = abci.ResponseDeliverTx{
 		Code:      uint32(result.Code),
 		Codespace: string(result.Codespace),
 		Data:      result.Data,
@@ -584,6 +626,10 @@ func (app *BaseApp) DeliverTx(txBytes []byte) (res abci.ResponseDeliverTx) {
 		GasUsed:   int64(result.GasUsed),
 		Tags:      result.Tags,
 	}
+	if app.streaming != nil {
+		app.streaming.ListenDeliverTx(abci.RequestDeliverTx{Tx: txBytes}, res)
+	}
+	return res
 }
 
 // Basic validator for msgs
@@ -855,9 +901,15 @@ func (app *BaseApp) runTx(mode RunTxMode, txBytes []byte, tx sdk.Tx) (result sdk
 
 		newCtx.GasMeter().ConsumeGas(auth.BlockStoreCostPerByte*sdk.Gas(len(txBytes)), "blockstore")
 		msCache.Write()
+
+		// the tx is now pending in the local mempool, the first signer pays the fees
+		if signers := auth.GetSigners(newCtx); mode == RunTxModeCheck && len(signers) > 0 {
+			ctx.PendingTxs().Incr(signers[0].GetAddress())
+		}
 	}
 
 	if mode == RunTxModeCheck {
+		result.Tags = sdk.NewTags(sdk.TagPriority, []byte(strconv.FormatInt(ctx.Priority(), 10)))
 		return
 	}
 
@@ -882,6 +934,9 @@ func (app *BaseApp) runTx(mode RunTxMode, txBytes []byte, tx sdk.Tx) (result sdk
 
 // EndBlock implements the ABCI application interface.
 func (app *BaseApp) EndBlock(req abci.RequestEndBlock) (res abci.ResponseEndBlock) {
+	if app.streaming != nil {
+		defer func() { app.streaming.ListenEndBlock(req, res) }()
+	}
 
 	if app.deliverState.ms.TracingEnabled() {
 		app.deliverState.ms = app.deliverState.ms.ResetTraceContext().(sdk.CacheMultiStore)
@@ -892,6 +947,10 @@ func (app *BaseApp) EndBlock(req abci.RequestEndBlock) (res abci.ResponseEndBloc
 		res = endBlocker(app.deliverState.ctx, req)
 	}
 
+	if _, ok := abci.GetTagByKey(res.Tags, sdk.ExportGenesisTag); ok {
+		app.haltExportPending = true
+	}
+
 	appVersionStr, ok := abci.GetTagByKey(res.Tags, sdk.AppVersionTag)
 	if !ok {
 		return
@@ -926,6 +985,7 @@ func (app *BaseApp) Commit() (res abci.ResponseCommit) {
 
 	// Write the Deliver state and commit the MultiStore
 	app.deliverState.ms.Write()
+	app.streamBlock()
 	commitID := app.cms.Commit(app.Engine.GetCurrentProtocol().GetKVStoreKeyList())
 	// TODO: this is missing a module identifier and dumps byte array
 	app.Logger.Debug("Commit synced",
@@ -936,10 +996,18 @@ func (app *BaseApp) Commit() (res abci.ResponseCommit) {
 	// NOTE: safe because Tendermint holds a lock on the mempool for Commit.
 	// Use the header from this latest block.
 	app.setCheckState(header)
+	if app.queryPool != nil {
+		app.setQueryState(header)
+	}
 
 	// Empty the Deliver state
 	app.deliverState = nil
 
+	if app.haltExportPending {
+		app.haltExportPending = false
+		app.exportGenesisAtHalt(header)
+	}
+
 	return abci.ResponseCommit{
 		Data: commitID.Hash,
 	}
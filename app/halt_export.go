@@ -0,0 +1,49 @@
+package app
+
+import (
+	"fmt"
+
+	sdk "github.com/NPC-Chain/npcchub/types"
+	abci "github.com/tendermint/tendermint/abci/types"
+	tmtypes "github.com/tendermint/tendermint/types"
+)
+
+type haltExportConfig struct {
+	genesisFile string // the genesis file of the running chain
+	exportFile  string // the file to write the exported genesis to
+}
+
+// exportGenesisAtHalt exports the committed state for zero height once the system halt height
+// is committed. The node is stopped by the halt tag of the same block, as without the export.
+func (app *BaseApp) exportGenesisAtHalt(header abci.Header) {
+	logger := app.Logger.With("module", "iris/halt")
+	if len(app.haltExport.exportFile) == 0 {
+		logger.Error("SystemHalt genesis export is skipped, halt_export_file is not configured", "height", header.Height)
+		return
+	}
+
+	if err := app.writeHaltGenesis(header); err != nil {
+		logger.Error("SystemHalt genesis export failed, export the state manually with `iris export`", "height", header.Height, "err", err.Error())
+		return
+	}
+	logger.Info(fmt.Sprintf("export state from height %d to file %s successfully", header.Height, app.haltExport.exportFile))
+}
+
+func (app *BaseApp) writeHaltGenesis(header abci.Header) error {
+	// preparing the state for zero height changes it, so it is prepared on a cache of the committed
+	// state which is discarded, leaving the committed state and the check state untouched
+	ctx := sdk.NewContext(app.cms.CacheMultiStore(), header, true, app.Logger)
+	appState, validators, err := app.Engine.GetCurrentProtocol().ExportAppStateAndValidators(ctx, true)
+	if err != nil {
+		return err
+	}
+
+	doc, err := tmtypes.GenesisDocFromFile(app.haltExport.genesisFile)
+	if err != nil {
+		return err
+	}
+	doc.AppState = sdk.MustSortJSON(appState)
+	doc.Validators = validators
+
+	return doc.SaveAs(app.haltExport.exportFile)
+}
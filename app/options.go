@@ -2,6 +2,7 @@ package app
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/NPC-Chain/npcchub/store"
 	sdk "github.com/NPC-Chain/npcchub/types"
@@ -13,19 +14,21 @@ import (
 
 // SetPruning sets a pruning option on the multistore associated with the app
 func SetPruning(pruning string) func(*BaseApp) {
-	var pruningEnum sdk.PruningStrategy
-	switch pruning {
-	case "nothing":
-		pruningEnum = sdk.PruneNothing
-	case "everything":
-		pruningEnum = sdk.PruneEverything
-	case "syncable":
-		pruningEnum = sdk.PruneSyncable
-	default:
-		panic(fmt.Sprintf("Invalid pruning strategy: %s", pruning))
+	pruningOptions, err := sdk.ParsePruningOptions(pruning, 0, 0, 0)
+	if err != nil {
+		panic(err)
+	}
+	return SetPruningOptions(pruningOptions)
+}
+
+// SetPruningOptions sets the pruning options, possibly custom, on the multistore associated with the app
+func SetPruningOptions(pruning sdk.PruningOptions) func(*BaseApp) {
+	if err := pruning.Validate(); err != nil {
+		panic(err)
 	}
 	return func(bap *BaseApp) {
-		bap.cms.SetPruning(pruningEnum)
+		bap.pruning = pruning
+		bap.cms.SetPruning(pruning)
 	}
 }
 
@@ -49,6 +52,11 @@ func SetMinimumFees(minFees string) func(*BaseApp) {
 	return func(bap *BaseApp) { bap.SetMinimumFees(fees) }
 }
 
+// SetMaxPendingTxs returns an option that sets the limit of the txs of a sender pending in the mempool.
+func SetMaxPendingTxs(limit uint64) func(*BaseApp) {
+	return func(bap *BaseApp) { bap.SetMaxPendingTxs(limit) }
+}
+
 // SetCheckInvariant set app invariant check config
 func SetCheckInvariant(check bool) func(*BaseApp) {
 	return func(bap *BaseApp) { bap.SetCheckInvariant(check) }
@@ -59,6 +67,27 @@ func SetTrackCoinFlow(enable bool) func(*BaseApp) {
 	return func(bap *BaseApp) { bap.SetTrackCoinFlow(enable) }
 }
 
+// SetHaltExport sets the files to read the current genesis from and to write the exported genesis to
+// when a SystemHaltProposal asks for the genesis export
+func SetHaltExport(genesisFile, exportFile string) func(*BaseApp) {
+	return func(bap *BaseApp) { bap.SetHaltExport(genesisFile, exportFile) }
+}
+
+// SetStreaming streams the ABCI messages and the state changes of every block to the sink
+func SetStreaming(sink StreamSink, haltOnError bool) func(*BaseApp) {
+	return func(bap *BaseApp) { bap.SetStreamingService(NewStreamingService(sink, haltOnError)) }
+}
+
+// SetQueryPool runs the custom queries in a pool of workers against the last committed version
+func SetQueryPool(workers int, timeout time.Duration) func(*BaseApp) {
+	return func(bap *BaseApp) { bap.SetQueryPool(workers, timeout) }
+}
+
+// SetQueryGasLimit sets the gas limit of the custom and store queries
+func SetQueryGasLimit(gasLimit uint64) func(*BaseApp) {
+	return func(bap *BaseApp) { bap.SetQueryGasLimit(gasLimit) }
+}
+
 // nolint - Setter functions
 func (app *BaseApp) SetName(name string) {
 	if app.sealed {
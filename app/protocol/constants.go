@@ -28,6 +28,7 @@ const (
 	// all route for query and handler
 	BankRoute     = "bank"
 	AccountRoute  = AccountStore
+	FeeRoute      = FeeStore
 	StakeRoute    = StakeStore
 	DistrRoute    = DistrStore
 	SlashingRoute = SlashingStore
@@ -0,0 +1,194 @@
+package app
+
+import (
+	"fmt"
+	"runtime/debug"
+	"strings"
+	"time"
+
+	"github.com/NPC-Chain/npcchub/app/protocol"
+	sdk "github.com/NPC-Chain/npcchub/types"
+	abci "github.com/tendermint/tendermint/abci/types"
+)
+
+// queryPool runs the custom queries in a bounded number of workers, each query with its own timeout
+type queryPool struct {
+	workers chan struct{}
+	timeout time.Duration // 0 means no timeout
+}
+
+// queryState is the last committed state the concurrent queries run against
+type queryState struct {
+	version     int64
+	header      abci.Header
+	queryRouter protocol.QueryRouter
+}
+
+// SetQueryPool runs the custom queries against the last committed version in a pool of workers,
+// concurrently with the other ABCI calls. A number of workers of zero or less disables the pool.
+func (app *BaseApp) SetQueryPool(workers int, timeout time.Duration) {
+	if workers <= 0 {
+		app.queryPool = nil
+		return
+	}
+	app.queryPool = &queryPool{
+		workers: make(chan struct{}, workers),
+		timeout: timeout,
+	}
+}
+
+// SetQueryGasLimit sets the gas limit of the custom and store queries, 0 means no limit
+func (app *BaseApp) SetQueryGasLimit(gasLimit uint64) { app.queryGasLimit = gasLimit }
+
+// IsConcurrentQuery returns if the query can be served concurrently with the other ABCI calls,
+// that is without holding the ABCI mutex. Only the custom queries are, once a version is committed.
+func (app *BaseApp) IsConcurrentQuery(req abci.RequestQuery) bool {
+	if app.queryPool == nil || app.getQueryState() == nil {
+		return false
+	}
+	path := splitPath(req.Path)
+	return len(path) > 0 && path[0] == "custom"
+}
+
+// setQueryState makes the concurrent queries run against the version just committed
+func (app *BaseApp) setQueryState(header abci.Header) {
+	qs := &queryState{
+		version:     app.cms.LastCommitID().Version,
+		header:      header,
+		queryRouter: app.Engine.GetCurrentProtocol().GetQueryRouter(),
+	}
+	app.queryMtx.Lock()
+	app.queryState = qs
+	app.queryMtx.Unlock()
+}
+
+func (app *BaseApp) getQueryState() *queryState {
+	app.queryMtx.RLock()
+	defer app.queryMtx.RUnlock()
+	return app.queryState
+}
+
+// errQueryCancelled is panicked by the gas meter of a query that timed out, to stop its work
+type errQueryCancelled struct{}
+
+// cancelGasMeter stops the query at its next gas consumption once it is cancelled
+type cancelGasMeter struct {
+	sdk.GasMeter
+	cancel <-chan struct{}
+}
+
+func (g cancelGasMeter) ConsumeGas(amount sdk.Gas, descriptor string) {
+	select {
+	case <-g.cancel:
+		panic(errQueryCancelled{})
+	default:
+	}
+	g.GasMeter.ConsumeGas(amount, descriptor)
+}
+
+// runConcurrentQuery waits for a free worker and for the query result until the query timeout.
+// The query runs against the version of the requested height, the last committed one by default.
+func (app *BaseApp) runConcurrentQuery(qs *queryState, path []string, req abci.RequestQuery) abci.ResponseQuery {
+	querier := qs.queryRouter.Route(path[1])
+	if querier == nil {
+		return sdk.ErrUnknownRequest(fmt.Sprintf("no custom querier found for route %s", path[1])).QueryResult()
+	}
+
+	version, header := qs.version, qs.header
+	if req.Height > qs.version {
+		return sdk.ErrInternal(fmt.Sprintf("query height %d is above the last committed height %d", req.Height, qs.version)).QueryResult()
+	}
+	if req.Height > 0 && req.Height != qs.version {
+		version = req.Height
+		header = abci.Header{ChainID: qs.header.ChainID, Height: req.Height}
+	}
+
+	var timeout <-chan time.Time
+	if app.queryPool.timeout > 0 {
+		timer := time.NewTimer(app.queryPool.timeout)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+
+	select {
+	case app.queryPool.workers <- struct{}{}:
+	case <-timeout:
+		return sdk.ErrInternal("no query worker available before the query timeout").QueryResult()
+	}
+
+	done := make(chan abci.ResponseQuery, 1)
+	cancel := make(chan struct{})
+	go func() {
+		defer func() { <-app.queryPool.workers }()
+		done <- app.meterQuery(req, func(meter sdk.GasMeter) abci.ResponseQuery {
+			ms, release, err := app.cms.CacheMultiStoreWithVersion(version)
+			if err != nil {
+				return sdk.ErrInternal(err.Error()).QueryResult()
+			}
+			// the version is not pruned until the query is done
+			defer release()
+			return app.runQuerier(ms, header, cancelGasMeter{meter, cancel}, querier, path, req)
+		})
+	}()
+
+	select {
+	case res := <-done:
+		return res
+	case <-timeout:
+		// the query stops at its next read, which frees its worker
+		close(cancel)
+		return sdk.ErrInternal(fmt.Sprintf("query %s timed out after %s", strings.Join(path, "/"), app.queryPool.timeout)).QueryResult()
+	}
+}
+
+// meterQuery runs the query with a gas meter limited by the query gas limit of the node,
+// and reports the gas consumed in the info of the response
+func (app *BaseApp) meterQuery(req abci.RequestQuery, query func(meter sdk.GasMeter) abci.ResponseQuery) (res abci.ResponseQuery) {
+	var meter sdk.GasMeter
+	if app.queryGasLimit > 0 {
+		meter = sdk.NewGasMeter(app.queryGasLimit)
+	} else {
+		meter = sdk.NewInfiniteGasMeter()
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			switch rType := r.(type) {
+			case sdk.ErrorOutOfGas:
+				res = sdk.ErrOutOfGas(fmt.Sprintf("query out of gas in location: %v, gas limit: %d",
+					rType.Descriptor, app.queryGasLimit)).QueryResult()
+			case errQueryCancelled:
+				res = sdk.ErrInternal("query cancelled after the query timeout").QueryResult()
+			default:
+				app.Logger.Error("query panicked", "path", req.Path, "err", r, "stack", string(debug.Stack()))
+				res = sdk.ErrInternal(fmt.Sprintf("recovered: %v", r)).QueryResult()
+			}
+		}
+		res.Info = sdk.QueryGasInfo(meter.GasConsumed())
+	}()
+
+	return query(meter)
+}
+
+// runQuerier runs the custom querier against a cache of the multistore
+func (app *BaseApp) runQuerier(ms sdk.CacheMultiStore, header abci.Header, meter sdk.GasMeter,
+	querier sdk.Querier, path []string, req abci.RequestQuery) abci.ResponseQuery {
+	ctx := sdk.NewContext(ms, header, true, app.Logger).
+		WithMinimumFees(app.minimumFees).
+		WithGasMeter(meter)
+
+	// Passes the rest of the path as an argument to the querier.
+	// For example, in the path "custom/gov/proposal/test", the gov querier gets []string{"proposal", "test"} as the path
+	resBytes, err := querier(ctx, path[2:], req)
+	if err != nil {
+		return abci.ResponseQuery{
+			Code:      uint32(err.Code()),
+			Codespace: string(err.Codespace()),
+			Log:       err.ABCILog(),
+		}
+	}
+	return abci.ResponseQuery{
+		Code:  uint32(sdk.CodeOK),
+		Value: resBytes,
+	}
+}
@@ -0,0 +1,146 @@
+package app
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/NPC-Chain/npcchub/server/config"
+	"github.com/pkg/errors"
+)
+
+const (
+	// StreamSinkFile writes the change feed to files rotated by size
+	StreamSinkFile = "file"
+	// StreamSinkUnix writes the change feed to a local Unix socket
+	StreamSinkUnix = "unix"
+
+	streamDialTimeout = 5 * time.Second
+	// a consumer not reading a block within the timeout is disconnected, so that it doesn't stall the commit
+	streamWriteTimeout = 5 * time.Second
+)
+
+// NewStreamSink returns the sink of the given kind. The path is the directory of the stream
+// files or the path of the Unix socket.
+func NewStreamSink(kind, path string, rotateSize int64) (StreamSink, error) {
+	if len(path) == 0 {
+		return nil, errors.Errorf("the path of the %s stream sink is not set", kind)
+	}
+	switch kind {
+	case StreamSinkFile:
+		return NewFileStreamSink(path, rotateSize)
+	case StreamSinkUnix:
+		return NewUnixStreamSink(path), nil
+	default:
+		return nil, errors.Errorf("unknown stream sink %s, expected %s or %s", kind, StreamSinkFile, StreamSinkUnix)
+	}
+}
+
+// FileStreamSink appends the blocks, one JSON object per line, to files named after the first
+// height they hold. A new file is started once the current one reaches the rotate size.
+type FileStreamSink struct {
+	dir        string
+	rotateSize int64
+
+	file *os.File
+	size int64
+}
+
+var _ StreamSink = (*FileStreamSink)(nil)
+
+// NewFileStreamSink returns a sink writing to files in dir
+func NewFileStreamSink(dir string, rotateSize int64) (*FileStreamSink, error) {
+	if rotateSize <= 0 {
+		rotateSize = config.DefaultStreamingRotateSize
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+	return &FileStreamSink{dir: dir, rotateSize: rotateSize}, nil
+}
+
+// WriteBlock implements the StreamSink interface
+func (fs *FileStreamSink) WriteBlock(height int64, bz []byte) error {
+	if fs.file != nil && fs.size >= fs.rotateSize {
+		if err := fs.Close(); err != nil {
+			return err
+		}
+	}
+	if fs.file == nil {
+		file, err := os.OpenFile(filepath.Join(fs.dir, fmt.Sprintf("block-%012d.jsonl", height)),
+			os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+		if err != nil {
+			return err
+		}
+		info, err := file.Stat()
+		if err != nil {
+			_ = file.Close()
+			return err
+		}
+		fs.file, fs.size = file, info.Size()
+	}
+
+	n, err := fs.file.Write(append(bz, '\n'))
+	fs.size += int64(n)
+	if err != nil {
+		return err
+	}
+	return fs.file.Sync()
+}
+
+// Close implements the StreamSink interface
+func (fs *FileStreamSink) Close() error {
+	if fs.file == nil {
+		return nil
+	}
+	err := fs.file.Close()
+	fs.file, fs.size = nil, 0
+	return err
+}
+
+// UnixStreamSink writes the blocks, one JSON object per line, to the Unix socket a local
+// consumer listens on. The connection is closed when a block can't be written within the
+// write timeout, and dialed again for the next block.
+type UnixStreamSink struct {
+	path string
+	conn net.Conn
+}
+
+var _ StreamSink = (*UnixStreamSink)(nil)
+
+// NewUnixStreamSink returns a sink writing to the Unix socket at path
+func NewUnixStreamSink(path string) *UnixStreamSink {
+	return &UnixStreamSink{path: path}
+}
+
+// WriteBlock implements the StreamSink interface
+func (us *UnixStreamSink) WriteBlock(_ int64, bz []byte) error {
+	if us.conn == nil {
+		conn, err := net.DialTimeout("unix", us.path, streamDialTimeout)
+		if err != nil {
+			return err
+		}
+		us.conn = conn
+	}
+	if err := us.conn.SetWriteDeadline(time.Now().Add(streamWriteTimeout)); err != nil {
+		_ = us.Close()
+		return err
+	}
+	if _, err := us.conn.Write(append(bz, '\n')); err != nil {
+		_ = us.Close()
+		return err
+	}
+	return nil
+}
+
+// Close implements the StreamSink interface
+func (us *UnixStreamSink) Close() error {
+	if us.conn == nil {
+		return nil
+	}
+	err := us.conn.Close()
+	us.conn = nil
+	return err
+}
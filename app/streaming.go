@@ -0,0 +1,151 @@
+package app
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/NPC-Chain/npcchub/codec"
+	sdk "github.com/NPC-Chain/npcchub/types"
+	abci "github.com/tendermint/tendermint/abci/types"
+)
+
+// StoreKVPair is a write made to a store, a deletion has a nil value
+type StoreKVPair struct {
+	Key    []byte `json:"key"`
+	Value  []byte `json:"value"`
+	Delete bool   `json:"delete"`
+}
+
+// StoreChanges holds the writes made to a store during a block, in the order they were flushed
+type StoreChanges struct {
+	Store string        `json:"store"`
+	Pairs []StoreKVPair `json:"pairs"`
+}
+
+// DeliverTxMessages is the ABCI request and response of a DeliverTx
+type DeliverTxMessages struct {
+	Request  abci.RequestDeliverTx  `json:"request"`
+	Response abci.ResponseDeliverTx `json:"response"`
+}
+
+// BlockStream is the change feed entry of a block: the ABCI requests and responses of the block
+// and the state changes they made, grouped by store name
+type BlockStream struct {
+	Height             int64                   `json:"height"`
+	BeginBlockRequest  abci.RequestBeginBlock  `json:"begin_block_request"`
+	BeginBlockResponse abci.ResponseBeginBlock `json:"begin_block_response"`
+	DeliverTxs         []DeliverTxMessages     `json:"deliver_txs"`
+	EndBlockRequest    abci.RequestEndBlock    `json:"end_block_request"`
+	EndBlockResponse   abci.ResponseEndBlock   `json:"end_block_response"`
+	Changes            []StoreChanges          `json:"changes"`
+}
+
+// StreamSink receives the change feed, one JSON encoded BlockStream per call
+type StreamSink interface {
+	WriteBlock(height int64, bz []byte) error
+	Close() error
+}
+
+// StreamingService collects the ABCI messages of a block and the writes they flush to the
+// committed stores, and hands them to a sink before the block is committed. A block is
+// streamed at least once: if the node stops before committing, the block is replayed and
+// streamed again, so consumers should skip the heights they already have.
+type StreamingService struct {
+	sink        StreamSink
+	haltOnError bool
+
+	mtx     sync.Mutex
+	block   BlockStream
+	changes map[string][]StoreKVPair
+}
+
+var _ sdk.WriteListener = (*StreamingService)(nil)
+
+// NewStreamingService returns a streaming service writing to the sink. If haltOnError is set,
+// the node stops when a block fails to be streamed instead of skipping it.
+func NewStreamingService(sink StreamSink, haltOnError bool) *StreamingService {
+	return &StreamingService{
+		sink:        sink,
+		haltOnError: haltOnError,
+		changes:     make(map[string][]StoreKVPair),
+	}
+}
+
+// OnWrite implements the WriteListener interface
+func (ss *StreamingService) OnWrite(storeKey sdk.StoreKey, key []byte, value []byte, delete bool) {
+	ss.mtx.Lock()
+	defer ss.mtx.Unlock()
+	name := storeKey.Name()
+	ss.changes[name] = append(ss.changes[name], StoreKVPair{Key: key, Value: value, Delete: delete})
+}
+
+// ListenBeginBlock records the BeginBlock messages and starts a new block
+func (ss *StreamingService) ListenBeginBlock(req abci.RequestBeginBlock, res abci.ResponseBeginBlock) {
+	ss.mtx.Lock()
+	defer ss.mtx.Unlock()
+	ss.block = BlockStream{
+		Height:             req.Header.Height,
+		BeginBlockRequest:  req,
+		BeginBlockResponse: res,
+	}
+}
+
+// ListenDeliverTx records the messages of a DeliverTx
+func (ss *StreamingService) ListenDeliverTx(req abci.RequestDeliverTx, res abci.ResponseDeliverTx) {
+	ss.mtx.Lock()
+	defer ss.mtx.Unlock()
+	ss.block.DeliverTxs = append(ss.block.DeliverTxs, DeliverTxMessages{Request: req, Response: res})
+}
+
+// ListenEndBlock records the EndBlock messages
+func (ss *StreamingService) ListenEndBlock(req abci.RequestEndBlock, res abci.ResponseEndBlock) {
+	ss.mtx.Lock()
+	defer ss.mtx.Unlock()
+	ss.block.EndBlockRequest = req
+	ss.block.EndBlockResponse = res
+}
+
+// Flush writes the block and the changes recorded since the previous flush to the sink.
+// The changes made before the first block, e.g. by InitChain, are streamed with it.
+func (ss *StreamingService) Flush() error {
+	ss.mtx.Lock()
+	defer ss.mtx.Unlock()
+
+	block := ss.block
+	names := make([]string, 0, len(ss.changes))
+	for name := range ss.changes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		block.Changes = append(block.Changes, StoreChanges{Store: name, Pairs: ss.changes[name]})
+	}
+	ss.block = BlockStream{}
+	ss.changes = make(map[string][]StoreKVPair)
+
+	bz, err := codec.Cdc.MarshalJSON(block)
+	if err != nil {
+		return err
+	}
+	return ss.sink.WriteBlock(block.Height, bz)
+}
+
+// Close closes the sink
+func (ss *StreamingService) Close() error {
+	return ss.sink.Close()
+}
+
+// streamBlock flushes the block to the streaming service, if any, before it is committed
+func (app *BaseApp) streamBlock() {
+	if app.streaming == nil {
+		return
+	}
+	height := app.deliverState.ctx.BlockHeight()
+	if err := app.streaming.Flush(); err != nil {
+		if app.streaming.haltOnError {
+			panic(fmt.Sprintf("failed to stream block %d: %v", height, err))
+		}
+		app.Logger.With("module", "iris/streaming").Error("failed to stream block, the block is skipped", "height", height, "err", err.Error())
+	}
+}
@@ -12,6 +12,10 @@ const MetricsSubsystem = "v0"
 
 type Metrics struct {
 	InvariantFailure metrics.Counter
+	// gas price of the txs accepted by CheckTx
+	CheckTxPriority metrics.Histogram
+	// txs rejected by the mempool policies of CheckTx
+	CheckTxRejected metrics.Counter
 }
 
 // PrometheusMetrics returns Metrics build using Prometheus client library.
@@ -26,11 +30,26 @@ func PrometheusMetrics(config *cfg.InstrumentationConfig) *Metrics {
 			Name:      "invariant_failure",
 			Help:      "invariant failure",
 		}, []string{"error"}),
+		CheckTxPriority: prometheus.NewHistogramFrom(stdprometheus.HistogramOpts{
+			Namespace: config.Namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "check_tx_priority",
+			Help:      "gas price in iris-atto of the txs accepted by CheckTx",
+			Buckets:   stdprometheus.ExponentialBuckets(1e9, 10, 8),
+		}, []string{}),
+		CheckTxRejected: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: config.Namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "check_tx_rejected",
+			Help:      "txs rejected by CheckTx for an insufficient fee or too many pending txs of the sender",
+		}, []string{"reason"}),
 	}
 }
 
 func NopMetrics() *Metrics {
 	return &Metrics{
 		InvariantFailure: discard.NewCounter(),
+		CheckTxPriority:  discard.NewHistogram(),
+		CheckTxRejected:  discard.NewCounter(),
 	}
 }
@@ -234,6 +234,7 @@ func (p *ProtocolV0) configKeepers() {
 		p.distrKeeper,
 		p.guardianKeeper,
 		&stakeKeeper,
+		p.router,
 		gov.DefaultCodespace,
 		gov.PrometheusMetrics(p.config),
 	)
@@ -270,6 +271,7 @@ func (p *ProtocolV0) configRouters() {
 
 	p.queryRouter.
 		AddRoute(protocol.AccountRoute, auth.NewQuerier(p.accountMapper)).
+		AddRoute(protocol.FeeRoute, auth.NewFeeQuerier(p.feeKeeper)).
 		AddRoute(protocol.GovRoute, gov.NewQuerier(p.govKeeper)).
 		AddRoute(protocol.StakeRoute, stake.NewQuerier(p.StakeKeeper, p.cdc)).
 		AddRoute(protocol.DistrRoute, distr.NewQuerier(p.distrKeeper)).
@@ -280,11 +282,31 @@ func (p *ProtocolV0) configRouters() {
 
 // configure all Stores
 func (p *ProtocolV0) configFeeHandlers() {
-	p.anteHandlers = []sdk.AnteHandler{auth.NewAnteHandler(p.accountMapper, p.feeKeeper)}
+	p.anteHandlers = []sdk.AnteHandler{p.checkTxMetrics(auth.NewAnteHandler(p.accountMapper, p.feeKeeper))}
 	p.feeRefundHandler = auth.NewFeeRefundHandler(p.accountMapper, p.feeKeeper)
 	p.feePreprocessHandler = auth.NewFeePreprocessHandler(p.feeKeeper)
 }
 
+// checkTxMetrics wraps the ante handler to record the priority of the txs accepted by CheckTx
+// and the txs rejected by the mempool policies
+func (p *ProtocolV0) checkTxMetrics(anteHandler sdk.AnteHandler) sdk.AnteHandler {
+	return func(ctx sdk.Context, tx sdk.Tx, simulate bool) (newCtx sdk.Context, res sdk.Result, abort bool) {
+		newCtx, res, abort = anteHandler(ctx, tx, simulate)
+		if !ctx.IsCheckTx() || simulate {
+			return
+		}
+		switch {
+		case !abort:
+			p.metrics.CheckTxPriority.Observe(float64(newCtx.Priority()))
+		case res.Code == sdk.CodeInsufficientFee:
+			p.metrics.CheckTxRejected.With("reason", "insufficient_fee").Add(1)
+		case res.Code == sdk.CodeTooManyPendingTxs:
+			p.metrics.CheckTxRejected.With("reason", "too_many_pending_txs").Add(1)
+		}
+		return
+	}
+}
+
 // configure all Stores
 func (p *ProtocolV0) GetKVStoreKeyList() []*sdk.KVStoreKey {
 	return []*sdk.KVStoreKey{
@@ -319,6 +341,9 @@ func (p *ProtocolV0) BeginBlocker(ctx sdk.Context, req abci.RequestBeginBlock) a
 
 	slashTags := slashing.BeginBlocker(ctx, req, p.slashingKeeper)
 
+	// migrate the service definitions stored before their versioning
+	service.BeginBlocker(ctx, p.serviceKeeper)
+
 	ctx.CoinFlowTags().TagWrite()
 
 	tags = tags.AppendTags(slashTags)
@@ -334,6 +359,7 @@ func (p *ProtocolV0) EndBlocker(ctx sdk.Context, req abci.RequestEndBlock) abci.
 	tags = tags.AppendTags(service.EndBlocker(ctx, p.serviceKeeper))
 	tags = tags.AppendTags(upgrade.EndBlocker(ctx, p.upgradeKeeper))
 	validatorUpdates := stake.EndBlocker(ctx, p.StakeKeeper)
+	tags = tags.AppendTags(auth.EndBlocker(ctx, p.feeKeeper, p.bankKeeper))
 	if p.trackCoinFlow {
 		ctx.CoinFlowTags().TagWrite()
 		tags = tags.AppendTags(ctx.CoinFlowTags().GetTags())
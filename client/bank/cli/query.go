@@ -10,6 +10,7 @@ import (
 	"github.com/NPC-Chain/npcchub/app/v1/stake"
 	"github.com/NPC-Chain/npcchub/client/context"
 	"github.com/NPC-Chain/npcchub/codec"
+	fee "github.com/NPC-Chain/npcchub/modules/auth"
 	sdk "github.com/NPC-Chain/npcchub/types"
 	"github.com/spf13/cobra"
 )
@@ -124,3 +125,29 @@ func GetCmdQueryTokenStats(cdc *codec.Codec, decoder auth.AccountDecoder) *cobra
 
 	return cmd
 }
+
+// GetCmdQueryBaseGasPrice performs the base gas price query
+func GetCmdQueryBaseGasPrice(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "base-gas-price",
+		Short:   "Query the base gas price in iris-atto, the minimum gas price of a tx, burned from its fee",
+		Example: "iriscli bank base-gas-price",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			res, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s", protocol.FeeRoute, fee.QueryBaseGasPrice), nil)
+			if err != nil {
+				return err
+			}
+
+			var baseGasPrice fee.BaseGasPrice
+			err = cdc.UnmarshalJSON(res, &baseGasPrice)
+			if err != nil {
+				return err
+			}
+			return cliCtx.PrintOutput(baseGasPrice)
+		},
+	}
+
+	return cmd
+}
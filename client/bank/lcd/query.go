@@ -14,6 +14,7 @@ import (
 	"github.com/NPC-Chain/npcchub/client/context"
 	"github.com/NPC-Chain/npcchub/client/utils"
 	"github.com/NPC-Chain/npcchub/codec"
+	fee "github.com/NPC-Chain/npcchub/modules/auth"
 	sdk "github.com/NPC-Chain/npcchub/types"
 )
 
@@ -120,3 +121,21 @@ func QueryTokenStatsRequestHandlerFn(cdc *codec.Codec, decoder auth.AccountDecod
 		utils.PostProcessResponse(w, cdc, tokenStats, cliCtx.Indent)
 	}
 }
+
+// QueryBaseGasPriceRequestHandlerFn performs the base gas price query
+func QueryBaseGasPriceRequestHandlerFn(cdc *codec.Codec, cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		res, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s", protocol.FeeRoute, fee.QueryBaseGasPrice), nil)
+		if err != nil {
+			utils.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		var baseGasPrice fee.BaseGasPrice
+		if err = cdc.UnmarshalJSON(res, &baseGasPrice); err != nil {
+			utils.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		utils.PostProcessResponse(w, cdc, baseGasPrice, cliCtx.Indent)
+	}
+}
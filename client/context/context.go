@@ -34,6 +34,7 @@ type CLIContext struct {
 	OutputFormat  string
 	Height        int64
 	NodeURI       string
+	ArchiveURI    string
 	AccountStore  string
 	TrustNode     bool
 	UseLedger     bool
@@ -65,6 +66,7 @@ func NewCLIContext() CLIContext {
 	return CLIContext{
 		Client:        rpc,
 		NodeURI:       nodeURI,
+		ArchiveURI:    viper.GetString(client.FlagArchiveNode),
 		AccountStore:  protocol.AccountStore,
 		Height:        viper.GetInt64(client.FlagHeight),
 		OutputFormat:  viper.GetString(cli.OutputFlag),
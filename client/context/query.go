@@ -61,6 +61,28 @@ func (cliCtx CLIContext) QuerySubspace(subspace []byte, storeName string) (res [
 	return
 }
 
+// QuerySubspacePage queries a page of at most limit pairs of the subspace, starting
+// from startKey. The next key of the result is the start key of the following page.
+func (cliCtx CLIContext) QuerySubspacePage(subspace []byte, storeName string, startKey []byte, limit int) (page store.SubspacePage, err error) {
+	params := store.QuerySubspacePageParams{
+		Subspace: subspace,
+		StartKey: startKey,
+		Limit:    limit,
+	}
+	bz, err := cliCtx.Codec.MarshalJSON(params)
+	if err != nil {
+		return page, err
+	}
+
+	resRaw, err := cliCtx.queryStore(bz, storeName, "subspace_page")
+	if err != nil {
+		return page, err
+	}
+
+	err = cliCtx.Codec.UnmarshalBinaryLengthPrefixed(resRaw, &page)
+	return
+}
+
 // GetAccount queries for an account given an address and a block height. An
 // error is returned if the query or decoding fails.
 func (cliCtx CLIContext) GetAccount(address []byte) (account auth.BaseAccount, err error) {
@@ -176,6 +198,15 @@ func (cliCtx CLIContext) query(path string, key cmn.HexBytes) (res []byte, err e
 		return res, err
 	}
 
+	// fall back to the archive node for the heights pruned by the node
+	if isHeightPruned(result.Response) && cliCtx.ArchiveURI != "" {
+		archive := rpcclient.NewHTTP(cliCtx.ArchiveURI, "/websocket")
+		result, err = archive.ABCIQueryWithOptions(path, key, opts)
+		if err != nil {
+			return res, err
+		}
+	}
+
 	resp := result.Response
 	if !resp.IsOK() {
 		return res, errors.Errorf(resp.Log)
@@ -194,6 +225,11 @@ func (cliCtx CLIContext) query(path string, key cmn.HexBytes) (res []byte, err e
 	return resp.Value, nil
 }
 
+// isHeightPruned returns whether the query failed because the height has been pruned
+func isHeightPruned(resp abci.ResponseQuery) bool {
+	return resp.Code == uint32(sdk.CodeHeightPruned) && resp.Codespace == string(sdk.CodespaceRoot)
+}
+
 // Verify verifies the consensus proof at given height.
 func (cliCtx CLIContext) Verify(height int64) (tmtypes.SignedHeader, error) {
 	check, err := tmliteProxy.GetCertifiedCommit(height, cliCtx.Client, cliCtx.Verifier)
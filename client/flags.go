@@ -3,7 +3,9 @@ package client
 import (
 	"fmt"
 	"strconv"
+	"strings"
 
+	"github.com/NPC-Chain/npcchub/crypto/keys"
 	"github.com/spf13/cobra"
 )
 
@@ -19,6 +21,7 @@ const (
 	FlagUseLedger      = "ledger"
 	FlagChainID        = "chain-id"
 	FlagNode           = "node"
+	FlagArchiveNode    = "archive-node"
 	FlagHeight         = "height"
 	FlagGas            = "gas"
 	FlagTrustNode      = "trust-node"
@@ -37,6 +40,7 @@ const (
 	FlagIndentResponse = "indent"
 	FlagDryRun         = "dry-run"
 	FlagGasAdjustment  = "gas-adjustment"
+	FlagKeyringBackend = "keyring-backend"
 )
 
 // GetCommands adds common flags to query commands
@@ -48,6 +52,7 @@ func GetCommands(cmds ...*cobra.Command) []*cobra.Command {
 		c.Flags().String(FlagChainID, "", "Chain ID of tendermint node")
 		c.Flags().String(FlagNode, "tcp://localhost:26657", "<host>:<port> to tendermint rpc interface for this chain")
 		c.Flags().Int64(FlagHeight, 0, "block height to query, omit to get most recent provable block")
+		c.Flags().String(FlagArchiveNode, "", "<host>:<port> to tendermint rpc interface of an archive node, queried when the height has been pruned by --node")
 	}
 	return cmds
 }
@@ -75,6 +80,7 @@ func PostCommands(cmds ...*cobra.Command) []*cobra.Command {
 		c.Flags().String(FlagFromAddr, "", "Specify from address in generate-only mode")
 		c.Flags().Bool(FlagDryRun, false, "ignore the --gas flag and perform a simulation of a transaction, but don't broadcast it")
 		c.Flags().Float64(FlagGasAdjustment, DefaultGasAdjustment, "adjustment factor to be multiplied against the estimate returned by the tx simulation; if the gas limit is set manually this flag is ignored ")
+		c.Flags().String(FlagKeyringBackend, keys.BackendDB, fmt.Sprintf("Keyring backend holding the keys: %s", strings.Join(keys.Backends, ", ")))
 	}
 	return cmds
 }
@@ -8,6 +8,7 @@ const (
 	flagDeposit      = "deposit"
 	flagVoter        = "voter"
 	flagOption       = "option"
+	flagOptions      = "options"
 	flagDepositor    = "depositor"
 	flagStatus       = "status"
 	flagNumLimit     = "limit"
@@ -23,6 +24,16 @@ const (
 	flagSoftware     = "software"
 	flagSwitchHeight = "switch-height"
 	flagThreshold    = "threshold"
+	flagMsgs         = "msgs"
+	flagExpedited    = "expedited"
+
+	flagHaltHeight    = "halt-height"
+	flagExportGenesis = "export-genesis"
+
+	flagGuardianOp          = "guardian-op"
+	flagGuardianRole        = "guardian-role"
+	flagGuardianAddress     = "guardian-address"
+	flagGuardianDescription = "guardian-description"
 
 	//for addTokenProposal
 	flagTokenSymbol          = "token-symbol"
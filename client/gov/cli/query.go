@@ -5,6 +5,7 @@ import (
 
 	"github.com/NPC-Chain/npcchub/app/protocol"
 	"github.com/NPC-Chain/npcchub/app/v1/gov"
+	"github.com/NPC-Chain/npcchub/app/v1/params"
 	"github.com/NPC-Chain/npcchub/client/context"
 	client "github.com/NPC-Chain/npcchub/client/gov"
 	"github.com/NPC-Chain/npcchub/codec"
@@ -322,3 +323,54 @@ func GetCmdQueryTally(cdc *codec.Codec) *cobra.Command {
 
 	return cmd
 }
+
+// GetCmdSimulateParams implements the command to validate a parameter change against the current state.
+func GetCmdSimulateParams(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "simulate-params",
+		Short:   "Validate the params of a ParameterProposal against the current state without submitting it",
+		Example: "iriscli gov simulate-params --param='slashing/SignedBlocksWindow=1000' --param='slashing/MinSignedPerWindow=0.8'",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			govParams, err := getParamsFromStrings(viper.GetStringSlice(flagParam))
+			if err != nil {
+				return err
+			}
+			for _, param := range govParams {
+				if err := client.ValidateParam(param); err != nil {
+					return err
+				}
+			}
+
+			params := gov.QuerySimulateParamsParams{
+				Params: govParams,
+			}
+			bz, err := cdc.MarshalJSON(params)
+			if err != nil {
+				return err
+			}
+
+			res, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/simulate_params", protocol.GovRoute), bz)
+			if err != nil {
+				return err
+			}
+
+			var paramSets []params.ParamSet
+			if err := cdc.UnmarshalJSON(res, &paramSets); err != nil {
+				return err
+			}
+
+			for _, paramSet := range paramSets {
+				if err := cliCtx.PrintOutput(paramSet); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringSlice(flagParam, []string{}, "parameter to change,eg. module/key=value, can be repeated")
+	cmd.MarkFlagRequired(flagParam)
+	return cmd
+}
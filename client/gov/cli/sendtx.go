@@ -2,6 +2,7 @@ package cli
 
 import (
 	"fmt"
+	"io/ioutil"
 	"os"
 	"strings"
 
@@ -22,7 +23,7 @@ func GetCmdSubmitProposal(cdc *codec.Codec) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:     "submit-proposal",
 		Short:   "Submit a proposal along with an initial deposit",
-		Example: "iriscli gov submit-proposal --chain-id=<chain-id> --from=<key-name> --fee=0.3iris --type=Parameter --description=test --title=test-proposal --param='mint/Inflation=0.050'",
+		Example: "iriscli gov submit-proposal --chain-id=<chain-id> --from=<key-name> --fee=0.3iris --type=Parameter --description=test --title=test-proposal --param='slashing/SignedBlocksWindow=1000' --param='slashing/MinSignedPerWindow=0.8'",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			title := viper.GetString(flagTitle)
 			description := viper.GetString(flagDescription)
@@ -50,19 +51,21 @@ func GetCmdSubmitProposal(cdc *codec.Codec) *cobra.Command {
 			}
 			var params gov.Params
 			if proposalType == gov.ProposalTypeParameter {
-				paramStr := viper.GetString(flagParam)
-				params, err = getParamFromString(paramStr)
+				params, err = getParamsFromStrings(viper.GetStringSlice(flagParam))
 				if err != nil {
 					return err
 				}
-				if len(params) != 1 {
-					return errors.New("the length of ParameterProposal's param should be one")
+				if len(params) == 0 {
+					return errors.New("ParameterProposal should contain at least one param")
 				}
-				if err := client.ValidateParam(params[0]); err != nil {
-					return err
+				for _, param := range params {
+					if err := client.ValidateParam(param); err != nil {
+						return err
+					}
 				}
 			}
 			msg := gov.NewMsgSubmitProposal(title, description, proposalType, fromAddr, amount, params)
+			msg.Expedited = viper.GetBool(flagExpedited)
 			if proposalType == gov.ProposalTypeCommunityTaxUsage {
 				usageStr := viper.GetString(flagUsage)
 				usage, err := gov.UsageTypeFromString(usageStr)
@@ -111,6 +114,49 @@ func GetCmdSubmitProposal(cdc *codec.Codec) *cobra.Command {
 				return utils.SendOrPrintTx(txCtx, cliCtx, []sdk.Msg{msg})
 			}
 
+			if proposalType == gov.ProposalTypeMsgExecution {
+				msgs, err := getMsgsFromFile(cdc, viper.GetString(flagMsgs))
+				if err != nil {
+					return err
+				}
+				msg := gov.NewMsgSubmitMsgExecutionProposal(msg, msgs)
+				if err := msg.ValidateBasic(); err != nil {
+					return err
+				}
+				return utils.SendOrPrintTx(txCtx, cliCtx, []sdk.Msg{msg})
+			}
+
+			if proposalType == gov.ProposalTypeSystemHalt {
+				msg := gov.NewMsgSubmitSystemHaltProposal(msg, viper.GetInt64(flagHaltHeight), viper.GetBool(flagExportGenesis))
+				if err := msg.ValidateBasic(); err != nil {
+					return err
+				}
+				return utils.SendOrPrintTx(txCtx, cliCtx, []sdk.Msg{msg})
+			}
+
+			if proposalType == gov.ProposalTypeGuardianChange {
+				op, err := gov.GuardianOpFromString(viper.GetString(flagGuardianOp))
+				if err != nil {
+					return err
+				}
+				role, err := gov.GuardianRoleFromString(viper.GetString(flagGuardianRole))
+				if err != nil {
+					return err
+				}
+				address, err := sdk.AccAddressFromBech32(viper.GetString(flagGuardianAddress))
+				if err != nil {
+					return err
+				}
+				guardianChange := gov.GuardianChange{
+					Op:          op,
+					Role:        role,
+					Address:     address,
+					Description: viper.GetString(flagGuardianDescription),
+				}
+				msg := gov.NewMsgSubmitGuardianChangeProposal(msg, guardianChange)
+				return utils.SendOrPrintTx(txCtx, cliCtx, []sdk.Msg{msg})
+			}
+
 			if proposalType == gov.ProposalTypeTokenAddition {
 				symbol := viper.GetString(flagTokenSymbol)
 				canonicalSymbol := viper.GetString(flagTokenCanonicalSymbol)
@@ -127,9 +173,10 @@ func GetCmdSubmitProposal(cdc *codec.Codec) *cobra.Command {
 
 	cmd.Flags().String(flagTitle, "", "title of proposal")
 	cmd.Flags().String(flagDescription, "", "description of proposal")
-	cmd.Flags().String(flagProposalType, "", "proposalType of proposal,eg:PlainText/Parameter/SoftwareUpgrade/SystemHalt/CommunityTaxUsage/TokenAddition")
+	cmd.Flags().String(flagProposalType, "", "proposalType of proposal,eg:PlainText/Parameter/SoftwareUpgrade/SystemHalt/CommunityTaxUsage/TokenAddition/MsgExecution/GuardianChange")
 	cmd.Flags().String(flagDeposit, "", "deposit of proposal(at least 30% of MinDeposit)")
-	cmd.Flags().String(flagParam, "", "parameter of proposal,eg. key=value")
+	cmd.Flags().Bool(flagExpedited, false, "submit an expedited proposal with a shorter voting period and a stricter threshold, it falls back to the normal voting rules if the expedited tally fails")
+	cmd.Flags().StringSlice(flagParam, []string{}, "parameter of proposal,eg. module/key=value, can be repeated to change several params atomically")
 	cmd.Flags().String(flagUsage, "", "the transaction fee tax usage type, valid values can be Burn, Distribute and Grant")
 	cmd.Flags().String(flagPercent, "", "percent of transaction fee tax pool to use, integer or decimal >0 and <=1")
 	cmd.Flags().String(flagDestAddress, "", "the destination trustee address")
@@ -139,6 +186,19 @@ func GetCmdSubmitProposal(cdc *codec.Codec) *cobra.Command {
 	cmd.Flags().String(flagSwitchHeight, "0", "the switchheight of the new protocol")
 	cmd.Flags().String(flagThreshold, "0.8", "the upgrade signal threshold of the software upgrade")
 
+	//for SystemHaltProposal
+	cmd.Flags().Int64(flagHaltHeight, 0, "the height to halt the chain at, 0 means SystemHaltPeriod blocks after the proposal passes")
+	cmd.Flags().Bool(flagExportGenesis, false, "whether every node exports the genesis for zero height at the halt height and then stops")
+
+	//for MsgExecutionProposal
+	cmd.Flags().String(flagMsgs, "", fmt.Sprintf("path of a JSON file with the msgs to execute, the signer of every msg must be %s", gov.GovModuleAccAddr))
+
+	//for GuardianChangeProposal
+	cmd.Flags().String(flagGuardianOp, "", "the guardian change operation, valid values can be Add and Delete")
+	cmd.Flags().String(flagGuardianRole, "", "the role of the guardian, valid values can be Profiler and Trustee")
+	cmd.Flags().String(flagGuardianAddress, "", "the address of the guardian to add or delete")
+	cmd.Flags().String(flagGuardianDescription, "", "the description of the guardian to add")
+
 	//for TokenAdditionProposal
 	cmd.Flags().String(flagTokenSymbol, "", "the asset symbol. Once created, it cannot be modified")
 	cmd.Flags().String(flagTokenCanonicalSymbol, "", "the source symbol of a external asset")
@@ -168,6 +228,33 @@ func getParamFromString(paramsStr string) (gov.Params, error) {
 	return govParams, nil
 }
 
+func getParamsFromStrings(paramStrs []string) (gov.Params, error) {
+	var govParams gov.Params
+	for _, paramStr := range paramStrs {
+		param, err := getParamFromString(paramStr)
+		if err != nil {
+			return gov.Params{}, err
+		}
+		govParams = append(govParams, param...)
+	}
+	return govParams, nil
+}
+
+func getMsgsFromFile(cdc *codec.Codec, path string) ([]sdk.Msg, error) {
+	if len(path) == 0 {
+		return nil, errors.New("the msgs file of MsgExecutionProposal is required")
+	}
+	bz, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var msgs []sdk.Msg
+	if err := cdc.UnmarshalJSON(bz, &msgs); err != nil {
+		return nil, err
+	}
+	return msgs, nil
+}
+
 // GetCmdDeposit implements depositing tokens for an active proposal.
 func GetCmdDeposit(cdc *codec.Codec) *cobra.Command {
 	cmd := &cobra.Command{
@@ -263,3 +350,53 @@ func GetCmdVote(cdc *codec.Codec) *cobra.Command {
 	cmd.MarkFlagRequired(flagOption)
 	return cmd
 }
+
+// GetCmdVoteWeighted implements creating a new weighted vote command.
+func GetCmdVoteWeighted(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "vote-weighted",
+		Short:   "Vote for an active proposal with the voting power split across several options, the weights must sum up to 1",
+		Example: "iriscli gov vote-weighted --chain-id=<chain-id> --from=<key-name> --fee=0.3iris --proposal-id=1 --options=Yes=0.7,No=0.3",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().
+				WithCodec(cdc).
+				WithLogger(os.Stdout).
+				WithAccountDecoder(utils.GetAccountDecoder(cdc))
+			txCtx := utils.NewTxContextFromCLI().WithCodec(cdc).
+				WithCliCtx(cliCtx)
+
+			voterAddr, err := cliCtx.GetFromAddress()
+			if err != nil {
+				return err
+			}
+
+			proposalID := uint64(viper.GetInt64(flagProposalID))
+			options, err := gov.WeightedVoteOptionsFromString(client.NormalizeWeightedVoteOptions(viper.GetString(flagOptions)))
+			if err != nil {
+				return err
+			}
+
+			msg := gov.NewMsgVoteWeighted(voterAddr, proposalID, options)
+
+			err = msg.ValidateBasic()
+			if err != nil {
+				return err
+			}
+
+			fmt.Printf("Vote[Voter:%s,ProposalID:%d,Options:%s]",
+				voterAddr.String(), msg.ProposalID, msg.Options.String(),
+			)
+			// Build and sign the transaction, then broadcast to a Tendermint
+			// node.
+			cliCtx.PrintResponse = true
+
+			return utils.SendOrPrintTx(txCtx, cliCtx, []sdk.Msg{msg})
+		},
+	}
+
+	cmd.Flags().String(flagProposalID, "", "proposalID of proposal voting on")
+	cmd.Flags().String(flagOptions, "", "weighted vote options, eg. Yes=0.7,No=0.3")
+	cmd.MarkFlagRequired(flagProposalID)
+	cmd.MarkFlagRequired(flagOptions)
+	return cmd
+}
@@ -60,8 +60,15 @@ func queryDepositsHandlerFn(cdc *codec.Codec, cliCtx context.CLIContext) http.Ha
 			return
 		}
 
+		page, size, ok := utils.ParsePaginationParamsOrReturnBadRequest(w, r)
+		if !ok {
+			return
+		}
+
 		params := gov.QueryDepositsParams{
 			ProposalID: proposalID,
+			Page:       page,
+			Size:       size,
 		}
 
 		bz, err := cdc.MarshalJSON(params)
@@ -228,8 +235,15 @@ func queryVotesOnProposalHandlerFn(cdc *codec.Codec, cliCtx context.CLIContext)
 			return
 		}
 
+		page, size, ok := utils.ParsePaginationParamsOrReturnBadRequest(w, r)
+		if !ok {
+			return
+		}
+
 		params := gov.QueryVotesParams{
 			ProposalID: proposalID,
+			Page:       page,
+			Size:       size,
 		}
 		bz, err := cdc.MarshalJSON(params)
 		if err != nil {
@@ -1,6 +1,8 @@
 package gov
 
 import (
+	"strings"
+
 	"github.com/NPC-Chain/npcchub/app/v1/asset"
 	"github.com/NPC-Chain/npcchub/app/v1/auth"
 	distr "github.com/NPC-Chain/npcchub/app/v1/distribution"
@@ -47,6 +49,19 @@ func NormalizeVoteOption(option string) string {
 	return option
 }
 
+// NormalizeWeightedVoteOptions - normalize the options of user specified weighted vote options, eg. yes=0.7,no=0.3
+func NormalizeWeightedVoteOptions(options string) string {
+	var normalized []string
+	for _, option := range strings.Split(options, ",") {
+		fields := strings.Split(strings.TrimSpace(option), "=")
+		if len(fields) == 2 {
+			fields[0] = NormalizeVoteOption(strings.TrimSpace(fields[0]))
+		}
+		normalized = append(normalized, strings.Join(fields, "="))
+	}
+	return strings.Join(normalized, ",")
+}
+
 //NormalizeProposalType - normalize user specified proposal type
 func NormalizeProposalType(proposalType string) string {
 	switch proposalType {
@@ -58,8 +73,12 @@ func NormalizeProposalType(proposalType string) string {
 		return "SystemHalt"
 	case "CommunityTaxUsage", "community_tax_usage":
 		return "CommunityTaxUsage"
+	case "GuardianChange", "guardian_change":
+		return "GuardianChange"
 	case "TokenAddition", "token_addition":
 		return "TokenAddition"
+	case "MsgExecution", "msg_execution":
+		return "MsgExecution"
 	}
 	return proposalType
 }
@@ -75,6 +94,8 @@ func NormalizeProposalStatus(status string) string {
 		return "Passed"
 	case "Rejected", "rejected":
 		return "Rejected"
+	case "Failed", "failed":
+		return "Failed"
 	}
 	return status
 }
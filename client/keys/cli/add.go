@@ -10,7 +10,6 @@ import (
 	"github.com/NPC-Chain/npcchub/client/keys"
 	ccrypto "github.com/NPC-Chain/npcchub/crypto"
 	cryptokeys "github.com/NPC-Chain/npcchub/crypto/keys"
-	"github.com/NPC-Chain/npcchub/crypto/keystore"
 	sdk "github.com/NPC-Chain/npcchub/types"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
@@ -22,6 +21,7 @@ import (
 
 const (
 	flagType     = "type"
+	flagAlgo     = "algo"
 	flagRecover  = "recover"
 	flagNoBackup = "no-backup"
 	flagDryRun   = "dry-run"
@@ -30,6 +30,12 @@ const (
 	flagMultisig = "multisig"
 	flagNoSort   = "nosort"
 	flagKeystore = "keystore"
+
+	flagRemote        = "remote"
+	flagRemoteKey     = "remote-key"
+	flagRemoteCACert  = "remote-ca-cert"
+	flagRemoteCert    = "remote-cert"
+	flagRemoteCertKey = "remote-cert-key"
 )
 
 func addKeyCommand() *cobra.Command {
@@ -46,7 +52,9 @@ phrase, otherwise, a new key will be generated.`,
 	cmd.Flags().Uint(flagMultiSigThreshold, 1, "K out of N required signatures. For use in conjunction with --multisig")
 	cmd.Flags().Bool(flagNoSort, false, "Keys passed to --multisig are taken in the order they're supplied")
 	cmd.Flags().String(FlagPublicKey, "", "Parse a public key in bech32 format and save it to disk")
-	cmd.Flags().StringP(flagType, "t", "secp256k1", "Type of private key (secp256k1|ed25519)")
+	cmd.Flags().String(flagAlgo, string(cryptokeys.Secp256k1), "Signing algorithm of the key (secp256k1|ed25519|secp256r1)")
+	cmd.Flags().StringP(flagType, "t", string(cryptokeys.Secp256k1), "Type of private key (secp256k1|ed25519|secp256r1)")
+	cmd.Flags().MarkDeprecated(flagType, "use --algo")
 	cmd.Flags().Bool(client.FlagUseLedger, false, "Store a local reference to a private key on a Ledger device")
 	cmd.Flags().Bool(flagRecover, false, "Provide seed phrase to recover existing key instead of creating")
 	cmd.Flags().String(flagKeystore, "", "Provide keystore file to recover existing key instead of creating. For use in conjunction with --recover")
@@ -54,17 +62,27 @@ phrase, otherwise, a new key will be generated.`,
 	cmd.Flags().Bool(flagDryRun, false, "Perform action, but don't add key to local keystore")
 	cmd.Flags().Uint32(flagAccount, 0, "Account number for HD derivation")
 	cmd.Flags().Uint32(flagIndex, 0, "Index number for HD derivation")
+	cmd.Flags().String(flagRemote, "", "Store a reference to a key of the remote signer at this URL, https://host:port")
+	cmd.Flags().String(flagRemoteKey, "", "Name of the key in the remote signer, the key name by default")
+	cmd.Flags().String(flagRemoteCACert, "", "CA certificate file the remote signer is verified with")
+	cmd.Flags().String(flagRemoteCert, "", "Certificate file presented to the remote signer")
+	cmd.Flags().String(flagRemoteCertKey, "", "Key file of the certificate presented to the remote signer")
 	cmd.Flags().Bool(client.FlagIndentResponse, false, "Add indent to JSON response")
 	return cmd
 }
 
 // nolint: gocyclo
 // TODO remove the above when addressing #1446
-func runAddCmd(_ *cobra.Command, args []string) error {
+func runAddCmd(cmd *cobra.Command, args []string) error {
 	var kb cryptokeys.Keybase
 	var err error
 	var name, pass string
 
+	algo, err := signingAlgo(cmd)
+	if err != nil {
+		return err
+	}
+
 	buf := keys.BufferStdin()
 	if viper.GetBool(flagDryRun) {
 		// we throw this away, so don't enforce args,
@@ -132,6 +150,26 @@ func runAddCmd(_ *cobra.Command, args []string) error {
 			return nil
 		}
 
+		if endpoint := viper.GetString(flagRemote); len(endpoint) != 0 {
+			remoteKey := viper.GetString(flagRemoteKey)
+			if len(remoteKey) == 0 {
+				remoteKey = name
+			}
+			info, err := kb.CreateRemote(name, cryptokeys.RemoteSigner{
+				Endpoint:   endpoint,
+				Key:        remoteKey,
+				CACert:     viper.GetString(flagRemoteCACert),
+				ClientCert: viper.GetString(flagRemoteCert),
+				ClientKey:  viper.GetString(flagRemoteCertKey),
+			})
+			if err != nil {
+				return err
+			}
+			viper.Set(flagNoBackup, true)
+			printCreate(info, "")
+			return nil
+		}
+
 		// ask for a password when generating a local key
 		if viper.GetString(FlagPublicKey) == "" && !viper.GetBool(client.FlagUseLedger) {
 			pass, err = keys.GetCheckPassword(
@@ -160,7 +198,6 @@ func runAddCmd(_ *cobra.Command, args []string) error {
 		account := uint32(viper.GetInt(flagAccount))
 		index := uint32(viper.GetInt(flagIndex))
 		path := ccrypto.DerivationPath{44, 118, account, 0, index}
-		algo := cryptokeys.SigningAlgo(viper.GetString(flagType))
 		info, err := kb.CreateLedger(name, path, algo)
 		if err != nil {
 			return err
@@ -180,11 +217,11 @@ func runAddCmd(_ *cobra.Command, args []string) error {
 				}
 			}
 
-			km, err := keystore.NewKeyStoreKeyManager(keystoreFile, passphrase)
+			privKey, err := readKeystore(keystoreFile, passphrase, algo)
 			if err != nil {
 				return err
 			}
-			info, err := kb.ImportPrivateKey(name, pass, km.GetPrivKey())
+			info, err := kb.ImportPrivateKey(name, pass, privKey)
 			if err != nil {
 				return err
 			}
@@ -197,7 +234,12 @@ func runAddCmd(_ *cobra.Command, args []string) error {
 			if err != nil {
 				return err
 			}
-			info, err := kb.CreateKey(name, seed, pass)
+			var info cryptokeys.Info
+			if algo == cryptokeys.Secp256k1 {
+				info, err = kb.CreateKey(name, seed, pass)
+			} else {
+				info, err = kb.CreateAccount(name, seed, "", pass, cryptokeys.DefaultHDPath(algo), algo)
+			}
 			if err != nil {
 				return err
 			}
@@ -206,7 +248,6 @@ func runAddCmd(_ *cobra.Command, args []string) error {
 			printCreate(info, "")
 		}
 	} else {
-		algo := cryptokeys.SigningAlgo(viper.GetString(flagType))
 		info, seed, err := kb.CreateMnemonic(name, cryptokeys.English, pass, algo)
 		if err != nil {
 			return err
@@ -216,6 +257,18 @@ func runAddCmd(_ *cobra.Command, args []string) error {
 	return nil
 }
 
+// signingAlgo returns the algo of --algo, or of the deprecated --type if it is set
+func signingAlgo(cmd *cobra.Command) (cryptokeys.SigningAlgo, error) {
+	algo := cryptokeys.SigningAlgo(viper.GetString(flagAlgo))
+	if cmd.Flags().Changed(flagType) {
+		algo = cryptokeys.SigningAlgo(viper.GetString(flagType))
+	}
+	if !cryptokeys.IsSupportedAlgo(algo) {
+		return "", fmt.Errorf("unsupported signing algo %q, expected secp256k1, ed25519 or secp256r1", algo)
+	}
+	return algo, nil
+}
+
 func printCreate(info cryptokeys.Info, seed string) {
 	output := viper.Get(cli.OutputFlag)
 	switch output {
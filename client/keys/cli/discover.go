@@ -0,0 +1,102 @@
+package keys
+
+import (
+	"fmt"
+
+	"github.com/NPC-Chain/npcchub/app/v1/auth"
+	"github.com/NPC-Chain/npcchub/client/context"
+	"github.com/NPC-Chain/npcchub/client/keys"
+	cryptokeys "github.com/NPC-Chain/npcchub/crypto/keys"
+	"github.com/NPC-Chain/npcchub/crypto/keys/hd"
+	sdk "github.com/NPC-Chain/npcchub/types"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+const (
+	flagGapLimit = "gap-limit"
+	flagName     = "name"
+)
+
+func discoverKeysCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "discover [mnemonic]",
+		Short: "Discover the accounts of a mnemonic in use on chain",
+		Long: `Scan the BIP44 accounts and address indexes of a mnemonic for the addresses with an
+account on chain. The address indexes of an account are scanned until --gap-limit consecutive
+addresses are unused, and the scan stops at the first account without any used address.
+The mnemonic is read from stdin if it isn't given. With --name the addresses found are stored
+under the one key name, and shown by keys show --index. The index-th address signs the txs
+sent with --from=<key name>#<index> or --from=<address>.`,
+		Example: "iriscli keys discover --name=<key name> --node=<node address>",
+		Args:    cobra.MaximumNArgs(1),
+		RunE:    runDiscoverCmd,
+	}
+	cmd.Flags().Uint32(flagGapLimit, cryptokeys.DefaultGapLimit, "Number of consecutive unused addresses after which an account is no longer scanned")
+	cmd.Flags().String(flagName, "", "Store the addresses found under this key name")
+	return cmd
+}
+
+func runDiscoverCmd(cmd *cobra.Command, args []string) error {
+	buf := keys.BufferStdin()
+	var mnemonic string
+	if len(args) == 1 {
+		mnemonic = args[0]
+	} else {
+		var err error
+		mnemonic, err = keys.GetSeed("Enter your recovery seed phrase:", buf)
+		if err != nil {
+			return err
+		}
+	}
+
+	cliCtx := context.NewCLIContext()
+	discovered, err := cryptokeys.DiscoverAccounts(mnemonic, "", uint32(viper.GetInt(flagGapLimit)), func(addr sdk.AccAddress) (bool, error) {
+		res, err := cliCtx.QueryStore(auth.AddressStoreKey(addr), cliCtx.AccountStore)
+		if err != nil {
+			return false, err
+		}
+		return len(res) > 0, nil
+	})
+	if err != nil {
+		return err
+	}
+	if len(discovered) == 0 {
+		return fmt.Errorf("no account of the mnemonic in use on chain")
+	}
+	for i, d := range discovered {
+		fmt.Printf("%d\t%s\t%s\n", i, d.Params.String(), d.Address)
+	}
+
+	name := viper.GetString(flagName)
+	if name == "" {
+		return nil
+	}
+	kb, err := keys.GetKeyBaseWithWritePerm()
+	if err != nil {
+		return err
+	}
+	if _, err := kb.Get(name); err == nil {
+		// account exists, ask for user confirmation, its addresses are deleted with it
+		if response, err := keys.GetConfirmation(
+			fmt.Sprintf("override the existing name %s", name), buf); err != nil || !response {
+			return err
+		}
+	}
+	pass, err := keys.GetCheckPassword(
+		"Enter a passphrase for your key:",
+		"Repeat the passphrase:", buf)
+	if err != nil {
+		return err
+	}
+	params := make([]hd.BIP44Params, len(discovered))
+	for i, d := range discovered {
+		params[i] = d.Params
+	}
+	info, err := kb.DeriveAddresses(name, mnemonic, "", pass, params)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("stored %d addresses under the key %s\n", len(params), info.GetName())
+	return nil
+}
@@ -20,14 +20,17 @@ const (
 func exportKeyCommand() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "export <name>",
-		Short: "Export an existing key",
-		Long: `Export an existing key to the file,
-You can import the file by keys add --recover --keystore=<file>.`,
-		Example: "iriscli keys export <key name>",
+		Short: "Export an existing key to a keystore file",
+		Long: `Export an existing key to a keystore file in the V3 format of Ethereum wallets,
+encrypted with scrypt and AES-128-CTR, written to --keystore or to STDOUT.
+You can import the file by keys import <name> --keystore=<file>.`,
+		Example: "iriscli keys export <key name> --keystore=<file>",
 		RunE:    runExportCmd,
 		Args:    cobra.ExactArgs(1),
 	}
-	cmd.Flags().String(flagOutfile, "", "The keystore info will be written to the given file instead of STDOUT")
+	cmd.Flags().String(flagKeystore, "", "The keystore will be written to the given file instead of STDOUT")
+	cmd.Flags().String(flagOutfile, "", "The keystore will be written to the given file instead of STDOUT")
+	cmd.Flags().MarkDeprecated(flagOutfile, "use --keystore")
 	cmd.Flags().Bool(client.FlagIndentResponse, false, "Add indent to JSON response")
 	return cmd
 }
@@ -77,20 +80,26 @@ func runExportCmd(_ *cobra.Command, args []string) error {
 	} else {
 		jsonString, err = json.Marshal(encryptedKeyJSON)
 	}
+	if err != nil {
+		return err
+	}
 
-	if viper.GetString(flagOutfile) == "" {
+	outfile := viper.GetString(flagKeystore)
+	if outfile == "" {
+		outfile = viper.GetString(flagOutfile)
+	}
+	if outfile == "" {
 		fmt.Printf("%s\n", jsonString)
 		return nil
 	}
 
 	fp, err := os.OpenFile(
-		viper.GetString(flagOutfile), os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0644,
+		outfile, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0600,
 	)
-	defer fp.Close()
-
 	if err != nil {
 		return err
 	}
+	defer fp.Close()
 
 	fmt.Fprintf(fp, "%s\n", jsonString)
 
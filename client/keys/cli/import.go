@@ -0,0 +1,134 @@
+package keys
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/NPC-Chain/npcchub/client/keys"
+	cryptokeys "github.com/NPC-Chain/npcchub/crypto/keys"
+	"github.com/NPC-Chain/npcchub/crypto/keystore"
+	"github.com/NPC-Chain/npcchub/crypto/secp256r1"
+	sdk "github.com/NPC-Chain/npcchub/types"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
+)
+
+func importKeyCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "import <name>",
+		Short: "Import a key from a keystore file",
+		Long: `Import the key of a keystore file in the V3 format of Ethereum wallets, encrypted with
+scrypt or pbkdf2, or exported by keys export. The keystore holds the raw private key only,
+so keys of another algo than secp256k1 are imported with --algo.`,
+		Example: "iriscli keys import <key name> --keystore=<file>",
+		RunE:    runImportCmd,
+		Args:    cobra.ExactArgs(1),
+	}
+	cmd.Flags().String(flagKeystore, "", "The keystore file to import the key from")
+	cmd.Flags().String(flagAlgo, string(cryptokeys.Secp256k1), "Signing algorithm of the key (secp256k1|ed25519|secp256r1)")
+	cmd.MarkFlagRequired(flagKeystore)
+	return cmd
+}
+
+func runImportCmd(cmd *cobra.Command, args []string) error {
+	name := args[0]
+	algo, err := signingAlgo(cmd)
+	if err != nil {
+		return err
+	}
+
+	buf := keys.BufferStdin()
+	kb, err := keys.GetKeyBaseWithWritePerm()
+	if err != nil {
+		return err
+	}
+	if _, err := kb.Get(name); err == nil {
+		// account exists, ask for user confirmation
+		if response, err := keys.GetConfirmation(
+			fmt.Sprintf("override the existing name %s", name), buf); err != nil || !response {
+			return err
+		}
+	}
+
+	passphrase, err := keys.GetPassword("Password of the keystore file:", buf)
+	if err != nil {
+		return fmt.Errorf("Error reading passphrase: %v", err)
+	}
+	privKey, err := readKeystore(viper.GetString(flagKeystore), passphrase, algo)
+	if err != nil {
+		return err
+	}
+
+	pass, err := keys.GetCheckPassword(
+		"Enter a passphrase for your key:",
+		"Repeat the passphrase:", buf)
+	if err != nil {
+		return err
+	}
+	info, err := kb.ImportPrivateKey(name, pass, privKey)
+	if err != nil {
+		return err
+	}
+	// print out results without the seed phrase
+	viper.Set(flagNoBackup, true)
+	printCreate(info, "")
+	return nil
+}
+
+// readKeystore decrypts the key of the algo of the keystore file. The keys of the files
+// holding a bech32 account address must be of that address.
+func readKeystore(file, passphrase string, algo cryptokeys.SigningAlgo) (crypto.PrivKey, error) {
+	keyJSON, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	keyBytes, err := keystore.DecryptKeyJSON(keyJSON, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	privKey, err := privKeyFromBytes(keyBytes, algo)
+	if err != nil {
+		return nil, err
+	}
+
+	var encryptedKey keystore.EncryptedKeyJSON
+	if err := json.Unmarshal(keyJSON, &encryptedKey); err != nil {
+		return nil, err
+	}
+	if addr, err := sdk.AccAddressFromBech32(encryptedKey.Address); err == nil {
+		if !addr.Equals(sdk.AccAddress(privKey.PubKey().Address())) {
+			return nil, fmt.Errorf("the keystore is of the address %s, not of the %s key %s, check --algo",
+				addr, algo, sdk.AccAddress(privKey.PubKey().Address()))
+		}
+	}
+	return privKey, nil
+}
+
+func privKeyFromBytes(keyBytes []byte, algo cryptokeys.SigningAlgo) (crypto.PrivKey, error) {
+	switch algo {
+	case cryptokeys.Secp256k1:
+		var privKey secp256k1.PrivKeySecp256k1
+		if len(keyBytes) != len(privKey) {
+			break
+		}
+		copy(privKey[:], keyBytes)
+		return privKey, nil
+	case cryptokeys.Secp256r1:
+		if privKey, ok := secp256r1.PrivKeyFromScalar(keyBytes); ok {
+			return privKey, nil
+		}
+	case cryptokeys.Ed25519:
+		var privKey ed25519.PrivKeyEd25519
+		if len(keyBytes) != len(privKey) {
+			break
+		}
+		copy(privKey[:], keyBytes)
+		return privKey, nil
+	}
+	return nil, errors.Errorf("the keystore doesn't hold a %s key", algo)
+}
@@ -1,7 +1,11 @@
 package keys
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/NPC-Chain/npcchub/client"
+	"github.com/NPC-Chain/npcchub/crypto/keys"
 	"github.com/spf13/cobra"
 )
 
@@ -21,12 +25,15 @@ func Commands() *cobra.Command {
 		mnemonicKeyCommand(),
 		newKeyCommand(),
 		addKeyCommand(),
+		importKeyCommand(),
 		exportKeyCommand(),
 		listKeysCmd(),
 		showKeysCmd(),
+		client.GetCommands(discoverKeysCommand())[0],
 		client.LineBreak,
 		deleteKeyCommand(),
 		updateKeyCommand(),
 	)
+	cmd.PersistentFlags().String(client.FlagKeyringBackend, keys.BackendDB, fmt.Sprintf("Keyring backend holding the keys: %s", strings.Join(keys.Backends, ", ")))
 	return cmd
 }
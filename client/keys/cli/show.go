@@ -51,6 +51,7 @@ func showKeysCmd() *cobra.Command {
 	cmd.Flags().Bool(FlagAddress, false, "output the address only (overrides --output)")
 	cmd.Flags().Bool(FlagPublicKey, false, "output the public key only (overrides --output)")
 	cmd.Flags().Uint(flagMultiSigThreshold, 1, "K out of N required signatures")
+	cmd.Flags().Uint(flagIndex, 0, "Index of the address stored under the key name, as by keys discover")
 	cmd.Flags().Bool(client.FlagIndentResponse, false, "Add indent to JSON response")
 
 	return cmd
@@ -60,11 +61,18 @@ func runShowCmd(cmd *cobra.Command, args []string) (err error) {
 	var info cryptokeys.Info
 
 	if len(args) == 1 {
-		info, err = keys.GetKeyInfo(args[0])
+		kb, err := keys.GetKeyBase()
+		if err != nil {
+			return err
+		}
+		info, err = kb.GetIndex(args[0], viper.GetInt(flagIndex))
 		if err != nil {
 			return err
 		}
 	} else {
+		if viper.GetInt(flagIndex) != 0 {
+			return fmt.Errorf("cannot use --index with multiple keys")
+		}
 		pks := make([]crypto.PubKey, len(args))
 		for i, keyName := range args {
 			info, err := keys.GetKeyInfo(keyName)
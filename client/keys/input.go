@@ -14,10 +14,16 @@ import (
 // MinPassLength is the minimum acceptable password length
 const MinPassLength = 8
 
+// stdin is shared by the prompts so that piped lines aren't lost between them
+var stdin *bufio.Reader
+
 // BufferStdin is used to allow reading prompts for stdin
 // multiple times, when we read from non-tty
 func BufferStdin() *bufio.Reader {
-	return bufio.NewReader(os.Stdin)
+	if stdin == nil {
+		stdin = bufio.NewReader(os.Stdin)
+	}
+	return stdin
 }
 
 // GetPassword will prompt for a password one-time (to sign a tx)
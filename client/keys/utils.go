@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"net/http"
 	"path/filepath"
+	"strings"
 
 	"github.com/NPC-Chain/npcchub/client"
 	"github.com/NPC-Chain/npcchub/codec"
@@ -18,6 +19,9 @@ import (
 // KeyDBName is the directory under root where we store the keys
 const KeyDBName = "keys"
 
+// keyringDirPrefix prefixes the backend to name the directory under root of its keys
+const keyringDirPrefix = "keyring-"
+
 type BechKeyOutFn func(keyInfo keys.Info) (KeyOutput, error)
 
 // keybase is used to make GetKeyBase a singleton
@@ -79,15 +83,70 @@ func GetKeyBaseFromDirWithWritePerm(rootDir string) (keys.Keybase, error) {
 
 func getKeyBaseFromDirWithOpts(rootDir string, o *opt.Options) (keys.Keybase, error) {
 	if keybase == nil {
-		db, err := dbm.NewGoLevelDBWithOpts(KeyDBName, filepath.Join(rootDir, "keys"), o)
+		kb, err := newKeyBase(rootDir, o)
 		if err != nil {
 			return nil, err
 		}
-		keybase = client.GetKeyBase(db)
+		keybase = kb
 	}
 	return keybase, nil
 }
 
+// newKeyBase opens the keybase of the --keyring-backend in the root dir, the LevelDB
+// of the keys being opened with the options
+func newKeyBase(rootDir string, o *opt.Options) (keys.Keybase, error) {
+	switch backend := KeyringBackend(); backend {
+	case keys.BackendDB:
+		db, err := dbm.NewGoLevelDBWithOpts(KeyDBName, filepath.Join(rootDir, "keys"), o)
+		if err != nil {
+			return nil, err
+		}
+		return client.GetKeyBase(db), nil
+	case keys.BackendReadOnly:
+		db, err := dbm.NewGoLevelDBWithOpts(KeyDBName, filepath.Join(rootDir, "keys"), &opt.Options{ReadOnly: true})
+		if err != nil {
+			return nil, err
+		}
+		return keys.NewReadOnly(client.GetKeyBase(db)), nil
+	case keys.BackendFile:
+		dir := filepath.Join(rootDir, keyringDirPrefix+backend)
+		passphrase, err := readKeyringPassphrase(dir)
+		if err != nil {
+			return nil, err
+		}
+		return keys.NewFile(dir, passphrase)
+	case keys.BackendPass:
+		return keys.NewPass(filepath.Join(rootDir, keyringDirPrefix+backend))
+	case keys.BackendTest:
+		return keys.NewTest(filepath.Join(rootDir, keyringDirPrefix+backend))
+	default:
+		return nil, fmt.Errorf("unsupported keyring backend %s, expected one of: %s", backend, strings.Join(keys.Backends, ", "))
+	}
+}
+
+// KeyringBackend returns the keyring backend set by --keyring-backend or the config, the LevelDB by default
+func KeyringBackend() string {
+	backend := viper.GetString(client.FlagKeyringBackend)
+	if len(backend) == 0 {
+		return keys.BackendDB
+	}
+	return backend
+}
+
+// readKeyringPassphrase reads the passphrase of the file keyring in the dir from STDIN,
+// twice if the keyring is created
+func readKeyringPassphrase(dir string) (string, error) {
+	buf := BufferStdin()
+	if keys.KeyringExists(dir) {
+		passphrase, err := GetPassword("Enter keyring passphrase:", buf)
+		if err != nil {
+			return passphrase, fmt.Errorf("Error reading keyring passphrase: %v", err)
+		}
+		return passphrase, nil
+	}
+	return GetCheckPassword("Enter a passphrase for the new keyring:", "Repeat the passphrase:", buf)
+}
+
 // ReadPassphraseFromStdin attempts to read a passphrase from STDIN return an
 // error upon failure.
 func ReadPassphraseFromStdin(name string) (string, error) {
@@ -117,14 +176,7 @@ func ReadKeystorePassphraseFromStdin() (string, error) {
 
 // initialize a keybase based on the configuration
 func GetKeyBaseFromDir(rootDir string) (keys.Keybase, error) {
-	if keybase == nil {
-		db, err := dbm.NewGoLevelDB(KeyDBName, filepath.Join(rootDir, "keys"))
-		if err != nil {
-			return nil, err
-		}
-		keybase = GetKeyBaseFromDB(db)
-	}
-	return keybase, nil
+	return getKeyBaseFromDirWithOpts(rootDir, nil)
 }
 
 func GetKey(name string) (keys.Info, error) {
@@ -29,6 +29,11 @@ const (
 	FlagReqId              = "request-id"
 	FlagDestAddress        = "dest-address"
 	FlagWithdrawAmount     = "withdraw-amount"
+	FlagDefVersion         = "def-version"
+	FlagDeprecatePrevious  = "deprecate-previous"
+	FlagSortBy             = "sort-by"
+	FlagMinSuccessRate     = "min-success-rate"
+	FlagMaxAvgRspBlocks    = "max-avg-rsp-blocks"
 )
 
 var (
@@ -44,6 +49,7 @@ var (
 
 func init() {
 	FsServiceDefinitionCreate.String(FlagServiceName, "", "service name")
+	FsServiceDefinitionCreate.String(FlagDefVersion, "", "semantic version of the service definition, default 1.0.0")
 	FsServiceDefinitionCreate.String(FlagServiceDescription, "", "service description")
 	FsServiceDefinitionCreate.StringSlice(FlagTags, []string{}, "service tags")
 	FsServiceDefinitionCreate.String(FlagAuthorDescription, "", "service author description")
@@ -6,6 +6,7 @@ import (
 
 	"github.com/NPC-Chain/npcchub/app/protocol"
 	"github.com/NPC-Chain/npcchub/app/v1/service"
+	client "github.com/NPC-Chain/npcchub/client/service"
 	"github.com/NPC-Chain/npcchub/client/context"
 	"github.com/NPC-Chain/npcchub/client/utils"
 	"github.com/NPC-Chain/npcchub/codec"
@@ -18,17 +19,19 @@ func GetCmdQuerySvcDef(cdc *codec.Codec) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:     "definition",
 		Short:   "Query service definition",
-		Example: "iriscli service definition --def-chain-id=<chain-id> --service-name=<service name>",
+		Example: "iriscli service definition --def-chain-id=<chain-id> --service-name=<service name> --def-version=<version>",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			cliCtx := context.NewCLIContext().WithCodec(cdc).WithLogger(os.Stdout).
 				WithAccountDecoder(utils.GetAccountDecoder(cdc))
 
 			name := viper.GetString(FlagServiceName)
 			defChainId := viper.GetString(FlagDefChainID)
+			defVersion := viper.GetString(FlagDefVersion)
 
 			params := service.QueryServiceParams{
 				DefChainID:  defChainId,
 				ServiceName: name,
+				DefVersion:  defVersion,
 			}
 
 			bz, err := cdc.MarshalJSON(params)
@@ -47,6 +50,45 @@ func GetCmdQuerySvcDef(cdc *codec.Codec) *cobra.Command {
 		},
 	}
 	cmd.Flags().AddFlagSet(FsServiceDefinition)
+	cmd.Flags().String(FlagDefVersion, "", "the version of the service definition, default the latest version")
+	cmd.MarkFlagRequired(FlagDefChainID)
+	cmd.MarkFlagRequired(FlagServiceName)
+	return cmd
+}
+
+func GetCmdQuerySvcDefVersions(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "definition-versions",
+		Short:   "Query all the versions of a service definition",
+		Example: "iriscli service definition-versions --def-chain-id=<chain-id> --service-name=<service name>",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc).WithLogger(os.Stdout).
+				WithAccountDecoder(utils.GetAccountDecoder(cdc))
+
+			name := viper.GetString(FlagServiceName)
+			defChainId := viper.GetString(FlagDefChainID)
+
+			params := service.QueryServiceParams{
+				DefChainID:  defChainId,
+				ServiceName: name,
+			}
+
+			bz, err := cdc.MarshalJSON(params)
+			if err != nil {
+				return err
+			}
+
+			route := fmt.Sprintf("custom/%s/%s", protocol.ServiceRoute, service.QueryDefinitionVersions)
+			res, err := cliCtx.QueryWithData(route, bz)
+			if err != nil {
+				return err
+			}
+
+			fmt.Println(string(res))
+			return nil
+		},
+	}
+	cmd.Flags().AddFlagSet(FsServiceDefinition)
 	cmd.MarkFlagRequired(FlagDefChainID)
 	cmd.MarkFlagRequired(FlagServiceName)
 	return cmd
@@ -106,7 +148,8 @@ func GetCmdQuerySvcBinds(cdc *codec.Codec) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:     "bindings",
 		Short:   "Query service bindings",
-		Example: "iriscli service bindings --def-chain-id=<chain-id> --service-name=<service name>",
+		Example: "iriscli service bindings --def-chain-id=<chain-id> --service-name=<service name> " +
+			"--sort-by=success-rate --min-success-rate=0.9 --max-avg-rsp-blocks=5",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			cliCtx := context.NewCLIContext().WithCodec(cdc).WithLogger(os.Stdout).
 				WithAccountDecoder(utils.GetAccountDecoder(cdc))
@@ -124,17 +167,44 @@ func GetCmdQuerySvcBinds(cdc *codec.Codec) *cobra.Command {
 				return err
 			}
 
+			minSuccessRate, err := sdk.NewDecFromStr(viper.GetString(FlagMinSuccessRate))
+			if err != nil {
+				return err
+			}
+			maxAvgRspBlocks, err := sdk.NewDecFromStr(viper.GetString(FlagMaxAvgRspBlocks))
+			if err != nil {
+				return err
+			}
+
 			route := fmt.Sprintf("custom/%s/%s", protocol.ServiceRoute, service.QueryBindings)
 			res, err := cliCtx.QueryWithData(route, bz)
 			if err != nil {
 				return err
 			}
 
-			fmt.Println(string(res))
+			var bindings []service.SvcBinding
+			if err := cdc.UnmarshalJSON(res, &bindings); err != nil {
+				return err
+			}
+			bindings = client.FilterBindings(bindings, minSuccessRate, maxAvgRspBlocks)
+			if sortBy := viper.GetString(FlagSortBy); len(sortBy) > 0 {
+				if err := client.SortBindings(bindings, sortBy); err != nil {
+					return err
+				}
+			}
+
+			output, err := codec.MarshalJSONIndent(cdc, bindings)
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(output))
 			return nil
 		},
 	}
 	cmd.Flags().AddFlagSet(FsServiceDefinition)
+	cmd.Flags().String(FlagSortBy, "", "sort the bindings by their request history, valid values can be success-rate, avg-rsp-blocks, served-requests and slashes")
+	cmd.Flags().String(FlagMinSuccessRate, "0", "the minimum ratio of the served requests to the finished requests")
+	cmd.Flags().String(FlagMaxAvgRspBlocks, "0", "the maximum average response latency in blocks, 0 means no limit")
 	cmd.MarkFlagRequired(FlagDefChainID)
 	cmd.MarkFlagRequired(FlagServiceName)
 	return cmd
@@ -22,7 +22,7 @@ func GetCmdSvcDef(cdc *codec.Codec) *cobra.Command {
 		Use:   "define",
 		Short: "Create a new service definition",
 		Example: "iriscli service define --chain-id=<chain-id> --from=<key-name> --fee=0.3iris " +
-			"--service-name=<service name> --service-description=<service description> --author-description=<author description> " +
+			"--service-name=<service name> --def-version=1.0.0 --service-description=<service description> --author-description=<author description> " +
 			"--tags=tag1,tag2 --idl-content=<interface description content> --file=test.proto",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			cliCtx := context.NewCLIContext().WithCodec(cdc).WithLogger(os.Stdout).
@@ -31,20 +31,13 @@ func GetCmdSvcDef(cdc *codec.Codec) *cobra.Command {
 				WithCliCtx(cliCtx)
 
 			name := viper.GetString(FlagServiceName)
+			version := viper.GetString(FlagDefVersion)
 			description := viper.GetString(FlagServiceDescription)
 			authorDescription := viper.GetString(FlagAuthorDescription)
 			tags := viper.GetStringSlice(FlagTags)
-			content := viper.GetString(FlagIdlContent)
-			if len(content) > 0 {
-				content = strings.Replace(content, `\n`, "\n", -1)
-			}
-			filePath := viper.GetString(FlagFile)
-			if len(filePath) > 0 {
-				contentBytes, err := cmn.ReadFile(filePath)
-				if err != nil {
-					return err
-				}
-				content = string(contentBytes)
+			content, err := readIdlContent()
+			if err != nil {
+				return err
 			}
 			fmt.Printf("idl condent: \n%s\n", content)
 			chainId := viper.GetString(client.FlagChainID)
@@ -54,26 +47,81 @@ func GetCmdSvcDef(cdc *codec.Codec) *cobra.Command {
 				return err
 			}
 
+			msg := service.NewMsgSvcDef(name, chainId, version, description, tags, fromAddr, authorDescription, content)
+			cliCtx.PrintResponse = true
+			return utils.SendOrPrintTx(txCtx, cliCtx, []sdk.Msg{msg})
+		},
+	}
+	cmd.Flags().AddFlagSet(FsServiceDefinitionCreate)
+	cmd.MarkFlagRequired(FlagServiceName)
+	return cmd
+}
+
+func GetCmdSvcDefUpdate(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "update-definition",
+		Short: "Add a new version to a service definition",
+		Example: "iriscli service update-definition --chain-id=<chain-id> --from=<key-name> --fee=0.3iris " +
+			"--service-name=<service name> --def-version=1.1.0 --service-description=<service description> --author-description=<author description> " +
+			"--tags=tag1,tag2 --idl-content=<interface description content> --file=test.proto --deprecate-previous",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc).WithLogger(os.Stdout).
+				WithAccountDecoder(utils.GetAccountDecoder(cdc))
+			txCtx := utils.NewTxContextFromCLI().WithCodec(cdc).
+				WithCliCtx(cliCtx)
+
+			name := viper.GetString(FlagServiceName)
+			version := viper.GetString(FlagDefVersion)
+			description := viper.GetString(FlagServiceDescription)
+			authorDescription := viper.GetString(FlagAuthorDescription)
+			tags := viper.GetStringSlice(FlagTags)
+			deprecatePrevious := viper.GetBool(FlagDeprecatePrevious)
+			content, err := readIdlContent()
+			if err != nil {
+				return err
+			}
+			chainId := viper.GetString(client.FlagChainID)
+
+			fromAddr, err := cliCtx.GetFromAddress()
 			if err != nil {
 				return err
 			}
 
-			msg := service.NewMsgSvcDef(name, chainId, description, tags, fromAddr, authorDescription, content)
+			msg := service.NewMsgSvcDefUpdate(name, chainId, version, description, tags, fromAddr, authorDescription, content, deprecatePrevious)
 			cliCtx.PrintResponse = true
 			return utils.SendOrPrintTx(txCtx, cliCtx, []sdk.Msg{msg})
 		},
 	}
 	cmd.Flags().AddFlagSet(FsServiceDefinitionCreate)
+	cmd.Flags().Bool(FlagDeprecatePrevious, false, "deprecate all the previous versions, they can't be bound any more")
 	cmd.MarkFlagRequired(FlagServiceName)
+	cmd.MarkFlagRequired(FlagDefVersion)
 	return cmd
 }
 
+// read the idl content from the flags, the file takes precedence
+func readIdlContent() (string, error) {
+	content := viper.GetString(FlagIdlContent)
+	if len(content) > 0 {
+		content = strings.Replace(content, `\n`, "\n", -1)
+	}
+	filePath := viper.GetString(FlagFile)
+	if len(filePath) > 0 {
+		contentBytes, err := cmn.ReadFile(filePath)
+		if err != nil {
+			return "", err
+		}
+		content = string(contentBytes)
+	}
+	return content, nil
+}
+
 func GetCmdSvcBind(cdc *codec.Codec) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "bind",
 		Short: "Create a new service binding",
 		Example: "iriscli service bind --chain-id=<chain-id> --from=<key-name> --fee=0.3iris " +
-			"--service-name=<service name> --def-chain-id=<chain-id> --bind-type=Local " +
+			"--service-name=<service name> --def-chain-id=<chain-id> --def-version=<version> --bind-type=Local " +
 			"--deposit=1iris --prices=1iris,2iris --avg-rsp-time=10000 --usable-time=100",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			cliCtx := context.NewCLIContext().WithCodec(cdc).WithLogger(os.Stdout).
@@ -89,6 +137,7 @@ func GetCmdSvcBind(cdc *codec.Codec) *cobra.Command {
 
 			name := viper.GetString(FlagServiceName)
 			defChainId := viper.GetString(FlagDefChainID)
+			defVersion := viper.GetString(FlagDefVersion)
 			initialDeposit := viper.GetString(FlagDeposit)
 			initialPrices := viper.GetStringSlice(FlagPrices)
 			avgRspTime := viper.GetInt64(FlagAvgRspTime)
@@ -115,15 +164,17 @@ func GetCmdSvcBind(cdc *codec.Codec) *cobra.Command {
 			}
 
 			level := service.Level{AvgRspTime: avgRspTime, UsableTime: usableTime}
-			msg := service.NewMsgSvcBind(defChainId, name, chainId, fromAddr, bindingType, deposit, prices, level)
+			msg := service.NewMsgSvcBind(defChainId, name, defVersion, chainId, fromAddr, bindingType, deposit, prices, level)
 			cliCtx.PrintResponse = true
 			return utils.SendOrPrintTx(txCtx, cliCtx, []sdk.Msg{msg})
 		},
 	}
 	cmd.Flags().AddFlagSet(FsServiceDefinition)
 	cmd.Flags().AddFlagSet(FsServiceBindingCreate)
+	cmd.Flags().String(FlagDefVersion, "", "the version of the service definition to bind")
 	cmd.MarkFlagRequired(FlagDefChainID)
 	cmd.MarkFlagRequired(FlagServiceName)
+	cmd.MarkFlagRequired(FlagDefVersion)
 	cmd.MarkFlagRequired(FlagBindType)
 	cmd.MarkFlagRequired(FlagPrices)
 	cmd.MarkFlagRequired(FlagAvgRspTime)
@@ -136,7 +187,7 @@ func GetCmdSvcBindUpdate(cdc *codec.Codec) *cobra.Command {
 		Use:   "update-binding",
 		Short: "Update a service binding",
 		Example: "iriscli service update-binding --chain-id=<chain-id> --from=<key-name> --fee=0.3iris " +
-			"--service-name=<service name> --def-chain-id=<chain-id> --bind-type=Local " +
+			"--service-name=<service name> --def-chain-id=<chain-id> --def-version=<version> --bind-type=Local " +
 			"--deposit=1iris --prices=1iris,2iris --avg-rsp-time=10000 --usable-time=100",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			cliCtx := context.NewCLIContext().WithCodec(cdc).WithLogger(os.Stdout).
@@ -152,6 +203,7 @@ func GetCmdSvcBindUpdate(cdc *codec.Codec) *cobra.Command {
 			chainId := viper.GetString(client.FlagChainID)
 			name := viper.GetString(FlagServiceName)
 			defChainId := viper.GetString(FlagDefChainID)
+			defVersion := viper.GetString(FlagDefVersion)
 			initialDeposit := viper.GetString(FlagDeposit)
 			initialPrices := viper.GetStringSlice(FlagPrices)
 			avgRspTime := viper.GetInt64(FlagAvgRspTime)
@@ -184,13 +236,14 @@ func GetCmdSvcBindUpdate(cdc *codec.Codec) *cobra.Command {
 			}
 
 			level := service.Level{AvgRspTime: avgRspTime, UsableTime: usableTime}
-			msg := service.NewMsgSvcBindingUpdate(defChainId, name, chainId, fromAddr, bindingType, deposit, prices, level)
+			msg := service.NewMsgSvcBindingUpdate(defChainId, name, defVersion, chainId, fromAddr, bindingType, deposit, prices, level)
 			cliCtx.PrintResponse = true
 			return utils.SendOrPrintTx(txCtx, cliCtx, []sdk.Msg{msg})
 		},
 	}
 	cmd.Flags().AddFlagSet(FsServiceDefinition)
 	cmd.Flags().AddFlagSet(FsServiceBindingUpdate)
+	cmd.Flags().String(FlagDefVersion, "", "rebind to another version of the service definition")
 	cmd.MarkFlagRequired(FlagDefChainID)
 	cmd.MarkFlagRequired(FlagServiceName)
 	return cmd
@@ -307,7 +360,7 @@ func GetCmdSvcCall(cdc *codec.Codec) *cobra.Command {
 		Use:   "call",
 		Short: "Call a service method",
 		Example: "iriscli service call --chain-id=<chain-id> --from=<key-name> --fee=0.3iris --def-chain-id=<bind-chain-id> " +
-			"--service-name=<service name> --method-id=<method-id> --bind-chain-id=<chain-id> --provider=<provider> --service-fee=1iris --request-data=<req>",
+			"--service-name=<service name> --def-version=<version> --method-id=<method-id> --bind-chain-id=<chain-id> --provider=<provider> --service-fee=1iris --request-data=<req>",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			cliCtx := context.NewCLIContext().WithCodec(cdc).WithLogger(os.Stdout).
 				WithAccountDecoder(utils.GetAccountDecoder(cdc))
@@ -323,6 +376,7 @@ func GetCmdSvcCall(cdc *codec.Codec) *cobra.Command {
 
 			defChainId := viper.GetString(FlagDefChainID)
 			name := viper.GetString(FlagServiceName)
+			defVersion := viper.GetString(FlagDefVersion)
 			bindChainId := viper.GetString(FlagBindChainID)
 			methodId := int16(viper.GetInt(FlagMethodID))
 
@@ -346,7 +400,7 @@ func GetCmdSvcCall(cdc *codec.Codec) *cobra.Command {
 
 			profiling := viper.GetBool(FlagProfiling)
 
-			msg := service.NewMsgSvcRequest(defChainId, name, bindChainId, chainId, fromAddr, provider, methodId, input, serviceFee, profiling)
+			msg := service.NewMsgSvcRequest(defChainId, name, defVersion, bindChainId, chainId, fromAddr, provider, methodId, input, serviceFee, profiling)
 			cliCtx.PrintResponse = true
 			return utils.SendOrPrintTx(txCtx, cliCtx, []sdk.Msg{msg})
 		},
@@ -354,8 +408,10 @@ func GetCmdSvcCall(cdc *codec.Codec) *cobra.Command {
 	cmd.Flags().AddFlagSet(FsServiceDefinition)
 	cmd.Flags().AddFlagSet(FsServiceBinding)
 	cmd.Flags().AddFlagSet(FsServiceRequest)
+	cmd.Flags().String(FlagDefVersion, "", "the version of the service definition bound by the provider")
 	cmd.MarkFlagRequired(FlagDefChainID)
 	cmd.MarkFlagRequired(FlagServiceName)
+	cmd.MarkFlagRequired(FlagDefVersion)
 	cmd.MarkFlagRequired(FlagBindChainID)
 	cmd.MarkFlagRequired(FlagProvider)
 	cmd.MarkFlagRequired(FlagMethodID)
@@ -9,4 +9,6 @@ const (
 	Provider    = "provider"
 	Consumer    = "consumer"
 	Address     = "address"
+
+	RestDefVersion = "version"
 )
@@ -21,6 +21,12 @@ func registerQueryRoutes(cliCtx context.CLIContext, r *mux.Router, cdc *codec.Co
 		definitionGetHandlerFn(cliCtx, cdc),
 	).Methods("GET")
 
+	// get all versions of a definition
+	r.HandleFunc(
+		fmt.Sprintf("/service/definitions/{%s}/{%s}/versions", DefChainId, ServiceName),
+		definitionVersionsHandlerFn(cliCtx, cdc),
+	).Methods("GET")
+
 	// get a single binding info
 	r.HandleFunc(
 		fmt.Sprintf("/service/bindings/{%s}/{%s}/{%s}/{%s}", DefChainId, ServiceName, BindChainId, Provider),
@@ -57,10 +63,12 @@ func definitionGetHandlerFn(cliCtx context.CLIContext, cdc *codec.Codec) http.Ha
 		vars := mux.Vars(r)
 		defChainId := vars[DefChainId]
 		serviceName := vars[ServiceName]
+		defVersion := r.URL.Query().Get(RestDefVersion)
 
 		params := service.QueryServiceParams{
 			DefChainID:  defChainId,
 			ServiceName: serviceName,
+			DefVersion:  defVersion,
 		}
 
 		bz, err := cdc.MarshalJSON(params)
@@ -80,6 +88,34 @@ func definitionGetHandlerFn(cliCtx context.CLIContext, cdc *codec.Codec) http.Ha
 	}
 }
 
+func definitionVersionsHandlerFn(cliCtx context.CLIContext, cdc *codec.Codec) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		defChainId := vars[DefChainId]
+		serviceName := vars[ServiceName]
+
+		params := service.QueryServiceParams{
+			DefChainID:  defChainId,
+			ServiceName: serviceName,
+		}
+
+		bz, err := cdc.MarshalJSON(params)
+		if err != nil {
+			utils.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		route := fmt.Sprintf("custom/%s/%s", protocol.ServiceRoute, service.QueryDefinitionVersions)
+		res, err := cliCtx.QueryWithData(route, bz)
+		if err != nil {
+			utils.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		utils.PostProcessResponse(w, cdc, res, cliCtx.Indent)
+	}
+}
+
 func bindingHandlerFn(cliCtx context.CLIContext, cdc *codec.Codec) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		vars := mux.Vars(r)
@@ -123,9 +159,16 @@ func bindingsHandlerFn(cliCtx context.CLIContext, cdc *codec.Codec) http.Handler
 		defChainId := vars[DefChainId]
 		serviceName := vars[ServiceName]
 
+		page, size, ok := utils.ParsePaginationParamsOrReturnBadRequest(w, r)
+		if !ok {
+			return
+		}
+
 		params := service.QueryServiceParams{
 			DefChainID:  defChainId,
 			ServiceName: serviceName,
+			Page:        page,
+			Size:        size,
 		}
 
 		bz, err := cdc.MarshalJSON(params)
@@ -159,11 +202,18 @@ func requestsHandlerFn(cliCtx context.CLIContext, cdc *codec.Codec) http.Handler
 			return
 		}
 
+		page, size, ok := utils.ParsePaginationParamsOrReturnBadRequest(w, r)
+		if !ok {
+			return
+		}
+
 		params := service.QueryBindingParams{
 			DefChainID:  defChainId,
 			ServiceName: serviceName,
 			BindChainId: bindChainId,
 			Provider:    provider,
+			Page:        page,
+			Size:        size,
 		}
 
 		bz, err := cdc.MarshalJSON(params)
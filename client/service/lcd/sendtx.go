@@ -20,6 +20,12 @@ func registerTxRoutes(cliCtx context.CLIContext, r *mux.Router, cdc *codec.Codec
 		definitionPostHandlerFn(cdc, cliCtx),
 	).Methods("POST")
 
+	// Add a new version to a service definition
+	r.HandleFunc(
+		fmt.Sprintf("/service/definitions/{%s}/{%s}/versions", DefChainId, ServiceName),
+		definitionUpdateHandlerFn(cdc, cliCtx),
+	).Methods("POST")
+
 	// Add a new service binding
 	r.HandleFunc(
 		"/service/bindings",
@@ -94,7 +100,43 @@ func definitionPostHandlerFn(cdc *codec.Codec, cliCtx context.CLIContext) http.H
 			return
 		}
 
-		msg := service.NewMsgSvcDef(req.ServiceName, baseReq.ChainID, req.ServiceDescription, req.Tags, authorAddr, req.AuthorDescription, req.IdlContent)
+		msg := service.NewMsgSvcDef(req.ServiceName, baseReq.ChainID, req.Version, req.ServiceDescription, req.Tags, authorAddr, req.AuthorDescription, req.IdlContent)
+		err = msg.ValidateBasic()
+		if err != nil {
+			utils.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		txCtx := utils.BuildReqTxCtx(cliCtx, baseReq, w)
+
+		utils.WriteGenerateStdTxResponse(w, txCtx, []sdk.Msg{msg})
+	}
+}
+
+func definitionUpdateHandlerFn(cdc *codec.Codec, cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		defChainId := vars[DefChainId]
+		serviceName := vars[ServiceName]
+
+		var req definitionUpdate
+		err := utils.ReadPostBody(w, r, cdc, &req)
+		if err != nil {
+			return
+		}
+
+		baseReq := req.BaseTx.Sanitize()
+		if !baseReq.ValidateBasic(w) {
+			return
+		}
+
+		authorAddr, err := sdk.AccAddressFromBech32(req.AuthorAddr)
+		if err != nil {
+			utils.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		msg := service.NewMsgSvcDefUpdate(serviceName, defChainId, req.Version, req.ServiceDescription, req.Tags, authorAddr, req.AuthorDescription, req.IdlContent, req.DeprecatePrevious)
 		err = msg.ValidateBasic()
 		if err != nil {
 			utils.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
@@ -148,7 +190,7 @@ func bindingAddHandlerFn(cdc *codec.Codec, cliCtx context.CLIContext) http.Handl
 			prices = append(prices, price)
 		}
 
-		msg := service.NewMsgSvcBind(req.DefChainId, req.ServiceName, baseReq.ChainID, providerAddr, bindingType, deposit, prices, req.Level)
+		msg := service.NewMsgSvcBind(req.DefChainId, req.ServiceName, req.DefVersion, baseReq.ChainID, providerAddr, bindingType, deposit, prices, req.Level)
 		err = msg.ValidateBasic()
 		if err != nil {
 			utils.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
@@ -213,7 +255,7 @@ func bindingUpdateHandlerFn(cdc *codec.Codec, cliCtx context.CLIContext) http.Ha
 			prices = append(prices, price)
 		}
 
-		msg := service.NewMsgSvcBindingUpdate(DefChainId, serviceName, baseReq.ChainID, providerAddr, bindingType, deposit, prices, req.Level)
+		msg := service.NewMsgSvcBindingUpdate(DefChainId, serviceName, req.DefVersion, baseReq.ChainID, providerAddr, bindingType, deposit, prices, req.Level)
 		err = msg.ValidateBasic()
 		if err != nil {
 			utils.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
@@ -386,7 +428,7 @@ func requestAddHandlerFn(cdc *codec.Codec, cliCtx context.CLIContext) http.Handl
 				return
 			}
 
-			msg := service.NewMsgSvcRequest(request.DefChainId, request.ServiceName, request.BindChainId, baseReq.ChainID, consumer, provider, request.MethodId, input, serviceFee, request.Profiling)
+			msg := service.NewMsgSvcRequest(request.DefChainId, request.ServiceName, request.DefVersion, request.BindChainId, baseReq.ChainID, consumer, provider, request.MethodId, input, serviceFee, request.Profiling)
 			err = msg.ValidateBasic()
 			if err != nil {
 				utils.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
@@ -521,17 +563,30 @@ func FeesWithdrawHandlerFn(cdc *codec.Codec, cliCtx context.CLIContext) http.Han
 type definition struct {
 	BaseTx             utils.BaseTx `json:"base_tx"` // basic tx info
 	ServiceName        string       `json:"service_name"`
+	Version            string       `json:"version"`
+	ServiceDescription string       `json:"service_description"`
+	AuthorDescription  string       `json:"author_description"`
+	Tags               []string     `json:"tags"`
+	IdlContent         string       `json:"idl_content"`
+	AuthorAddr         string       `json:"author_addr"`
+}
+
+type definitionUpdate struct {
+	BaseTx             utils.BaseTx `json:"base_tx"` // basic tx info
+	Version            string       `json:"version"`
 	ServiceDescription string       `json:"service_description"`
 	AuthorDescription  string       `json:"author_description"`
 	Tags               []string     `json:"tags"`
 	IdlContent         string       `json:"idl_content"`
 	AuthorAddr         string       `json:"author_addr"`
+	DeprecatePrevious  bool         `json:"deprecate_previous"`
 }
 
 type binding struct {
 	BaseTx      utils.BaseTx  `json:"base_tx"` // basic tx info
 	ServiceName string        `json:"service_name"`
 	DefChainId  string        `json:"def_chain_id"`
+	DefVersion  string        `json:"def_version"`
 	BindingType string        `json:"binding_type"`
 	Deposit     string        `json:"deposit"`
 	Prices      []string      `json:"prices"`
@@ -541,6 +596,7 @@ type binding struct {
 
 type bindingUpdate struct {
 	BaseTx      utils.BaseTx  `json:"base_tx"` // basic tx info
+	DefVersion  string        `json:"def_version"`
 	BindingType string        `json:"binding_type"`
 	Deposit     string        `json:"deposit"`
 	Prices      []string      `json:"prices"`
@@ -556,6 +612,7 @@ type serviceRequest struct {
 	ServiceName string `json:"service_name"`
 	BindChainId string `json:"bind_chain_id"`
 	DefChainId  string `json:"def_chain_id"`
+	DefVersion  string `json:"def_version"`
 	MethodId    int16  `json:"method_id"`
 	Provider    string `json:"provider"`
 	Consumer    string `json:"consumer"`
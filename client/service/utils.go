@@ -1,6 +1,9 @@
 package service
 
 import (
+	"fmt"
+	"sort"
+
 	"github.com/NPC-Chain/npcchub/app/v1/service"
 	sdk "github.com/NPC-Chain/npcchub/types"
 )
@@ -14,3 +17,49 @@ type FeesOutput struct {
 	ReturnedFee sdk.Coins `json:"returned_fee"`
 	IncomingFee sdk.Coins `json:"incoming_fee"`
 }
+
+// the criteria to sort service bindings by their on-chain stats
+const (
+	SortBySuccessRate    = "success-rate"
+	SortByAvgRspBlocks   = "avg-rsp-blocks"
+	SortByServedRequests = "served-requests"
+	SortBySlashes        = "slashes"
+)
+
+// FilterBindings keeps the bindings with a success rate of at least minSuccessRate
+// and an average response latency of at most maxAvgRspBlocks if it's positive
+func FilterBindings(bindings []service.SvcBinding, minSuccessRate, maxAvgRspBlocks sdk.Dec) []service.SvcBinding {
+	var filtered []service.SvcBinding
+	for _, binding := range bindings {
+		if binding.Stats.SuccessRate().LT(minSuccessRate) {
+			continue
+		}
+		if maxAvgRspBlocks.IsPositive() && binding.Stats.AvgRspBlocks().GT(maxAvgRspBlocks) {
+			continue
+		}
+		filtered = append(filtered, binding)
+	}
+	return filtered
+}
+
+// SortBindings sorts the bindings from the most to the least reputable by the criterion
+func SortBindings(bindings []service.SvcBinding, sortBy string) error {
+	var less func(a, b service.BindingStats) bool
+	switch sortBy {
+	case SortBySuccessRate:
+		less = func(a, b service.BindingStats) bool { return a.SuccessRate().GT(b.SuccessRate()) }
+	case SortByAvgRspBlocks:
+		less = func(a, b service.BindingStats) bool { return a.AvgRspBlocks().LT(b.AvgRspBlocks()) }
+	case SortByServedRequests:
+		less = func(a, b service.BindingStats) bool { return a.ServedRequests > b.ServedRequests }
+	case SortBySlashes:
+		less = func(a, b service.BindingStats) bool { return a.Slashes < b.Slashes }
+	default:
+		return fmt.Errorf("'%s' is not a valid sort criterion, valid values can be %s, %s, %s and %s",
+			sortBy, SortBySuccessRate, SortByAvgRspBlocks, SortByServedRequests, SortBySlashes)
+	}
+	sort.SliceStable(bindings, func(i, j int) bool {
+		return less(bindings[i].Stats, bindings[j].Stats)
+	})
+	return nil
+}
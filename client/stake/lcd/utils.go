@@ -229,7 +229,13 @@ func queryValidator(cliCtx context.CLIContext, cdc *codec.Codec, endpoint string
 			return
 		}
 
+		page, size, ok := utils.ParsePaginationParamsOrReturnBadRequest(w, r)
+		if !ok {
+			return
+		}
+
 		params := stake.NewQueryValidatorParams(validatorAddr)
+		params.Page, params.Size = page, size
 		bz, err := cdc.MarshalJSON(params)
 		if err != nil {
 			utils.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
@@ -4,6 +4,7 @@ import (
 	"os"
 
 	"github.com/NPC-Chain/npcchub/client/context"
+	"github.com/NPC-Chain/npcchub/client/utils"
 	"github.com/spf13/cobra"
 	"github.com/tendermint/go-amino"
 )
@@ -14,11 +15,22 @@ func GetBroadcastCommand(codec *amino.Codec) *cobra.Command {
 		Use:   "broadcast <file>",
 		Short: "Broadcast transactions generated offline",
 		Long: `Broadcast transactions created with the --generate-only flag and signed with the sign command.
-Read a transaction from <file> and broadcast it to a node.`,
+Read a transaction from <file> and broadcast it to a node.
+
+If <file> is a bundle of the prepare command signed with the sign command, the account number
+and sequence of the bundle are checked to still be those of the account first.`,
 		Example: "iriscli tx broadcast <file>",
 		Args:    cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) (err error) {
 			cliCtx := context.NewCLIContext().WithLogger(os.Stdout).WithCodec(codec)
+			bundle, isBundle, err := readTxBundle(codec, args[0])
+			if err != nil {
+				return
+			}
+			if isBundle {
+				return broadcastTxBundle(cliCtx.WithAccountDecoder(utils.GetAccountDecoder(codec)), bundle)
+			}
+
 			stdTx, err := readAndUnmarshalStdTx(cliCtx.Codec, args[0])
 			if err != nil {
 				return
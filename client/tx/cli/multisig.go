@@ -0,0 +1,419 @@
+package cli
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/NPC-Chain/npcchub/app/v1/auth"
+	"github.com/NPC-Chain/npcchub/client"
+	"github.com/NPC-Chain/npcchub/client/context"
+	"github.com/NPC-Chain/npcchub/client/keys"
+	"github.com/NPC-Chain/npcchub/client/utils"
+	crkeys "github.com/NPC-Chain/npcchub/crypto/keys"
+	sdk "github.com/NPC-Chain/npcchub/types"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/tendermint/go-amino"
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/multisig"
+)
+
+// multisigBundle is a transaction of a multisig account with the signatures collected so far
+// and the account number and sequence they sign
+type multisigBundle struct {
+	ChainID       string              `json:"chain_id"`
+	AccountNumber uint64              `json:"account_number"`
+	Sequence      uint64              `json:"sequence"`
+	PubKey        crypto.PubKey       `json:"pub_key"`
+	Tx            auth.StdTx          `json:"tx"`
+	Signatures    []auth.StdSignature `json:"signatures"`
+}
+
+func (b multisigBundle) signBytes() []byte {
+	return auth.StdSignBytes(b.ChainID, b.AccountNumber, b.Sequence, b.Tx.Fee, b.Tx.GetMsgs(), b.Tx.GetMemo())
+}
+
+func (b multisigBundle) multisigPubKey() multisig.PubKeyMultisigThreshold {
+	return b.PubKey.(multisig.PubKeyMultisigThreshold)
+}
+
+// newMultisigBundle returns the bundle of the transaction of the multisig account of the pubkey,
+// which must be the only signer of the transaction as the bundle collects a single multisignature
+func newMultisigBundle(stdTx auth.StdTx, pub crypto.PubKey, chainID string, accountNumber, sequence uint64) (multisigBundle, error) {
+	if _, ok := pub.(multisig.PubKeyMultisigThreshold); !ok {
+		return multisigBundle{}, fmt.Errorf("%s is not the pubkey of a multisig account", sdk.AccAddress(pub.Address()))
+	}
+	signers := stdTx.GetSigners()
+	if len(signers) != 1 || !signers[0].Equals(sdk.AccAddress(pub.Address())) {
+		return multisigBundle{}, fmt.Errorf("the multisig account %s must be the only signer of the transaction, got %v",
+			sdk.AccAddress(pub.Address()), signers)
+	}
+	if len(chainID) == 0 {
+		return multisigBundle{}, fmt.Errorf("missing chain-id")
+	}
+	return multisigBundle{
+		ChainID:       chainID,
+		AccountNumber: accountNumber,
+		Sequence:      sequence,
+		PubKey:        pub,
+		Tx:            stdTx,
+	}, nil
+}
+
+// keyIndex returns the index of the pubkey in the keys of the multisig account, or -1
+func (b multisigBundle) keyIndex(pub crypto.PubKey) int {
+	for i, key := range b.multisigPubKey().PubKeys {
+		if key.Equals(pub) {
+			return i
+		}
+	}
+	return -1
+}
+
+// signed returns whether the signer of the index of the multisig key has signed
+func (b multisigBundle) signed(index int) bool {
+	pub := b.multisigPubKey().PubKeys[index]
+	for _, sig := range b.Signatures {
+		if sig.PubKey.Equals(pub) {
+			return true
+		}
+	}
+	return false
+}
+
+// addSignature adds the signature of a key of the multisig account which has not signed yet,
+// after verifying it against the sign bytes of the bundle
+func (b *multisigBundle) addSignature(pub crypto.PubKey, sig []byte) error {
+	index := b.keyIndex(pub)
+	if index < 0 {
+		return fmt.Errorf("%s is not a key of the multisig account", sdk.AccAddress(pub.Address()))
+	}
+	if b.signed(index) {
+		return fmt.Errorf("%s has already signed the bundle", sdk.AccAddress(pub.Address()))
+	}
+	if !pub.VerifyBytes(b.signBytes(), sig) {
+		return fmt.Errorf("the signature of %s is invalid", sdk.AccAddress(pub.Address()))
+	}
+	b.Signatures = append(b.Signatures, auth.StdSignature{
+		PubKey:        pub,
+		Signature:     sig,
+		AccountNumber: b.AccountNumber,
+		Sequence:      b.Sequence,
+	})
+	return nil
+}
+
+// multisigTx verifies every signature of the bundle against its key of the multisig account and
+// the sign bytes, and returns the transaction signed with the multisignature once the threshold is met
+func (b multisigBundle) multisigTx(cdc *amino.Codec) (auth.StdTx, error) {
+	multisigPub := b.multisigPubKey()
+	signBytes := b.signBytes()
+	multisigSig := multisig.NewMultisig(len(multisigPub.PubKeys))
+	signed := make(map[int]bool)
+	for _, sig := range b.Signatures {
+		index := b.keyIndex(sig.PubKey)
+		if index < 0 {
+			return auth.StdTx{}, fmt.Errorf("%s is not a key of the multisig account", sdk.AccAddress(sig.PubKey.Address()))
+		}
+		if signed[index] {
+			return auth.StdTx{}, fmt.Errorf("%s has signed the bundle more than once", sdk.AccAddress(sig.PubKey.Address()))
+		}
+		if !sig.PubKey.VerifyBytes(signBytes, sig.Signature) {
+			return auth.StdTx{}, fmt.Errorf("the signature of %s is invalid", sdk.AccAddress(sig.PubKey.Address()))
+		}
+		signed[index] = true
+		if err := multisigSig.AddSignatureFromPubKey(sig.Signature, sig.PubKey, multisigPub.PubKeys); err != nil {
+			return auth.StdTx{}, err
+		}
+	}
+	if uint(len(signed)) < multisigPub.K {
+		return auth.StdTx{}, fmt.Errorf("%d of the %d required signatures are collected", len(signed), multisigPub.K)
+	}
+
+	sigBytes := cdc.MustMarshalBinaryBare(multisigSig)
+	if !multisigPub.VerifyBytes(signBytes, sigBytes) {
+		return auth.StdTx{}, fmt.Errorf("the multisignature is invalid")
+	}
+	stdSig := auth.StdSignature{Signature: sigBytes, AccountNumber: b.AccountNumber, Sequence: b.Sequence, PubKey: multisigPub}
+	return auth.NewStdTx(b.Tx.GetMsgs(), b.Tx.Fee, []auth.StdSignature{stdSig}, b.Tx.GetMemo()), nil
+}
+
+// GetMultisigCommand returns the commands coordinating the signers of a multisig account
+func GetMultisigCommand(cdc *amino.Codec, decoder auth.AccountDecoder) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "multisig",
+		Short: "Collect the signatures of a multisig account and broadcast the transaction",
+		Long: `Coordinate the signers of a k-of-n multisig account through a bundle file holding the
+transaction, the account number and sequence it is signed for, and the signatures collected so far.
+
+Example:
+iriscli tx multisig create unsigned.json k1k2k3 --chain-id=<chain-id> --output-document=bundle.json
+iriscli tx multisig sign bundle.json --name=k1
+iriscli tx multisig sign bundle.json --name=k2
+iriscli tx multisig status bundle.json
+iriscli tx multisig broadcast bundle.json
+`,
+	}
+	cmd.AddCommand(client.PostCommands(
+		getMultisigCreateCommand(cdc, decoder),
+		getMultisigSignCommand(cdc, decoder),
+		getMultisigBroadcastCommand(cdc, decoder),
+	)...)
+	// status only reads the bundle file
+	cmd.AddCommand(getMultisigStatusCommand(cdc))
+	return cmd
+}
+
+func getMultisigCreateCommand(cdc *amino.Codec, decoder auth.AccountDecoder) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "create <file> <multisig key name>",
+		Short: "Create the signature bundle of a transaction generated offline for a multisig account",
+		Long: `Create the signature bundle of the transaction read from <file>, created with the --generate-only
+flag, for the multisig key <multisig key name>, whose account must be the only signer of the transaction.
+The account number and sequence are queried from the chain, or read from the flags with --offline.`,
+		Example: "iriscli tx multisig create unsigned.json k1k2k3 --chain-id=<chain-id> --output-document=bundle.json",
+		PreRun:  preSignCmd,
+		Args:    cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			stdTx, err := readAndUnmarshalStdTx(cdc, args[0])
+			if err != nil {
+				return err
+			}
+			keybase, err := keys.GetKeyBase()
+			if err != nil {
+				return err
+			}
+			multisigInfo, err := keybase.Get(args[1])
+			if err != nil {
+				return err
+			}
+			if multisigInfo.GetType() != crkeys.TypeMulti {
+				return fmt.Errorf("%q must be of type %s: %s", args[1], crkeys.TypeMulti, multisigInfo.GetType())
+			}
+
+			txCtx := utils.NewTxContextFromCLI()
+			if _, err := newMultisigBundle(stdTx, multisigInfo.GetPubKey(), txCtx.ChainID, 0, 0); err != nil {
+				return err
+			}
+			if !viper.GetBool(flagOffline) {
+				cliCtx := context.NewCLIContext().WithCodec(cdc).WithAccountDecoder(decoder)
+				acc, err := cliCtx.GetAccount(multisigInfo.GetAddress())
+				if err != nil {
+					return err
+				}
+				txCtx = txCtx.WithAccountNumber(acc.GetAccountNumber()).WithSequence(acc.GetSequence())
+			}
+
+			bundle, err := newMultisigBundle(stdTx, multisigInfo.GetPubKey(), txCtx.ChainID, txCtx.AccountNumber, txCtx.Sequence)
+			if err != nil {
+				return err
+			}
+			return writeMultisigBundle(cdc, bundle, viper.GetString(flagOutfile))
+		},
+	}
+	cmd.Flags().Bool(flagOffline, false, "Offline mode. Do not query a full node")
+	cmd.Flags().String(flagOutfile, "", "The bundle will be written to the given file instead of STDOUT")
+	return cmd
+}
+
+func getMultisigSignCommand(cdc *amino.Codec, decoder auth.AccountDecoder) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "sign <bundle>",
+		Short: "Add the signature of a signer of the multisig account to a bundle",
+		Long: `Sign the transaction of the bundle with the key --name, which must be one of the keys of the
+multisig account, and add the signature to the bundle file. Unless --offline, the account number
+and sequence of the bundle are checked against the account first.`,
+		Example: "iriscli tx multisig sign bundle.json --name=k1",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			bundle, err := readMultisigBundle(cdc, args[0])
+			if err != nil {
+				return err
+			}
+			if chainID := viper.GetString(client.FlagChainID); len(chainID) != 0 && chainID != bundle.ChainID {
+				return fmt.Errorf("the bundle is signed for the chain %s, not %s", bundle.ChainID, chainID)
+			}
+
+			name := viper.GetString(client.FlagName)
+			keybase, err := keys.GetKeyBase()
+			if err != nil {
+				return err
+			}
+			info, err := keybase.Get(name)
+			if err != nil {
+				return err
+			}
+			index := bundle.keyIndex(info.GetPubKey())
+			if index < 0 {
+				return fmt.Errorf("%q is not a key of the multisig account", name)
+			}
+			if bundle.signed(index) {
+				return fmt.Errorf("%q has already signed the bundle", name)
+			}
+
+			if !viper.GetBool(flagOffline) {
+				cliCtx := context.NewCLIContext().WithCodec(cdc).WithAccountDecoder(decoder)
+				if err := validateMultisigBundleAccount(cliCtx, bundle); err != nil {
+					return err
+				}
+			}
+
+			passphrase, err := keys.GetPassphrase(name)
+			if err != nil {
+				return err
+			}
+			sig, pub, err := keybase.Sign(name, passphrase, bundle.signBytes())
+			if err != nil {
+				return err
+			}
+			if err := bundle.addSignature(pub, sig); err != nil {
+				return err
+			}
+
+			outfile := viper.GetString(flagOutfile)
+			if len(outfile) == 0 {
+				outfile = args[0]
+			}
+			if err := writeMultisigBundle(cdc, bundle, outfile); err != nil {
+				return err
+			}
+			printMultisigBundleStatus(bundle)
+			return nil
+		},
+	}
+	cmd.Flags().String(client.FlagName, "", "Name of the key of the multisig account to sign with")
+	cmd.Flags().Bool(flagOffline, false, "Offline mode. Do not check the account number and sequence against a full node")
+	cmd.Flags().String(flagOutfile, "", "The bundle will be written to the given file instead of <bundle>")
+	cmd.MarkFlagRequired(client.FlagName)
+	return cmd
+}
+
+func getMultisigStatusCommand(cdc *amino.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "status <bundle>",
+		Short:   "Print the signers of the multisig account who have signed a bundle and those still missing",
+		Example: "iriscli tx multisig status bundle.json",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			bundle, err := readMultisigBundle(cdc, args[0])
+			if err != nil {
+				return err
+			}
+			printMultisigBundleStatus(bundle)
+			return nil
+		},
+	}
+	return cmd
+}
+
+func getMultisigBroadcastCommand(cdc *amino.Codec, decoder auth.AccountDecoder) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "broadcast <bundle>",
+		Short: "Combine the signatures of a bundle and broadcast the transaction once the threshold is met",
+		Long: `Combine the signatures of the bundle into the multisig signature and broadcast the transaction.
+Every signature is verified against its key of the multisig account, the threshold of the multisig
+account must be met, and the account number and sequence of the bundle must still be those of the account.`,
+		Example: "iriscli tx multisig broadcast bundle.json",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			bundle, err := readMultisigBundle(cdc, args[0])
+			if err != nil {
+				return err
+			}
+			newTx, err := bundle.multisigTx(cdc)
+			if err != nil {
+				printMultisigBundleStatus(bundle)
+				return err
+			}
+
+			cliCtx := context.NewCLIContext().WithLogger(os.Stdout).WithCodec(cdc).WithAccountDecoder(decoder)
+			if err := validateMultisigBundleAccount(cliCtx, bundle); err != nil {
+				return err
+			}
+
+			txBytes, err := cdc.MarshalBinaryLengthPrefixed(newTx)
+			if err != nil {
+				return err
+			}
+			cliCtx.PrintResponse = true
+			_, err = cliCtx.BroadcastTx(txBytes)
+			return err
+		},
+	}
+	return cmd
+}
+
+// validateMultisigBundleAccount checks that the account number and sequence of the bundle are
+// still those of the multisig account, the signatures being invalid otherwise
+func validateMultisigBundleAccount(cliCtx context.CLIContext, bundle multisigBundle) error {
+	return validateBundleAccount(cliCtx, sdk.AccAddress(bundle.PubKey.Address()), bundle.AccountNumber, bundle.Sequence)
+}
+
+// validateBundleAccount checks that the account number and sequence a bundle is signed for are
+// still those of the account, the bundle being stale once the account has sent another transaction
+func validateBundleAccount(cliCtx context.CLIContext, addr sdk.AccAddress, accountNumber, sequence uint64) error {
+	acc, err := cliCtx.GetAccount(addr)
+	if err != nil {
+		return err
+	}
+	if acc.GetAccountNumber() != accountNumber || acc.GetSequence() != sequence {
+		return fmt.Errorf("the bundle is signed for the account number %d and sequence %d but %s has the account number %d and sequence %d, the bundle is stale",
+			accountNumber, sequence, addr, acc.GetAccountNumber(), acc.GetSequence())
+	}
+	return nil
+}
+
+func printMultisigBundleStatus(bundle multisigBundle) {
+	multisigPub := bundle.multisigPubKey()
+	signBytes := bundle.signBytes()
+
+	fmt.Printf("Multisig account: %s [threshold: %d/%d]\n", sdk.AccAddress(bundle.PubKey.Address()), multisigPub.K, len(multisigPub.PubKeys))
+	fmt.Printf("Chain ID: %s, account number: %d, sequence: %d\n\n", bundle.ChainID, bundle.AccountNumber, bundle.Sequence)
+	fmt.Println("Signers:")
+	for i, pub := range multisigPub.PubKeys {
+		status := "MISSING"
+		for _, sig := range bundle.Signatures {
+			if sig.PubKey.Equals(pub) {
+				status = "SIGNED"
+				if !pub.VerifyBytes(signBytes, sig.Signature) {
+					status = "ERROR: signature invalid"
+				}
+			}
+		}
+		fmt.Printf("  %d: %s\t[%s]\n", i, sdk.AccAddress(pub.Address()), status)
+	}
+
+	fmt.Println("")
+	if missing := int(multisigPub.K) - len(bundle.Signatures); missing > 0 {
+		fmt.Printf("%d more signature(s) required\n", missing)
+	} else {
+		fmt.Println("The threshold is met, the bundle can be broadcast")
+	}
+}
+
+func readMultisigBundle(cdc *amino.Codec, filename string) (bundle multisigBundle, err error) {
+	bz, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return
+	}
+	if err = cdc.UnmarshalJSON(bz, &bundle); err != nil {
+		return
+	}
+	if _, ok := bundle.PubKey.(multisig.PubKeyMultisigThreshold); !ok {
+		err = fmt.Errorf("%s is not the bundle of a multisig account", filename)
+	}
+	return
+}
+
+func writeMultisigBundle(cdc *amino.Codec, bundle multisigBundle, filename string) error {
+	json, err := cdc.MarshalJSONIndent(bundle, "", "  ")
+	if err != nil {
+		return err
+	}
+	if len(filename) == 0 {
+		fmt.Printf("%s\n", json)
+		return nil
+	}
+	return ioutil.WriteFile(filename, append(json, '\n'), 0644)
+}
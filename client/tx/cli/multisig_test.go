@@ -0,0 +1,163 @@
+package cli
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/NPC-Chain/npcchub/app/v1/auth"
+	"github.com/NPC-Chain/npcchub/client"
+	"github.com/NPC-Chain/npcchub/codec"
+	sdk "github.com/NPC-Chain/npcchub/types"
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/multisig"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
+)
+
+type testMsg struct {
+	Signers []sdk.AccAddress `json:"signers"`
+}
+
+func (msg testMsg) Route() string                { return "test" }
+func (msg testMsg) Type() string                 { return "test" }
+func (msg testMsg) ValidateBasic() sdk.Error     { return nil }
+func (msg testMsg) GetSigners() []sdk.AccAddress { return msg.Signers }
+func (msg testMsg) GetSignBytes() []byte {
+	return sdk.MustSortJSON(msgCdc.MustMarshalJSON(msg))
+}
+
+var msgCdc = newTestCodec()
+
+func newTestCodec() *codec.Codec {
+	cdc := codec.New()
+	codec.RegisterCrypto(cdc)
+	cdc.RegisterInterface((*sdk.Msg)(nil), nil)
+	cdc.RegisterConcrete(testMsg{}, "test/testMsg", nil)
+	return cdc
+}
+
+// a 2 of 3 multisig account
+func newTestMultisig() ([]crypto.PrivKey, multisig.PubKeyMultisigThreshold) {
+	privs := []crypto.PrivKey{secp256k1.GenPrivKey(), secp256k1.GenPrivKey(), secp256k1.GenPrivKey()}
+	pubs := []crypto.PubKey{privs[0].PubKey(), privs[1].PubKey(), privs[2].PubKey()}
+	return privs, multisig.NewPubKeyMultisigThreshold(2, pubs).(multisig.PubKeyMultisigThreshold)
+}
+
+func newTestTx(signers ...sdk.AccAddress) auth.StdTx {
+	return auth.NewStdTx([]sdk.Msg{testMsg{signers}}, auth.NewStdFee(200000), nil, "")
+}
+
+func TestNewMultisigBundle(t *testing.T) {
+	privs, multisigPub := newTestMultisig()
+	multisigAddr := sdk.AccAddress(multisigPub.Address())
+	otherAddr := sdk.AccAddress(privs[0].PubKey().Address())
+
+	bundle, err := newMultisigBundle(newTestTx(multisigAddr), multisigPub, "test-chain", 3, 5)
+	require.NoError(t, err)
+	require.Equal(t, uint64(3), bundle.AccountNumber)
+	require.Equal(t, uint64(5), bundle.Sequence)
+
+	// the multisig account must be the only signer
+	_, err = newMultisigBundle(newTestTx(multisigAddr, otherAddr), multisigPub, "test-chain", 3, 5)
+	require.Error(t, err)
+	_, err = newMultisigBundle(newTestTx(otherAddr), multisigPub, "test-chain", 3, 5)
+	require.Error(t, err)
+
+	_, err = newMultisigBundle(newTestTx(otherAddr), privs[0].PubKey(), "test-chain", 3, 5)
+	require.Error(t, err)
+	_, err = newMultisigBundle(newTestTx(multisigAddr), multisigPub, "", 3, 5)
+	require.Error(t, err)
+}
+
+func TestMultisigBundleSign(t *testing.T) {
+	privs, multisigPub := newTestMultisig()
+	bundle, err := newMultisigBundle(newTestTx(sdk.AccAddress(multisigPub.Address())), multisigPub, "test-chain", 3, 5)
+	require.NoError(t, err)
+
+	sign := func(priv crypto.PrivKey, msg []byte) []byte {
+		sig, err := priv.Sign(msg)
+		require.NoError(t, err)
+		return sig
+	}
+
+	require.NoError(t, bundle.addSignature(privs[0].PubKey(), sign(privs[0], bundle.signBytes())))
+	require.True(t, bundle.signed(0))
+	require.False(t, bundle.signed(1))
+
+	// signed twice
+	require.Error(t, bundle.addSignature(privs[0].PubKey(), sign(privs[0], bundle.signBytes())))
+	// not a key of the multisig account
+	other := secp256k1.GenPrivKey()
+	require.Error(t, bundle.addSignature(other.PubKey(), sign(other, bundle.signBytes())))
+	// signed for another sequence
+	stale := bundle
+	stale.Sequence++
+	require.Error(t, bundle.addSignature(privs[1].PubKey(), sign(privs[1], stale.signBytes())))
+	require.Len(t, bundle.Signatures, 1)
+}
+
+func TestMultisigBundleBroadcast(t *testing.T) {
+	privs, multisigPub := newTestMultisig()
+	bundle, err := newMultisigBundle(newTestTx(sdk.AccAddress(multisigPub.Address())), multisigPub, "test-chain", 3, 5)
+	require.NoError(t, err)
+
+	for _, priv := range privs[:2] {
+		sig, err := priv.Sign(bundle.signBytes())
+		require.NoError(t, err)
+		require.NoError(t, bundle.addSignature(priv.PubKey(), sig))
+
+		// the threshold is met by the second signature only
+		_, err = bundle.multisigTx(msgCdc)
+		require.Equal(t, len(bundle.Signatures) < 2, err != nil)
+	}
+
+	stdTx, err := bundle.multisigTx(msgCdc)
+	require.NoError(t, err)
+	require.Len(t, stdTx.Signatures, 1)
+	require.True(t, multisigPub.VerifyBytes(bundle.signBytes(), stdTx.Signatures[0].Signature))
+
+	// the signatures edited in the bundle file are verified
+	tampered := bundle
+	tampered.Signatures = append([]auth.StdSignature{}, bundle.Signatures...)
+	tampered.Signatures[1].Signature = tampered.Signatures[0].Signature
+	_, err = tampered.multisigTx(msgCdc)
+	require.Error(t, err)
+
+	tampered.Signatures = append([]auth.StdSignature{}, bundle.Signatures[0], bundle.Signatures[0])
+	_, err = tampered.multisigTx(msgCdc)
+	require.Error(t, err)
+}
+
+func TestMultisigBundleFile(t *testing.T) {
+	privs, multisigPub := newTestMultisig()
+	cdc := newTestCodec()
+
+	bundle, err := newMultisigBundle(newTestTx(sdk.AccAddress(multisigPub.Address())), multisigPub, "test-chain", 3, 5)
+	require.NoError(t, err)
+	sig, err := privs[2].Sign(bundle.signBytes())
+	require.NoError(t, err)
+	require.NoError(t, bundle.addSignature(privs[2].PubKey(), sig))
+
+	dir, err := ioutil.TempDir("", "multisig")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	filename := filepath.Join(dir, "bundle.json")
+	require.NoError(t, writeMultisigBundle(cdc, bundle, filename))
+
+	read, err := readMultisigBundle(cdc, filename)
+	require.NoError(t, err)
+	require.Equal(t, bundle.signBytes(), read.signBytes())
+	require.True(t, read.signed(2))
+
+	// status only reads the bundle, it has none of the flags of the commands posting txs
+	cmd := GetMultisigCommand(cdc, nil)
+	status, _, err := cmd.Find([]string{"status"})
+	require.NoError(t, err)
+	require.Nil(t, status.Flags().Lookup(client.FlagFrom))
+	require.NoError(t, status.RunE(status, []string{filename}))
+	broadcast, _, err := cmd.Find([]string{"broadcast"})
+	require.NoError(t, err)
+	require.NotNil(t, broadcast.Flags().Lookup(client.FlagFrom))
+}
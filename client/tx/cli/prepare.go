@@ -0,0 +1,266 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/NPC-Chain/npcchub/app/protocol"
+	"github.com/NPC-Chain/npcchub/app/v1/auth"
+	"github.com/NPC-Chain/npcchub/client"
+	"github.com/NPC-Chain/npcchub/client/context"
+	"github.com/NPC-Chain/npcchub/client/keys"
+	"github.com/NPC-Chain/npcchub/client/utils"
+	fee "github.com/NPC-Chain/npcchub/modules/auth"
+	sdk "github.com/NPC-Chain/npcchub/types"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/tendermint/go-amino"
+)
+
+const flagFeeAdjustment = "fee-adjustment"
+
+// txBundle is a transaction with the chain ID, account number and sequence of its single signer,
+// so that it is signed with no access to a full node
+type txBundle struct {
+	ChainID       string         `json:"chain_id"`
+	Address       sdk.AccAddress `json:"address"`
+	AccountNumber uint64         `json:"account_number"`
+	Sequence      uint64         `json:"sequence"`
+	SuggestedFee  sdk.Coins      `json:"suggested_fee"`
+	Tx            auth.StdTx     `json:"tx"`
+}
+
+// GetPrepareCommand returns the command preparing a transaction to be signed offline
+func GetPrepareCommand(cdc *amino.Codec, decoder auth.AccountDecoder) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "prepare <file>",
+		Short: "Prepare a transaction generated offline to be signed with no access to a full node",
+		Long: `Prepare the transaction read from <file>, created with the --generate-only flag, for the sign
+command on an air-gapped machine. The transaction must have a single signer, whose account number
+and sequence, the chain ID and the fee suggested by the base gas price are queried from the chain
+into a bundle file. The suggested fee is the fee of the transaction unless it has one.
+
+The base gas price may rise until the signed transaction is broadcast, which is then rejected if
+its fee is too low. The suggested fee is the fee of the current base gas price multiplied by
+--fee-adjustment to leave headroom for the rise.
+
+Example:
+iriscli tx prepare unsigned.json --output-document=bundle.json
+iriscli tx sign bundle.json --name=<key name> --output-document=signed.json
+iriscli tx broadcast signed.json
+`,
+		Example: "iriscli tx prepare unsigned.json --output-document=bundle.json",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			stdTx, err := readAndUnmarshalStdTx(cdc, args[0])
+			if err != nil {
+				return err
+			}
+			signer, err := txBundleSigner(stdTx)
+			if err != nil {
+				return err
+			}
+
+			cliCtx := context.NewCLIContext().WithCodec(cdc).WithAccountDecoder(decoder)
+			chainID := viper.GetString(client.FlagChainID)
+			if len(chainID) == 0 {
+				node, err := cliCtx.GetNode()
+				if err != nil {
+					return err
+				}
+				status, err := node.Status()
+				if err != nil {
+					return err
+				}
+				chainID = status.NodeInfo.Network
+			}
+			acc, err := cliCtx.GetAccount(signer)
+			if err != nil {
+				return err
+			}
+
+			res, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s", protocol.FeeRoute, fee.QueryBaseGasPrice), nil)
+			if err != nil {
+				return err
+			}
+			var baseGasPrice fee.BaseGasPrice
+			if err := cdc.UnmarshalJSON(res, &baseGasPrice); err != nil {
+				return err
+			}
+			bundle, err := newTxBundle(stdTx, chainID, acc.GetAccountNumber(), acc.GetSequence(),
+				baseGasPrice.BaseGasPrice, viper.GetFloat64(flagFeeAdjustment))
+			if err != nil {
+				return err
+			}
+			if !bundle.Tx.Fee.Amount.IsAllGTE(bundle.SuggestedFee) {
+				fmt.Fprintf(os.Stderr, "WARNING: The fee %s of the transaction is below the suggested fee %s\n", bundle.Tx.Fee.Amount, bundle.SuggestedFee)
+			}
+			return writeTxBundle(cdc, bundle, viper.GetString(flagOutfile))
+		},
+	}
+	cmd.Flags().String(flagOutfile, "", "The bundle will be written to the given file instead of STDOUT")
+	cmd.Flags().Float64(flagFeeAdjustment, 1.5, "Factor multiplied against the fee of the current base gas price to suggest the fee, no less than 1")
+	return cmd
+}
+
+// txBundleSigner returns the signer of the transaction, a bundle holding the account number
+// and sequence of a single signer
+func txBundleSigner(stdTx auth.StdTx) (sdk.AccAddress, error) {
+	signers := stdTx.GetSigners()
+	if len(signers) != 1 {
+		return nil, fmt.Errorf("the transaction must have a single signer to be prepared, got %d", len(signers))
+	}
+	return signers[0], nil
+}
+
+// newTxBundle returns the bundle of the transaction with the fee of the base gas price multiplied by
+// the fee adjustment as the suggested fee, which is the fee of the transaction unless it has one
+func newTxBundle(stdTx auth.StdTx, chainID string, accountNumber, sequence uint64, baseGasPrice sdk.Int, feeAdjustment float64) (txBundle, error) {
+	signer, err := txBundleSigner(stdTx)
+	if err != nil {
+		return txBundle{}, err
+	}
+	if feeAdjustment < 1 {
+		return txBundle{}, fmt.Errorf("the fee adjustment must be no less than 1, got %v", feeAdjustment)
+	}
+
+	gas := uint64(feeAdjustment * float64(stdTx.Fee.Gas))
+	suggestedFee := sdk.NewCoins(sdk.NewCoin(sdk.IrisAtto, baseGasPrice.MulRaw(int64(gas))))
+	if stdTx.Fee.Amount.Empty() {
+		stdTx.Fee = auth.NewStdFee(stdTx.Fee.Gas, suggestedFee...)
+	}
+	return txBundle{
+		ChainID:       chainID,
+		Address:       signer,
+		AccountNumber: accountNumber,
+		Sequence:      sequence,
+		SuggestedFee:  suggestedFee,
+		Tx:            stdTx,
+	}, nil
+}
+
+// validateTxBundleSigner checks that the key of the address signs for the signer of the bundle
+func validateTxBundleSigner(bundle txBundle, addr sdk.AccAddress) error {
+	signer, err := txBundleSigner(bundle.Tx)
+	if err != nil {
+		return err
+	}
+	if !signer.Equals(bundle.Address) {
+		return fmt.Errorf("the bundle is prepared for %s but the signer of the transaction is %s", bundle.Address, signer)
+	}
+	if !addr.Equals(bundle.Address) {
+		return fmt.Errorf("the bundle is prepared for %s, not for the key of %s", bundle.Address, addr)
+	}
+	return nil
+}
+
+// validateTxBundleSignFlags rejects the flags of the sign command a bundle is not signed with,
+// the bundle being always signed offline into a signed bundle
+func validateTxBundleSignFlags() error {
+	switch {
+	case viper.GetBool(flagSigOnly):
+		return fmt.Errorf("--%s is not supported when signing a bundle", flagSigOnly)
+	case viper.GetBool(flagValidateSigs):
+		return fmt.Errorf("--%s is not supported when signing a bundle", flagValidateSigs)
+	case len(viper.GetString(flagMultisig)) != 0:
+		return fmt.Errorf("--%s is not supported when signing a bundle, use the multisig commands", flagMultisig)
+	}
+	return nil
+}
+
+// signTxBundle signs the transaction of the bundle with the key of the name, with the chain ID,
+// account number and sequence of the bundle and no query to a full node
+func signTxBundle(cdc *amino.Codec, bundle txBundle, name string) error {
+	if err := validateTxBundleSignFlags(); err != nil {
+		return err
+	}
+	keybase, err := keys.GetKeyBase()
+	if err != nil {
+		return err
+	}
+	info, err := keybase.Get(name)
+	if err != nil {
+		return err
+	}
+	if err := validateTxBundleSigner(bundle, info.GetAddress()); err != nil {
+		return err
+	}
+	if chainID := viper.GetString(client.FlagChainID); len(chainID) != 0 && chainID != bundle.ChainID {
+		return fmt.Errorf("the bundle is prepared for the chain %s, not %s", bundle.ChainID, chainID)
+	}
+	txCtx := utils.NewTxContextFromCLI().
+		WithChainID(bundle.ChainID).
+		WithAccountNumber(bundle.AccountNumber).
+		WithSequence(bundle.Sequence)
+	cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+	appendSig := viper.GetBool(flagAppend)
+	newTx, err := utils.SignStdTx(txCtx, cliCtx, name, bundle.Tx, appendSig, true)
+	if err != nil {
+		return err
+	}
+	bundle.Tx = newTx
+	return writeTxBundle(cdc, bundle, viper.GetString(flagOutfile))
+}
+
+// broadcastTxBundle broadcasts the signed transaction of the bundle, once checked that the
+// account number and sequence of the bundle are still those of the account
+func broadcastTxBundle(cliCtx context.CLIContext, bundle txBundle) error {
+	if len(bundle.Tx.GetSignatures()) == 0 {
+		return fmt.Errorf("the transaction of the bundle is not signed")
+	}
+	if err := validateBundleAccount(cliCtx, bundle.Address, bundle.AccountNumber, bundle.Sequence); err != nil {
+		return err
+	}
+
+	txBytes, err := cliCtx.Codec.MarshalBinaryLengthPrefixed(bundle.Tx)
+	if err != nil {
+		return err
+	}
+	cliCtx.PrintResponse = true
+	_, err = cliCtx.BroadcastTx(txBytes)
+	return err
+}
+
+// readTxBundle reads the bundle of the file, and returns false if the file holds a transaction
+// instead, which has no chain ID
+func readTxBundle(cdc *amino.Codec, filename string) (bundle txBundle, ok bool, err error) {
+	bz, err := ioutil.ReadFile(filename)
+	if err != nil || !isTxBundle(bz) {
+		return
+	}
+	if err = cdc.UnmarshalJSON(bz, &bundle); err != nil {
+		return
+	}
+	if bundle.Address.Empty() {
+		err = fmt.Errorf("%s is not the bundle of a prepared transaction", filename)
+		return
+	}
+	return bundle, true, nil
+}
+
+func isTxBundleFile(filename string) bool {
+	bz, err := ioutil.ReadFile(filename)
+	return err == nil && isTxBundle(bz)
+}
+
+func isTxBundle(bz []byte) bool {
+	var header struct {
+		ChainID string `json:"chain_id"`
+	}
+	return json.Unmarshal(bz, &header) == nil && len(header.ChainID) != 0
+}
+
+func writeTxBundle(cdc *amino.Codec, bundle txBundle, filename string) error {
+	bz, err := cdc.MarshalJSONIndent(bundle, "", "  ")
+	if err != nil {
+		return err
+	}
+	if len(filename) == 0 {
+		fmt.Printf("%s\n", bz)
+		return nil
+	}
+	return ioutil.WriteFile(filename, append(bz, '\n'), 0644)
+}
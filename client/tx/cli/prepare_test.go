@@ -0,0 +1,98 @@
+package cli
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/NPC-Chain/npcchub/app/v1/auth"
+	sdk "github.com/NPC-Chain/npcchub/types"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
+)
+
+func TestNewTxBundle(t *testing.T) {
+	addr1 := sdk.AccAddress(secp256k1.GenPrivKey().PubKey().Address())
+	addr2 := sdk.AccAddress(secp256k1.GenPrivKey().PubKey().Address())
+	price := sdk.NewInt(20)
+
+	// the tx with no fee pays the suggested one, with the headroom of the adjustment
+	bundle, err := newTxBundle(newTestTx(addr1), "test-chain", 3, 5, price, 1.5)
+	require.NoError(t, err)
+	require.Equal(t, addr1, bundle.Address)
+	require.Equal(t, uint64(3), bundle.AccountNumber)
+	require.Equal(t, uint64(5), bundle.Sequence)
+	require.Equal(t, sdk.NewCoins(sdk.NewCoin(sdk.IrisAtto, sdk.NewInt(20*300000))), bundle.SuggestedFee)
+	require.Equal(t, bundle.SuggestedFee, bundle.Tx.Fee.Amount)
+
+	// the fee of the tx is kept
+	stdTx := newTestTx(addr1)
+	stdTx.Fee = auth.NewStdFee(200000, sdk.NewInt64Coin(sdk.IrisAtto, 1))
+	bundle, err = newTxBundle(stdTx, "test-chain", 3, 5, price, 1)
+	require.NoError(t, err)
+	require.Equal(t, sdk.NewCoins(sdk.NewCoin(sdk.IrisAtto, sdk.NewInt(20*200000))), bundle.SuggestedFee)
+	require.Equal(t, stdTx.Fee, bundle.Tx.Fee)
+
+	// a bundle holds the account number and sequence of a single signer
+	_, err = newTxBundle(newTestTx(addr1, addr2), "test-chain", 3, 5, price, 1.5)
+	require.Error(t, err)
+	_, err = newTxBundle(newTestTx(addr1), "test-chain", 3, 5, price, 0.5)
+	require.Error(t, err)
+}
+
+func TestValidateTxBundleSigner(t *testing.T) {
+	addr1 := sdk.AccAddress(secp256k1.GenPrivKey().PubKey().Address())
+	addr2 := sdk.AccAddress(secp256k1.GenPrivKey().PubKey().Address())
+	bundle, err := newTxBundle(newTestTx(addr1), "test-chain", 3, 5, sdk.NewInt(20), 1.5)
+	require.NoError(t, err)
+
+	require.NoError(t, validateTxBundleSigner(bundle, addr1))
+	require.Error(t, validateTxBundleSigner(bundle, addr2))
+
+	// the address of the bundle edited to another account
+	bundle.Address = addr2
+	require.Error(t, validateTxBundleSigner(bundle, addr2))
+}
+
+func TestValidateTxBundleSignFlags(t *testing.T) {
+	defer viper.Reset()
+	require.NoError(t, validateTxBundleSignFlags())
+
+	for _, flag := range []string{flagSigOnly, flagValidateSigs} {
+		viper.Set(flag, true)
+		require.Error(t, validateTxBundleSignFlags(), flag)
+		viper.Set(flag, false)
+	}
+	viper.Set(flagMultisig, sdk.AccAddress(secp256k1.GenPrivKey().PubKey().Address()).String())
+	require.Error(t, validateTxBundleSignFlags())
+}
+
+func TestTxBundleFile(t *testing.T) {
+	cdc := newTestCodec()
+	addr := sdk.AccAddress(secp256k1.GenPrivKey().PubKey().Address())
+	bundle, err := newTxBundle(newTestTx(addr), "test-chain", 3, 5, sdk.NewInt(20), 1.5)
+	require.NoError(t, err)
+
+	dir, err := ioutil.TempDir("", "prepare")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	bundleFile := filepath.Join(dir, "bundle.json")
+	require.NoError(t, writeTxBundle(cdc, bundle, bundleFile))
+	require.True(t, isTxBundleFile(bundleFile))
+	read, ok, err := readTxBundle(cdc, bundleFile)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, bundle.Address, read.Address)
+	require.Equal(t, bundle.SuggestedFee, read.SuggestedFee)
+
+	// a transaction is not a bundle
+	txFile := filepath.Join(dir, "tx.json")
+	require.NoError(t, ioutil.WriteFile(txFile, cdc.MustMarshalJSON(bundle.Tx), 0644))
+	require.False(t, isTxBundleFile(txFile))
+	_, ok, err = readTxBundle(cdc, txFile)
+	require.NoError(t, err)
+	require.False(t, ok)
+}
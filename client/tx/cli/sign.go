@@ -34,6 +34,11 @@ func GetSignCommand(codec *amino.Codec, decoder auth.AccountDecoder) *cobra.Comm
 		Long: `Sign transactions created with the --generate-only flag.
 Read a transaction from <file>, sign it, and print its JSON encoding.
 
+If <file> is a bundle of the prepare command, the transaction is signed with the chain ID,
+account number and sequence of the bundle, with no access to a full node, and the signed
+bundle is printed for the broadcast command. The key must be the one of the signer of the bundle,
+--offline is implied and --signature-only, --validate-signatures and --multisig are not supported.
+
 If the flag --signature-only flag is set, it will output a JSON representation
 of the generated signature only.
 
@@ -70,10 +75,10 @@ be generated via the 'multisign' command.`,
 	return cmd
 }
 
-func preSignCmd(cmd *cobra.Command, _ []string) {
+func preSignCmd(cmd *cobra.Command, args []string) {
 	// Conditionally mark the account and sequence numbers required as no RPC
-	// query will be done.
-	if viper.GetBool(flagOffline) {
+	// query will be done, unless they are read from a bundle.
+	if viper.GetBool(flagOffline) && !(len(args) > 0 && isTxBundleFile(args[0])) {
 		cmd.MarkFlagRequired(client.FlagAccountNumber)
 		cmd.MarkFlagRequired(client.FlagSequence)
 	}
@@ -81,6 +86,14 @@ func preSignCmd(cmd *cobra.Command, _ []string) {
 
 func makeSignCmd(cdc *amino.Codec, decoder auth.AccountDecoder) func(cmd *cobra.Command, args []string) error {
 	return func(cmd *cobra.Command, args []string) (err error) {
+		bundle, isBundle, err := readTxBundle(cdc, args[0])
+		if err != nil {
+			return err
+		}
+		if isBundle {
+			return signTxBundle(cdc, bundle, viper.GetString(client.FlagName))
+		}
+
 		if len(viper.GetString(client.FlagChainID)) == 0 {
 			return fmt.Errorf("missing chain-id")
 		}
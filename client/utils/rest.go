@@ -131,6 +131,25 @@ func ParseUint64OrReturnBadRequest(w http.ResponseWriter, s string) (n uint64, o
 	return n, true
 }
 
+// ParsePaginationParamsOrReturnBadRequest parses the optional page and size args of the list
+// queries. A size of 0, the default, selects the whole list.
+func ParsePaginationParamsOrReturnBadRequest(w http.ResponseWriter, r *http.Request) (page uint64, size uint16, ok bool) {
+	if pageStr := r.URL.Query().Get("page"); len(pageStr) > 0 {
+		if page, ok = ParseUint64OrReturnBadRequest(w, pageStr); !ok {
+			return 0, 0, false
+		}
+	}
+	if sizeStr := r.URL.Query().Get("size"); len(sizeStr) > 0 {
+		n, err := strconv.ParseUint(sizeStr, 10, 16)
+		if err != nil {
+			WriteErrorResponse(w, http.StatusBadRequest, fmt.Sprintf("'%s' is not a valid uint16", sizeStr))
+			return 0, 0, false
+		}
+		size = uint16(n)
+	}
+	return page, size, true
+}
+
 // ParseFloat64OrReturnBadRequest converts s to a float64 value. It returns a
 // default value, defaultIfEmpty, if the string is empty.
 func ParseFloat64OrReturnBadRequest(w http.ResponseWriter, s string, defaultIfEmpty float64) (n float64, ok bool) {
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"path/filepath"
 	"strings"
 
 	"github.com/NPC-Chain/npcchub/app"
@@ -12,6 +13,7 @@ import (
 	"github.com/NPC-Chain/npcchub/client"
 	"github.com/NPC-Chain/npcchub/server"
 	irisInit "github.com/NPC-Chain/npcchub/server/init"
+	sdk "github.com/NPC-Chain/npcchub/types"
 	"github.com/NPC-Chain/npcchub/version"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
@@ -68,6 +70,9 @@ func main() {
 		server.ResetCmd(ctx, cdc, resetAppState),
 		server.ExportCmd(ctx, cdc, exportAppStateAndTMValidators),
 		server.SnapshotCmd(ctx, cdc, resetAppState),
+		server.PruneCmd(ctx),
+		server.MigrateDBCmd(ctx),
+		server.DebugCmd(ctx, replayAppState),
 		client.LineBreak,
 	)
 
@@ -81,12 +86,41 @@ func main() {
 }
 
 func newApp(logger log.Logger, db dbm.DB, traceStore io.Writer, config *cfg.InstrumentationConfig) abci.Application {
-	return app.NewIrisApp(logger, db, config, traceStore,
-		bam.SetPruning(viper.GetString("pruning")),
+	options := []func(*bam.BaseApp){
+		bam.SetPruningOptions(pruningOptions()),
 		bam.SetMinimumFees(viper.GetString("minimum_fees")),
+		bam.SetMaxPendingTxs(uint64(viper.GetInt64("max_pending_txs"))),
 		bam.SetCheckInvariant(viper.GetBool("check_invariant")),
 		bam.SetTrackCoinFlow(viper.GetBool("track_coin_flow")),
-	)
+		bam.SetHaltExport(rootify(viper.GetString("genesis_file")), rootify(viper.GetString("halt_export_file"))),
+		bam.SetQueryPool(viper.GetInt("query_workers"), viper.GetDuration("query_timeout")),
+		bam.SetQueryGasLimit(uint64(viper.GetInt64("query_gas_limit"))),
+	}
+	if sink := viper.GetString("streaming_sink"); len(sink) > 0 {
+		streamSink, err := bam.NewStreamSink(sink, rootify(viper.GetString("streaming_path")), viper.GetInt64("streaming_rotate_size"))
+		if err != nil {
+			panic(err)
+		}
+		options = append(options, bam.SetStreaming(streamSink, viper.GetBool("streaming_halt_on_error")))
+	}
+	return app.NewIrisApp(logger, db, config, traceStore, options...)
+}
+
+// pruningOptions returns the options of the pruning strategy set by iris.toml or the start flags
+func pruningOptions() sdk.PruningOptions {
+	pruning, err := server.PruningOptions()
+	if err != nil {
+		panic(err)
+	}
+	return pruning
+}
+
+// rootify returns the path relative to the node home if it isn't absolute
+func rootify(path string) string {
+	if len(path) == 0 || filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(viper.GetString(cli.HomeFlag), path)
 }
 
 func exportAppStateAndTMValidators(ctx *server.Context,
@@ -112,7 +146,7 @@ func exportAppStateAndTMValidators(ctx *server.Context,
 
 func resetAppState(ctx *server.Context,
 	logger log.Logger, db dbm.DB, traceStore io.Writer, height int64) error {
-	gApp := app.NewIrisApp(logger, db, ctx.Config.Instrumentation, traceStore)
+	gApp := app.NewIrisApp(logger, db, ctx.Config.Instrumentation, traceStore, bam.SetPruningOptions(pruningOptions()))
 	if height > 0 {
 		if replay, replayHeight := gApp.ResetOrReplay(height); replay {
 			_, err := startNodeAndReplay(ctx, gApp, replayHeight)
@@ -127,6 +161,15 @@ func resetAppState(ctx *server.Context,
 	return nil
 }
 
+func replayAppState(ctx *server.Context,
+	logger log.Logger, db dbm.DB, traceStore io.Writer, height int64) (abci.Application, error) {
+	gApp := app.NewIrisApp(logger, db, ctx.Config.Instrumentation, traceStore, bam.SetPruningOptions(sdk.PruneNothing.Options()))
+	if err := gApp.LoadHeightWithProtocol(height); err != nil {
+		return nil, err
+	}
+	return gApp, nil
+}
+
 func startNodeAndReplay(ctx *server.Context, app *app.IrisApp, height int64) (n *node.Node, err error) {
 	cfg := ctx.Config
 	cfg.BaseConfig.ReplayHeight = height
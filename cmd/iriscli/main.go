@@ -64,10 +64,12 @@ func main() {
 	}
 	txCmd.AddCommand(
 		client.PostCommands(
+			txcmd.GetPrepareCommand(cdc, utils.GetAccountDecoder(cdc)),
 			txcmd.GetSignCommand(cdc, utils.GetAccountDecoder(cdc)),
 			txcmd.GetMultiSignCommand(cdc, utils.GetAccountDecoder(cdc)),
 			txcmd.GetBroadcastCommand(cdc),
 		)...)
+	txCmd.AddCommand(txcmd.GetMultisigCommand(cdc, utils.GetAccountDecoder(cdc)))
 	rootCmd.AddCommand(
 		txCmd,
 	)
@@ -96,6 +98,7 @@ func main() {
 			bankcmd.GetCmdQueryCoinType(cdc),
 			bankcmd.GetAccountCmd(cdc, utils.GetAccountDecoder(cdc)),
 			bankcmd.GetCmdQueryTokenStats(cdc, utils.GetAccountDecoder(cdc)),
+			bankcmd.GetCmdQueryBaseGasPrice(cdc),
 		)...)
 	bankCmd.AddCommand(
 		client.PostCommands(
@@ -140,12 +143,14 @@ func main() {
 			govcmd.GetCmdQueryDeposit(cdc),
 			govcmd.GetCmdQueryDeposits(cdc),
 			govcmd.GetCmdQueryTally(cdc),
+			govcmd.GetCmdSimulateParams(cdc),
 		)...)
 	govCmd.AddCommand(
 		client.PostCommands(
 			govcmd.GetCmdSubmitProposal(cdc),
 			govcmd.GetCmdDeposit(cdc),
 			govcmd.GetCmdVote(cdc),
+			govcmd.GetCmdVoteWeighted(cdc),
 		)...)
 	rootCmd.AddCommand(
 		govCmd,
@@ -208,6 +213,7 @@ func main() {
 	serviceCmd.AddCommand(
 		client.GetCommands(
 			servicecmd.GetCmdQuerySvcDef(cdc),
+			servicecmd.GetCmdQuerySvcDefVersions(cdc),
 			servicecmd.GetCmdQuerySvcBind(cdc),
 			servicecmd.GetCmdQuerySvcBinds(cdc),
 			servicecmd.GetCmdQuerySvcRequests(cdc),
@@ -216,6 +222,7 @@ func main() {
 		)...)
 	serviceCmd.AddCommand(client.PostCommands(
 		servicecmd.GetCmdSvcDef(cdc),
+		servicecmd.GetCmdSvcDefUpdate(cdc),
 		servicecmd.GetCmdSvcBind(cdc),
 		servicecmd.GetCmdSvcBindUpdate(cdc),
 		servicecmd.GetCmdSvcDisable(cdc),
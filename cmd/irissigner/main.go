@@ -0,0 +1,78 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/NPC-Chain/npcchub/crypto/keys"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/tendermint/tendermint/libs/cli"
+	dbm "github.com/tendermint/tm-db"
+)
+
+const (
+	flagListenAddr = "laddr"
+	flagCACert     = "ca-cert"
+	flagCert       = "cert"
+	flagKey        = "key"
+)
+
+// irissigner is a stand-in remote signer for testing, serving the keys of an iriscli home
+var rootCmd = &cobra.Command{
+	Use:   "irissigner",
+	Short: "Stand-in remote signer serving the keys of an iriscli home, for testing",
+	Long: `Serve the keys of an iriscli home as a remote signer, so that iriscli can sign with them
+through keys added with --remote. All the keys must be encrypted with the passphrase read
+from STDIN. With --cert and --key the signer serves TLS, and with --ca-cert it also requires
+the clients to present a certificate signed by the CA.
+
+Example:
+$ irissigner --home=$HOME/.irissigner --laddr=localhost:26660 --cert=signer.crt --key=signer.key --ca-cert=ca.crt
+`,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		db, err := dbm.NewGoLevelDB("keys", filepath.Join(viper.GetString(cli.HomeFlag), "keys"))
+		if err != nil {
+			return err
+		}
+		kb := keys.New(db)
+		defer kb.CloseDB()
+
+		fmt.Fprint(os.Stderr, "Passphrase of the keys: ")
+		passphrase, err := bufio.NewReader(os.Stdin).ReadString('\n')
+		if err != nil {
+			return err
+		}
+		handler := keys.NewRemoteSignerHandler(kb, strings.TrimRight(passphrase, "\r\n"))
+
+		server := &http.Server{Addr: viper.GetString(flagListenAddr), Handler: handler}
+		if len(viper.GetString(flagCert)) == 0 {
+			fmt.Fprintf(os.Stderr, "Serving plain HTTP on %s\n", server.Addr)
+			return server.ListenAndServe()
+		}
+		server.TLSConfig, err = keys.RemoteSignerTLSConfig(viper.GetString(flagCACert), viper.GetString(flagCert), viper.GetString(flagKey))
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(os.Stderr, "Serving HTTPS on %s\n", server.Addr)
+		return server.ListenAndServeTLS("", "")
+	},
+}
+
+func main() {
+	rootCmd.Flags().String(flagListenAddr, "localhost:26660", "Address to listen on")
+	rootCmd.Flags().String(flagCACert, "", "CA certificate file the client certificates must be signed by")
+	rootCmd.Flags().String(flagCert, "", "Certificate file of the signer, plain HTTP is served without it")
+	rootCmd.Flags().String(flagKey, "", "Key file of the certificate of the signer")
+	viper.BindPFlags(rootCmd.Flags())
+
+	executor := cli.PrepareMainCmd(rootCmd, "IRISSIGNER", os.ExpandEnv("$HOME/.irissigner"))
+	if err := executor.Execute(); err != nil {
+		os.Exit(1)
+	}
+}
@@ -1,7 +1,9 @@
 package crypto
 
 import (
+	"github.com/NPC-Chain/npcchub/crypto/secp256r1"
 	"github.com/tendermint/go-amino"
+	tmcrypto "github.com/tendermint/tendermint/crypto"
 	"github.com/tendermint/tendermint/crypto/encoding/amino"
 )
 
@@ -16,4 +18,20 @@ func init() {
 func RegisterAmino(cdc *amino.Codec) {
 	cdc.RegisterConcrete(PrivKeyLedgerSecp256k1{},
 		"tendermint/PrivKeyLedgerSecp256k1", nil)
+	cdc.RegisterConcrete(secp256r1.PubKeySecp256r1{},
+		secp256r1.PubKeyAminoRoute, nil)
+	cdc.RegisterConcrete(secp256r1.PrivKeySecp256r1{},
+		secp256r1.PrivKeyAminoRoute, nil)
+}
+
+// PrivKeyFromBytes decodes the amino encoded private key, of tendermint or of the types above
+func PrivKeyFromBytes(privKeyBytes []byte) (privKey tmcrypto.PrivKey, err error) {
+	err = cdc.UnmarshalBinaryBare(privKeyBytes, &privKey)
+	return
+}
+
+// PubKeyFromBytes decodes the amino encoded public key, of tendermint or of the types above
+func PubKeyFromBytes(pubKeyBytes []byte) (pubKey tmcrypto.PubKey, err error) {
+	err = cdc.UnmarshalBinaryBare(pubKeyBytes, &pubKey)
+	return
 }
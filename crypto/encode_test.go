@@ -6,6 +6,7 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/NPC-Chain/npcchub/crypto/secp256r1"
 	tcrypto "github.com/tendermint/tendermint/crypto"
 	"github.com/tendermint/tendermint/crypto/ed25519"
 	"github.com/tendermint/tendermint/crypto/secp256k1"
@@ -53,6 +54,8 @@ func ExamplePrintRegisteredTypes() {
 	// Output: | Type | Name | Prefix | Length | Notes |
 	//| ---- | ---- | ------ | ----- | ------ |
 	//| PrivKeyLedgerSecp256k1 | tendermint/PrivKeyLedgerSecp256k1 | 0x10CAB393 | variable |  |
+	//| PubKeySecp256r1 | irishub/PubKeySecp256r1 | 0xBDA0494A | 0x21 |  |
+	//| PrivKeySecp256r1 | irishub/PrivKeySecp256r1 | 0x5A51E260 | 0x20 |  |
 	//| PubKeyEd25519 | tendermint/PubKeyEd25519 | 0x1624DE64 | 0x20 |  |
 	//| PubKeySecp256k1 | tendermint/PubKeySecp256k1 | 0xEB5AE987 | 0x21 |  |
 	//| PubKeyMultisigThreshold | tendermint/PubKeyMultisigThreshold | 0x22C1F7E2 | variable |  |
@@ -75,6 +78,11 @@ func TestKeyEncodings(t *testing.T) {
 			privSize: 37,
 			pubSize:  38,
 		},
+		{
+			privKey:  secp256r1.GenPrivKey(),
+			privSize: 37,
+			pubSize:  38,
+		},
 	}
 
 	for _, tc := range cases {
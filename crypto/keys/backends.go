@@ -0,0 +1,310 @@
+package keys
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/NPC-Chain/npcchub/crypto"
+	"github.com/NPC-Chain/npcchub/crypto/keys/hd"
+	"github.com/pkg/errors"
+	tmcrypto "github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/xsalsa20symmetric"
+	dbm "github.com/tendermint/tm-db"
+	"golang.org/x/crypto/scrypt"
+)
+
+// Keyring backends, the storages a keybase can be backed by
+const (
+	// BackendDB keeps the keys in a LevelDB, the default
+	BackendDB = "db"
+	// BackendFile keeps every key in its own file, encrypted with the keyring passphrase
+	BackendFile = "file"
+	// BackendPass keeps every key in its own plain file of a directory, like pass does
+	BackendPass = "pass"
+	// BackendTest keeps the keys in plain files without keyring passphrase, for automation only
+	BackendTest = "test"
+	// BackendReadOnly opens the LevelDB of the keys read-only
+	BackendReadOnly = "readonly"
+)
+
+// Backends are the supported keyring backends
+var Backends = []string{BackendDB, BackendFile, BackendPass, BackendTest, BackendReadOnly}
+
+// ScryptN is the scrypt cost parameter deriving the encryption key of new file keyrings
+// from their passphrase
+const ScryptN = 1 << 15
+
+const (
+	keyringHashFile = "keyhash"
+	keyringKeysDir  = "keys"
+	scryptR         = 8
+	scryptP         = 1
+	scryptKeyLen    = 32
+)
+
+var (
+	// ErrReadOnlyKeybase is raised when a read-only keybase is asked to change a key
+	ErrReadOnlyKeybase = errors.New("the keybase is read-only")
+
+	// ErrWrongKeyringPassphrase is raised when a file keyring is opened with another passphrase
+	// than the one it was created with
+	ErrWrongKeyringPassphrase = errors.New("wrong keyring passphrase")
+)
+
+// NewInMemory creates a keybase keeping the keys in memory, for tests and automation
+func NewInMemory() Keybase {
+	return New(dbm.NewMemDB())
+}
+
+// NewPass creates a keybase keeping every key in its own file of the directory. The files
+// aren't encrypted but the private keys are, with the passphrase of each key.
+func NewPass(dir string) (Keybase, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
+	}
+	return New(dbm.NewFSDB(dir)), nil
+}
+
+// NewTest creates a keybase keeping every key in its own plain file of the directory, as
+// NewPass does. It is meant for tests and automation, the keys persisting between the
+// processes without a keyring passphrase to enter.
+func NewTest(dir string) (Keybase, error) {
+	return NewPass(dir)
+}
+
+// keyringHash is the scrypt salt and parameters of a file keyring, with the hash
+// of the derived key to check the passphrase
+type keyringHash struct {
+	KDF  string `json:"kdf"`
+	Salt string `json:"salt"`
+	N    int    `json:"n"`
+	R    int    `json:"r"`
+	P    int    `json:"p"`
+	Hash string `json:"hash"`
+}
+
+// NewFile creates a keybase keeping every key in its own file of the directory, encrypted
+// with a key derived from the keyring passphrase by scrypt. The keyring is created with
+// the passphrase if the directory doesn't hold one yet.
+func NewFile(dir, passphrase string) (Keybase, error) {
+	return newFile(dir, passphrase, ScryptN)
+}
+
+// newFile opens the file keyring of the directory, a new keyring deriving its key with the scrypt cost
+func newFile(dir, passphrase string, scryptN int) (Keybase, error) {
+	keysDir := filepath.Join(dir, keyringKeysDir)
+	if err := os.MkdirAll(keysDir, 0700); err != nil {
+		return nil, err
+	}
+
+	hashPath := filepath.Join(dir, keyringHashFile)
+	bz, err := ioutil.ReadFile(hashPath)
+	var kh keyringHash
+	switch {
+	case os.IsNotExist(err):
+		kh = keyringHash{
+			KDF:  "scrypt",
+			Salt: hex.EncodeToString(tmcrypto.CRandBytes(16)),
+			N:    scryptN,
+			R:    scryptR,
+			P:    scryptP,
+		}
+	case err != nil:
+		return nil, err
+	default:
+		if err := json.Unmarshal(bz, &kh); err != nil {
+			return nil, fmt.Errorf("invalid keyring hash %s: %v", hashPath, err)
+		}
+		if kh.KDF != "scrypt" {
+			return nil, fmt.Errorf("unrecognized KDF type: %v", kh.KDF)
+		}
+	}
+
+	salt, err := hex.DecodeString(kh.Salt)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding salt: %v", err)
+	}
+	secret, err := scrypt.Key([]byte(passphrase), salt, kh.N, kh.R, kh.P, scryptKeyLen)
+	if err != nil {
+		return nil, err
+	}
+	hash := hex.EncodeToString(tmcrypto.Sha256(secret))
+
+	if len(kh.Hash) == 0 {
+		kh.Hash = hash
+		bz, err := json.MarshalIndent(kh, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+		if err := ioutil.WriteFile(hashPath, bz, 0600); err != nil {
+			return nil, err
+		}
+	} else if kh.Hash != hash {
+		return nil, ErrWrongKeyringPassphrase
+	}
+
+	return New(encryptedDB{DB: dbm.NewFSDB(keysDir), secret: secret}), nil
+}
+
+// KeyringExists returns whether the directory holds a file keyring
+func KeyringExists(dir string) bool {
+	_, err := os.Stat(filepath.Join(dir, keyringHashFile))
+	return err == nil
+}
+
+// encryptedDB encrypts the values written to the underlying db with the secret
+type encryptedDB struct {
+	dbm.DB
+	secret []byte
+}
+
+func (db encryptedDB) encrypt(value []byte) []byte {
+	return xsalsa20symmetric.EncryptSymmetric(value, db.secret)
+}
+
+func (db encryptedDB) decrypt(value []byte) []byte {
+	if value == nil {
+		return nil
+	}
+	plain, err := xsalsa20symmetric.DecryptSymmetric(value, db.secret)
+	if err != nil {
+		panic(errors.Wrap(err, "decrypting a keyring entry"))
+	}
+	return plain
+}
+
+// Implements DB.
+func (db encryptedDB) Get(key []byte) []byte {
+	return db.decrypt(db.DB.Get(key))
+}
+
+// Implements DB.
+func (db encryptedDB) Set(key []byte, value []byte) {
+	db.DB.Set(key, db.encrypt(value))
+}
+
+// Implements DB.
+func (db encryptedDB) SetSync(key []byte, value []byte) {
+	db.DB.SetSync(key, db.encrypt(value))
+}
+
+// Implements DB.
+func (db encryptedDB) Iterator(start, end []byte) dbm.Iterator {
+	return decryptIterator{Iterator: db.DB.Iterator(start, end), db: db}
+}
+
+// Implements DB.
+func (db encryptedDB) ReverseIterator(start, end []byte) dbm.Iterator {
+	return decryptIterator{Iterator: db.DB.ReverseIterator(start, end), db: db}
+}
+
+// Implements DB.
+func (db encryptedDB) NewBatch() dbm.Batch {
+	return encryptedBatch{Batch: db.DB.NewBatch(), db: db}
+}
+
+type decryptIterator struct {
+	dbm.Iterator
+	db encryptedDB
+}
+
+// Implements Iterator.
+func (iter decryptIterator) Value() []byte {
+	return iter.db.decrypt(iter.Iterator.Value())
+}
+
+type encryptedBatch struct {
+	dbm.Batch
+	db encryptedDB
+}
+
+// Implements Batch.
+func (b encryptedBatch) Set(key, value []byte) {
+	b.Batch.Set(key, b.db.encrypt(value))
+}
+
+// readOnlyKeybase refuses to change the keys of the underlying keybase
+type readOnlyKeybase struct {
+	Keybase
+}
+
+var _ Keybase = readOnlyKeybase{}
+
+// NewReadOnly wraps a keybase so that its keys can be read and used to sign but not changed
+func NewReadOnly(kb Keybase) Keybase {
+	return readOnlyKeybase{kb}
+}
+
+func (readOnlyKeybase) Delete(name, passphrase string, skipPass bool) error {
+	return ErrReadOnlyKeybase
+}
+
+func (readOnlyKeybase) CreateMnemonic(name string, language Language, passwd string, algo SigningAlgo) (Info, string, error) {
+	return nil, "", ErrReadOnlyKeybase
+}
+
+func (readOnlyKeybase) CreateKey(name, mnemonic, passwd string) (Info, error) {
+	return nil, ErrReadOnlyKeybase
+}
+
+func (readOnlyKeybase) CreateFundraiserKey(name, mnemonic, passwd string) (Info, error) {
+	return nil, ErrReadOnlyKeybase
+}
+
+func (readOnlyKeybase) Derive(name, mnemonic, bip39Passwd, encryptPasswd string, params hd.BIP44Params) (Info, error) {
+	return nil, ErrReadOnlyKeybase
+}
+
+func (readOnlyKeybase) CreateAccount(name, mnemonic, bip39Passwd, encryptPasswd, hdPath string, algo SigningAlgo) (Info, error) {
+	return nil, ErrReadOnlyKeybase
+}
+
+func (readOnlyKeybase) DeriveAddresses(name, mnemonic, bip39Passwd, encryptPasswd string, params []hd.BIP44Params) (Info, error) {
+	return nil, ErrReadOnlyKeybase
+}
+
+func (readOnlyKeybase) CreateLedger(name string, path crypto.DerivationPath, algo SigningAlgo) (Info, error) {
+	return nil, ErrReadOnlyKeybase
+}
+
+func (readOnlyKeybase) CreateOffline(name string, pubkey tmcrypto.PubKey) (Info, error) {
+	return nil, ErrReadOnlyKeybase
+}
+
+func (readOnlyKeybase) CreateRemote(name string, signer RemoteSigner) (Info, error) {
+	return nil, ErrReadOnlyKeybase
+}
+
+func (readOnlyKeybase) CreateMulti(name string, pubkey tmcrypto.PubKey) (Info, error) {
+	return nil, ErrReadOnlyKeybase
+}
+
+func (readOnlyKeybase) Update(name, oldpass string, getNewpass func() (string, error)) error {
+	return ErrReadOnlyKeybase
+}
+
+func (readOnlyKeybase) Import(name string, armor string) error {
+	return ErrReadOnlyKeybase
+}
+
+func (readOnlyKeybase) ImportPubKey(name string, armor string) error {
+	return ErrReadOnlyKeybase
+}
+
+func (readOnlyKeybase) ImportPrivateKey(name string, passwd string, privKey tmcrypto.PrivKey) (Info, error) {
+	return nil, ErrReadOnlyKeybase
+}
+
+// IsBackend returns whether the keyring backend is supported
+func IsBackend(backend string) bool {
+	for _, b := range Backends {
+		if b == backend {
+			return true
+		}
+	}
+	return false
+}
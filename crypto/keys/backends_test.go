@@ -0,0 +1,114 @@
+package keys
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// testScryptN is a low scrypt cost keeping the tests fast
+const testScryptN = 2
+
+func TestFileKeybase(t *testing.T) {
+	dir, err := ioutil.TempDir("", "keyring")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	require.False(t, KeyringExists(dir))
+	kb, err := newFile(dir, "keyring-pass", testScryptN)
+	require.NoError(t, err)
+	require.True(t, KeyringExists(dir))
+
+	info, _, err := kb.CreateMnemonic("foo", English, "1234567890", Secp256k1)
+	require.NoError(t, err)
+
+	// the key files are encrypted
+	files, err := ioutil.ReadDir(filepath.Join(dir, keyringKeysDir))
+	require.NoError(t, err)
+	require.Len(t, files, 2)
+	for _, file := range files {
+		bz, err := ioutil.ReadFile(filepath.Join(dir, keyringKeysDir, file.Name()))
+		require.NoError(t, err)
+		require.NotContains(t, string(bz), "foo")
+	}
+
+	_, err = newFile(dir, "another-pass", testScryptN)
+	require.Equal(t, ErrWrongKeyringPassphrase, err)
+
+	kb, err = newFile(dir, "keyring-pass", testScryptN)
+	require.NoError(t, err)
+	infos, err := kb.List()
+	require.NoError(t, err)
+	require.Len(t, infos, 1)
+	require.Equal(t, info.GetPubKey(), infos[0].GetPubKey())
+	byAddr, err := kb.GetByAddress(info.GetAddress())
+	require.NoError(t, err)
+	require.Equal(t, "foo", byAddr.GetName())
+
+	_, _, err = kb.Sign("foo", "1234567890", []byte("msg"))
+	require.NoError(t, err)
+	require.NoError(t, kb.Delete("foo", "1234567890", false))
+	infos, err = kb.List()
+	require.NoError(t, err)
+	require.Empty(t, infos)
+}
+
+func TestPassKeybase(t *testing.T) {
+	dir, err := ioutil.TempDir("", "keyring")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	kb, err := NewPass(dir)
+	require.NoError(t, err)
+	info, _, err := kb.CreateMnemonic("foo", English, "1234567890", Secp256k1)
+	require.NoError(t, err)
+
+	kb, err = NewPass(dir)
+	require.NoError(t, err)
+	stored, err := kb.Get("foo")
+	require.NoError(t, err)
+	require.Equal(t, info.GetAddress(), stored.GetAddress())
+}
+
+func TestTestKeybase(t *testing.T) {
+	dir, err := ioutil.TempDir("", "keyring")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	// the keys persist from a keybase to the next one opened on the directory
+	kb, err := NewTest(dir)
+	require.NoError(t, err)
+	info, _, err := kb.CreateMnemonic("foo", English, "1234567890", Secp256k1)
+	require.NoError(t, err)
+
+	kb, err = NewTest(dir)
+	require.NoError(t, err)
+	stored, err := kb.Get("foo")
+	require.NoError(t, err)
+	require.Equal(t, info.GetAddress(), stored.GetAddress())
+	_, _, err = kb.Sign("foo", "1234567890", []byte("msg"))
+	require.NoError(t, err)
+}
+
+func TestReadOnlyKeybase(t *testing.T) {
+	kb := NewInMemory()
+	info, _, err := kb.CreateMnemonic("foo", English, "1234567890", Secp256k1)
+	require.NoError(t, err)
+
+	ro := NewReadOnly(kb)
+	stored, err := ro.Get("foo")
+	require.NoError(t, err)
+	require.Equal(t, info.GetAddress(), stored.GetAddress())
+	_, _, err = ro.Sign("foo", "1234567890", []byte("msg"))
+	require.NoError(t, err)
+
+	_, _, err = ro.CreateMnemonic("bar", English, "1234567890", Secp256k1)
+	require.Equal(t, ErrReadOnlyKeybase, err)
+	require.Equal(t, ErrReadOnlyKeybase, ro.Delete("foo", "1234567890", false))
+	require.Equal(t, ErrReadOnlyKeybase, ro.Update("foo", "1234567890", func() (string, error) { return "0987654321", nil }))
+	_, err = kb.Get("foo")
+	require.NoError(t, err)
+}
@@ -0,0 +1,60 @@
+package keys
+
+import (
+	"github.com/NPC-Chain/npcchub/crypto/keys/hd"
+	"github.com/NPC-Chain/npcchub/types"
+	"github.com/cosmos/go-bip39"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
+)
+
+// DefaultGapLimit is the number of consecutive unused addresses after which
+// DiscoverAccounts stops scanning an account, as in BIP 44
+const DefaultGapLimit = 20
+
+// DiscoveredAddress is a used address of a mnemonic with the BIP44 params it is derived at
+type DiscoveredAddress struct {
+	Params  hd.BIP44Params
+	Address types.AccAddress
+}
+
+// DiscoverAccounts scans the BIP 44 accounts of the mnemonic in order, and the address
+// indexes of each until gapLimit consecutive addresses are unused, as told by used.
+// The scan stops at the first account without any used address.
+// It returns the used addresses in the order of their params.
+func DiscoverAccounts(mnemonic, bip39Passwd string, gapLimit uint32, used func(types.AccAddress) (bool, error)) ([]DiscoveredAddress, error) {
+	if gapLimit == 0 {
+		gapLimit = DefaultGapLimit
+	}
+	seed, err := bip39.NewSeedWithErrorChecking(mnemonic, bip39Passwd)
+	if err != nil {
+		return nil, err
+	}
+	masterPriv, ch := hd.ComputeMastersFromSeed(seed)
+
+	var discovered []DiscoveredAddress
+	for account := uint32(0); ; account++ {
+		found := false
+		for index, gap := uint32(0), uint32(0); gap < gapLimit; index++ {
+			params := *hd.NewFundraiserParams(account, index)
+			derivedPriv, err := hd.DerivePrivateKeyForPath(masterPriv, ch, params.String())
+			if err != nil {
+				return nil, err
+			}
+			addr := types.AccAddress(secp256k1.PrivKeySecp256k1(derivedPriv).PubKey().Address())
+			ok, err := used(addr)
+			if err != nil {
+				return nil, err
+			}
+			if !ok {
+				gap++
+				continue
+			}
+			gap = 0
+			found = true
+			discovered = append(discovered, DiscoveredAddress{params, addr})
+		}
+		if !found {
+			return discovered, nil
+		}
+	}
+}
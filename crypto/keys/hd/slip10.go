@@ -0,0 +1,111 @@
+package hd
+
+import (
+	"crypto/elliptic"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// The curve keys of the master keys, see https://github.com/satoshilabs/slips/blob/master/slip-0010.md
+const (
+	ed25519Curve   = "ed25519 seed"
+	nist256p1Curve = "Nist256p1 seed"
+)
+
+// FullHardenedFundraiserPath is the FullFundraiserPath with every level hardened,
+// as SLIP-0010 only defines the hardened derivation of ed25519 keys.
+const FullHardenedFundraiserPath = BIP44Prefix + "0'/0'/0'"
+
+// DeriveEd25519KeyForPath derives the seed of the ed25519 private key by following
+// the BIP 32 path from the master key of the BIP 39 seed, as specified by SLIP-0010.
+// Every level of the path must be hardened.
+func DeriveEd25519KeyForPath(seed []byte, path string) ([32]byte, error) {
+	indexes, err := slip10Path(path)
+	if err != nil {
+		return [32]byte{}, err
+	}
+	key, chainCode := i64([]byte(ed25519Curve), seed)
+	for _, index := range indexes {
+		if index < 0x80000000 {
+			return [32]byte{}, errors.New("invalid SLIP-0010 path: ed25519 keys only support hardened derivation")
+		}
+		data := append([]byte{0}, key[:]...)
+		data = append(data, uint32ToBytes(index)...)
+		key, chainCode = i64(chainCode[:], data)
+	}
+	return key, nil
+}
+
+// DeriveNist256p1KeyForPath derives the scalar of the secp256r1 (NIST P-256) private key
+// by following the BIP 32 path from the master key of the BIP 39 seed, as specified by SLIP-0010.
+func DeriveNist256p1KeyForPath(seed []byte, path string) ([32]byte, error) {
+	indexes, err := slip10Path(path)
+	if err != nil {
+		return [32]byte{}, err
+	}
+	curve := elliptic.P256()
+	n := curve.Params().N
+
+	// the master key is derived again from the whole HMAC while it is not a valid scalar
+	key, chainCode := i64([]byte(nist256p1Curve), seed)
+	for k := new(big.Int).SetBytes(key[:]); k.Sign() == 0 || k.Cmp(n) >= 0; k.SetBytes(key[:]) {
+		key, chainCode = i64([]byte(nist256p1Curve), append(key[:], chainCode[:]...))
+	}
+
+	for _, index := range indexes {
+		var data []byte
+		if index >= 0x80000000 {
+			data = append([]byte{0}, key[:]...)
+		} else {
+			x, y := curve.ScalarBaseMult(key[:])
+			data = make([]byte, 33)
+			data[0] = byte(y.Bit(0)) | 2
+			xBytes := x.Bytes()
+			copy(data[33-len(xBytes):], xBytes)
+		}
+		data = append(data, uint32ToBytes(index)...)
+		for {
+			il, ir := i64(chainCode[:], data)
+			childKey := new(big.Int).SetBytes(il[:])
+			if childKey.Cmp(n) < 0 {
+				childKey.Add(childKey, new(big.Int).SetBytes(key[:]))
+				childKey.Mod(childKey, n)
+				if childKey.Sign() != 0 {
+					key = [32]byte{}
+					childBytes := childKey.Bytes()
+					copy(key[32-len(childBytes):], childBytes)
+					chainCode = ir
+					break
+				}
+			}
+			// the child key is derived again from the right half while it is not a valid scalar
+			data = append(append([]byte{1}, ir[:]...), uint32ToBytes(index)...)
+		}
+	}
+	return key, nil
+}
+
+// slip10Path returns the indexes of the levels of the BIP 32 path,
+// with the hardened bit set for the hardened levels.
+func slip10Path(path string) ([]uint32, error) {
+	var indexes []uint32
+	for _, part := range strings.Split(path, "/") {
+		harden := strings.HasSuffix(part, "'")
+		if harden {
+			part = part[:len(part)-1]
+		}
+		idx, err := strconv.ParseUint(part, 10, 31)
+		if err != nil {
+			return nil, fmt.Errorf("invalid BIP 32 path: %s", err)
+		}
+		index := uint32(idx)
+		if harden {
+			index |= 0x80000000
+		}
+		indexes = append(indexes, index)
+	}
+	return indexes, nil
+}
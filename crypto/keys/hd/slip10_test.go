@@ -0,0 +1,51 @@
+package hd
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// the test vector 1 of SLIP-0010
+var slip10Seed, _ = hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+
+func TestDeriveEd25519KeyForPath(t *testing.T) {
+	cases := []struct {
+		path string
+		key  string
+	}{
+		{"0'", "68e0fe46dfb67e368c75379acec591dad19df3cde26e63b93a8e704f1dade7a3"},
+		{"0'/1'", "b1d0bad404bf35da785a64ca1ac54b2617211d2777696fbffaf208f746ae84f2"},
+		{"0'/1'/2'/2'/1000000000'", "8f94d394a8e8fd6b1bc2f3f49f5c47e385281d5c17e65324b0f62483e37e8793"},
+	}
+	for _, c := range cases {
+		key, err := DeriveEd25519KeyForPath(slip10Seed, c.path)
+		require.NoError(t, err, c.path)
+		require.Equal(t, c.key, hex.EncodeToString(key[:]), c.path)
+	}
+
+	_, err := DeriveEd25519KeyForPath(slip10Seed, FullFundraiserPath)
+	require.Error(t, err)
+	_, err = DeriveEd25519KeyForPath(slip10Seed, FullHardenedFundraiserPath)
+	require.NoError(t, err)
+}
+
+func TestDeriveNist256p1KeyForPath(t *testing.T) {
+	cases := []struct {
+		path string
+		key  string
+	}{
+		{"0'", "6939694369114c67917a182c59ddb8cafc3004e63ca5d3b84403ba8613debc0c"},
+		{"0'/1", "284e9d38d07d21e4e281b645089a94f4cf5a5a81369acf151a1c3a57f18b2129"},
+		{"0'/1/2'/2/1000000000", "21c4f269ef0a5fd1badf47eeacebeeaa3de22eb8e5b0adcd0f27dd99d34d0119"},
+	}
+	for _, c := range cases {
+		key, err := DeriveNist256p1KeyForPath(slip10Seed, c.path)
+		require.NoError(t, err, c.path)
+		require.Equal(t, c.key, hex.EncodeToString(key[:]), c.path)
+	}
+
+	_, err := DeriveNist256p1KeyForPath(slip10Seed, "44'/x'")
+	require.Error(t, err)
+}
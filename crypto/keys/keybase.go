@@ -9,12 +9,14 @@ import (
 	"github.com/NPC-Chain/npcchub/crypto/keys/hd"
 	"github.com/NPC-Chain/npcchub/crypto/keys/keyerror"
 	"github.com/NPC-Chain/npcchub/crypto/keys/mintkey"
+	"github.com/NPC-Chain/npcchub/crypto/secp256r1"
 	"github.com/NPC-Chain/npcchub/types"
 	"github.com/pkg/errors"
 	tmcrypto "github.com/tendermint/tendermint/crypto"
-	cryptoAmino "github.com/tendermint/tendermint/crypto/encoding/amino"
+	"github.com/tendermint/tendermint/crypto/ed25519"
 	"github.com/tendermint/tendermint/crypto/secp256k1"
 	dbm "github.com/tendermint/tm-db"
+	xed25519 "golang.org/x/crypto/ed25519"
 )
 
 var _ Keybase = dbKeybase{}
@@ -56,8 +58,8 @@ const (
 
 var (
 	// ErrUnsupportedSigningAlgo is raised when the caller tries to use a
-	// different signing scheme than secp256k1.
-	ErrUnsupportedSigningAlgo = errors.New("unsupported signing algo: only secp256k1 is supported")
+	// different signing scheme than secp256k1, ed25519 and secp256r1.
+	ErrUnsupportedSigningAlgo = errors.New("unsupported signing algo: only secp256k1, ed25519 and secp256r1 are supported")
 
 	// ErrUnsupportedLanguage is raised when the caller tries to use a
 	// different language than english for creating a mnemonic sentence.
@@ -87,7 +89,7 @@ func (kb dbKeybase) CreateMnemonic(name string, language Language, passwd string
 	if language != English {
 		return nil, "", ErrUnsupportedLanguage
 	}
-	if algo != Secp256k1 {
+	if !IsSupportedAlgo(algo) {
 		err = ErrUnsupportedSigningAlgo
 		return
 	}
@@ -104,13 +106,13 @@ func (kb dbKeybase) CreateMnemonic(name string, language Language, passwd string
 	}
 
 	seed := bip39.NewSeed(mnemonic, defaultBIP39Passphrase)
-	info, err = kb.persistDerivedKey(seed, passwd, name, hd.FullFundraiserPath)
+	info, err = kb.persistDerivedKey(seed, passwd, name, DefaultHDPath(algo), algo)
 	return
 }
 
 func (kb dbKeybase) ImportPrivateKey(name string, passwd string, privKey tmcrypto.PrivKey) (info Info, err error) {
 	switch privKey.(type) {
-	case secp256k1.PrivKeySecp256k1:
+	case secp256k1.PrivKeySecp256k1, ed25519.PrivKeyEd25519, secp256r1.PrivKeySecp256r1:
 		info = kb.writeLocalKey(privKey, name, passwd)
 		return
 	default:
@@ -130,7 +132,7 @@ func (kb dbKeybase) CreateKey(name, mnemonic, passwd string) (info Info, err err
 	if err != nil {
 		return
 	}
-	info, err = kb.persistDerivedKey(seed, passwd, name, hd.FullFundraiserPath)
+	info, err = kb.persistDerivedKey(seed, passwd, name, hd.FullFundraiserPath, Secp256k1)
 	return
 }
 
@@ -147,7 +149,7 @@ func (kb dbKeybase) CreateFundraiserKey(name, mnemonic, passwd string) (info Inf
 	if err != nil {
 		return
 	}
-	info, err = kb.persistDerivedKey(seed, passwd, name, hd.FullFundraiserPath)
+	info, err = kb.persistDerivedKey(seed, passwd, name, hd.FullFundraiserPath, Secp256k1)
 	return
 }
 
@@ -156,11 +158,63 @@ func (kb dbKeybase) Derive(name, mnemonic, bip39Passphrase, encryptPasswd string
 	if err != nil {
 		return
 	}
-	info, err = kb.persistDerivedKey(seed, encryptPasswd, name, params.String())
+	info, err = kb.persistDerivedKey(seed, encryptPasswd, name, params.String(), Secp256k1)
 
 	return
 }
 
+// CreateAccount derives the key of the algo at the HD path from the mnemonic and
+// the BIP 39 passphrase, and persists it encrypted with encryptPasswd
+func (kb dbKeybase) CreateAccount(name, mnemonic, bip39Passwd, encryptPasswd, hdPath string, algo SigningAlgo) (Info, error) {
+	if !IsSupportedAlgo(algo) {
+		return nil, ErrUnsupportedSigningAlgo
+	}
+	seed, err := bip39.NewSeedWithErrorChecking(mnemonic, bip39Passwd)
+	if err != nil {
+		return nil, err
+	}
+	return kb.persistDerivedKey(seed, encryptPasswd, name, hdPath, algo)
+}
+
+// DeriveAddresses derives the secp256k1 keys at the BIP44 params from the mnemonic and
+// the BIP 39 passphrase, and persists them encrypted with encryptPasswd under the one name.
+// The key of the first params is the key of the name, the others are found by GetIndex
+// and GetByAddress.
+func (kb dbKeybase) DeriveAddresses(name, mnemonic, bip39Passwd, encryptPasswd string, params []hd.BIP44Params) (Info, error) {
+	if len(params) == 0 {
+		return nil, errors.New("no BIP44 params to derive the keys of")
+	}
+	seed, err := bip39.NewSeedWithErrorChecking(mnemonic, bip39Passwd)
+	if err != nil {
+		return nil, err
+	}
+	masterPriv, ch := hd.ComputeMastersFromSeed(seed)
+	info := localInfo{Name: name}
+	for i, p := range params {
+		derivedPriv, err := hd.DerivePrivateKeyForPath(masterPriv, ch, p.String())
+		if err != nil {
+			return nil, err
+		}
+		priv := secp256k1.PrivKeySecp256k1(derivedPriv)
+		key := derivedKey{
+			Path:         p.String(),
+			PubKey:       priv.PubKey(),
+			PrivKeyArmor: mintkey.EncryptArmorPrivKey(priv, encryptPasswd),
+		}
+		if i == 0 {
+			info.PubKey, info.PrivKeyArmor, info.Path = key.PubKey, key.PrivKeyArmor, key.Path
+			continue
+		}
+		info.Derived = append(info.Derived, key)
+	}
+	// the addresses of the overridden key are no longer found by address
+	if old, err := kb.Get(name); err == nil && !kb.isDerived(name) {
+		kb.deleteInfo(old, name)
+	}
+	kb.writeInfo(info, name)
+	return info, nil
+}
+
 // CreateLedger creates a new locally-stored reference to a Ledger keypair
 // It returns the created key info and an error if the Ledger could not be queried
 func (kb dbKeybase) CreateLedger(name string, path crypto.DerivationPath, algo SigningAlgo) (Info, error) {
@@ -181,16 +235,24 @@ func (kb dbKeybase) CreateOffline(name string, pub tmcrypto.PubKey) (Info, error
 	return kb.writeOfflineKey(pub, name), nil
 }
 
+// CreateRemote creates a new reference to a key of a remote signer, asking the
+// signer for its public key. It returns the created key info
+func (kb dbKeybase) CreateRemote(name string, signer RemoteSigner) (Info, error) {
+	pub, err := signer.PubKey()
+	if err != nil {
+		return nil, err
+	}
+	return kb.writeRemoteKey(name, pub, signer), nil
+}
+
 // CreateMulti creates a new reference to a multisig (offline) keypair. It
 // returns the created key info.
 func (kb dbKeybase) CreateMulti(name string, pub tmcrypto.PubKey) (Info, error) {
 	return kb.writeMultisigKey(name, pub), nil
 }
 
-func (kb *dbKeybase) persistDerivedKey(seed []byte, passwd, name, fullHdPath string) (info Info, err error) {
-	// create master key and derive first key:
-	masterPriv, ch := hd.ComputeMastersFromSeed(seed)
-	derivedPriv, err := hd.DerivePrivateKeyForPath(masterPriv, ch, fullHdPath)
+func (kb *dbKeybase) persistDerivedKey(seed []byte, passwd, name, fullHdPath string, algo SigningAlgo) (info Info, err error) {
+	priv, err := derivePrivKey(seed, fullHdPath, algo)
 	if err != nil {
 		return
 	}
@@ -198,14 +260,43 @@ func (kb *dbKeybase) persistDerivedKey(seed []byte, passwd, name, fullHdPath str
 	// if we have a password, use it to encrypt the private key and store it
 	// else store the public key only
 	if passwd != "" {
-		info = kb.writeLocalKey(secp256k1.PrivKeySecp256k1(derivedPriv), name, passwd)
+		info = kb.writeLocalKey(priv, name, passwd)
 	} else {
-		pubk := secp256k1.PrivKeySecp256k1(derivedPriv).PubKey()
-		info = kb.writeOfflineKey(pubk, name)
+		info = kb.writeOfflineKey(priv.PubKey(), name)
 	}
 	return
 }
 
+// derivePrivKey derives the key of the algo at the HD path from the BIP 39 seed,
+// following BIP 32 for secp256k1 and SLIP-0010 for ed25519 and secp256r1.
+func derivePrivKey(seed []byte, fullHdPath string, algo SigningAlgo) (tmcrypto.PrivKey, error) {
+	switch algo {
+	case Ed25519:
+		derivedSeed, err := hd.DeriveEd25519KeyForPath(seed, fullHdPath)
+		if err != nil {
+			return nil, err
+		}
+		var priv ed25519.PrivKeyEd25519
+		copy(priv[:], xed25519.NewKeyFromSeed(derivedSeed[:]))
+		return priv, nil
+	case Secp256r1:
+		derivedPriv, err := hd.DeriveNist256p1KeyForPath(seed, fullHdPath)
+		if err != nil {
+			return nil, err
+		}
+		priv, _ := secp256r1.PrivKeyFromScalar(derivedPriv[:])
+		return priv, nil
+	default:
+		// create master key and derive first key:
+		masterPriv, ch := hd.ComputeMastersFromSeed(seed)
+		derivedPriv, err := hd.DerivePrivateKeyForPath(masterPriv, ch, fullHdPath)
+		if err != nil {
+			return nil, err
+		}
+		return secp256k1.PrivKeySecp256k1(derivedPriv), nil
+	}
+}
+
 // List returns the keys from storage in alphabetical order.
 func (kb dbKeybase) List() ([]Info, error) {
 	var res []Info
@@ -226,22 +317,67 @@ func (kb dbKeybase) List() ([]Info, error) {
 	return res, nil
 }
 
-// Get returns the public information about one key.
+// Get returns the public information about one key, or about the derived
+// address of a <name>#<index> name.
 func (kb dbKeybase) Get(name string) (Info, error) {
 	bs := kb.db.Get(infoKey(name))
 	if len(bs) == 0 {
+		if base, index, ok := splitDerivedName(name); ok {
+			if info, err := kb.GetIndex(base, index); err == nil {
+				return info, nil
+			}
+		}
 		return nil, keyerror.NewErrKeyNotFound(name)
 	}
 	return readInfo(bs)
 }
 
+// isDerived returns whether the name is the name of a derived address,
+// which is deleted and updated with the key it is stored under.
+func (kb dbKeybase) isDerived(name string) bool {
+	_, _, ok := splitDerivedName(name)
+	return ok && !kb.db.Has(infoKey(name))
+}
+
 func (kb dbKeybase) GetByAddress(address types.AccAddress) (Info, error) {
 	ik := kb.db.Get(addrKey(address))
 	if len(ik) == 0 {
 		return nil, fmt.Errorf("key with address %s not found", address)
 	}
 	bs := kb.db.Get(ik)
-	return readInfo(bs)
+	info, err := readInfo(bs)
+	if err != nil {
+		return nil, err
+	}
+	if linfo, ok := info.(localInfo); ok {
+		for i, key := range linfo.Derived {
+			if address.Equals(types.AccAddress(key.PubKey.Address())) {
+				info, _ = linfo.derivedInfo(i + 1)
+			}
+		}
+	}
+	return info, nil
+}
+
+// GetIndex returns the info of the index-th address stored under the name, 0 being
+// the key of the name.
+func (kb dbKeybase) GetIndex(name string, index int) (Info, error) {
+	info, err := kb.Get(name)
+	if err != nil {
+		return nil, err
+	}
+	if index == 0 {
+		return info, nil
+	}
+	linfo, ok := info.(localInfo)
+	if !ok {
+		return nil, fmt.Errorf("key %s holds a single address", name)
+	}
+	derived, ok := linfo.derivedInfo(index)
+	if !ok {
+		return nil, fmt.Errorf("key %s holds %d addresses, no index %d", name, len(linfo.Derived)+1, index)
+	}
+	return derived, nil
 }
 
 // Sign signs the msg with the named key.
@@ -269,6 +405,16 @@ func (kb dbKeybase) Sign(name, passphrase string, msg []byte) (sig []byte, pub t
 		if err != nil {
 			return
 		}
+	case remoteInfo:
+		rinfo := info.(remoteInfo)
+		sig, pub, err = rinfo.Signer.Sign(msg)
+		if err != nil {
+			return nil, nil, err
+		}
+		if !pub.Equals(rinfo.PubKey) {
+			return nil, nil, fmt.Errorf("the remote signer signed with another key than %s", name)
+		}
+		return sig, pub, nil
 	case offlineInfo:
 		err = fmt.Errorf("can not sign tx using offline key")
 		return
@@ -374,7 +520,7 @@ func (kb dbKeybase) ImportPubKey(name string, armor string) (err error) {
 	if err != nil {
 		return
 	}
-	pubKey, err := cryptoAmino.PubKeyFromBytes(pubBytes)
+	pubKey, err := crypto.PubKeyFromBytes(pubBytes)
 	if err != nil {
 		return
 	}
@@ -387,6 +533,9 @@ func (kb dbKeybase) ImportPubKey(name string, armor string) (err error) {
 // A passphrase of 'yes' is used to delete stored
 // references to offline and Ledger / HW wallet keys
 func (kb dbKeybase) Delete(name, passphrase string, skipPass bool) error {
+	if kb.isDerived(name) {
+		return fmt.Errorf("%s is a derived address, delete the key it is stored under", name)
+	}
 	// verify we have the proper password before deleting
 	info, err := kb.Get(name)
 	if err != nil {
@@ -397,9 +546,19 @@ func (kb dbKeybase) Delete(name, passphrase string, skipPass bool) error {
 			return err
 		}
 	}
+	kb.deleteInfo(info, name)
+	return nil
+}
+
+// deleteInfo deletes the info and the pointers to it of all its addresses
+func (kb dbKeybase) deleteInfo(info Info, name string) {
 	kb.db.DeleteSync(addrKey(info.GetAddress()))
+	if linfo, ok := info.(localInfo); ok {
+		for _, key := range linfo.Derived {
+			kb.db.DeleteSync(addrKey(key.PubKey.Address().Bytes()))
+		}
+	}
 	kb.db.DeleteSync(infoKey(name))
-	return nil
 }
 
 // Update changes the passphrase with which an already stored key is
@@ -409,6 +568,9 @@ func (kb dbKeybase) Delete(name, passphrase string, skipPass bool) error {
 // getNewpass is a function to get the passphrase to permanently replace
 // the current passphrase
 func (kb dbKeybase) Update(name, oldpass string, getNewpass func() (string, error)) error {
+	if kb.isDerived(name) {
+		return fmt.Errorf("%s is a derived address, update the key it is stored under", name)
+	}
 	info, err := kb.Get(name)
 	if err != nil {
 		return err
@@ -420,11 +582,25 @@ func (kb dbKeybase) Update(name, oldpass string, getNewpass func() (string, erro
 		if err != nil {
 			return err
 		}
+		derivedKeys := make([]tmcrypto.PrivKey, len(linfo.Derived))
+		for i, derived := range linfo.Derived {
+			if derivedKeys[i], err = mintkey.UnarmorDecryptPrivKey(derived.PrivKeyArmor, oldpass); err != nil {
+				return err
+			}
+		}
 		newpass, err := getNewpass()
 		if err != nil {
 			return err
 		}
-		kb.writeLocalKey(key, name, newpass)
+		if len(linfo.Derived) == 0 {
+			kb.writeLocalKey(key, name, newpass)
+			return nil
+		}
+		linfo.PrivKeyArmor = mintkey.EncryptArmorPrivKey(key, newpass)
+		for i, dkey := range derivedKeys {
+			linfo.Derived[i].PrivKeyArmor = mintkey.EncryptArmorPrivKey(dkey, newpass)
+		}
+		kb.writeInfo(linfo, name)
 		return nil
 	default:
 		return fmt.Errorf("locally stored key required")
@@ -464,12 +640,23 @@ func (kb dbKeybase) writeMultisigKey(name string, pub tmcrypto.PubKey) Info {
 	return info
 }
 
+func (kb dbKeybase) writeRemoteKey(name string, pub tmcrypto.PubKey, signer RemoteSigner) Info {
+	info := newRemoteInfo(name, pub, signer)
+	kb.writeInfo(info, name)
+	return info
+}
+
 func (kb dbKeybase) writeInfo(info Info, name string) {
 	// write the info by key
 	key := infoKey(name)
 	kb.db.SetSync(key, writeInfo(info))
 	// store a pointer to the infokey by address for fast lookup
 	kb.db.SetSync(addrKey(info.GetAddress()), key)
+	if linfo, ok := info.(localInfo); ok {
+		for _, derived := range linfo.Derived {
+			kb.db.SetSync(addrKey(derived.PubKey.Address().Bytes()), key)
+		}
+	}
 }
 
 func addrKey(address types.AccAddress) []byte {
@@ -38,8 +38,8 @@ func TestKeyManagement(t *testing.T) {
 	require.Nil(t, err)
 	assert.Empty(t, l)
 
-	_, _, err = cstore.CreateMnemonic(n1, English, p1, Ed25519)
-	require.Error(t, err, "ed25519 keys are currently not supported by keybase")
+	_, _, err = cstore.CreateMnemonic(n1, English, p1, SigningAlgo("sr25519"))
+	require.Equal(t, ErrUnsupportedSigningAlgo, err)
 
 	// create some keys
 	_, err = cstore.Get(n1)
@@ -352,6 +352,163 @@ func TestSeedPhrase(t *testing.T) {
 	require.Equal(t, info.GetPubKey(), newInfo.GetPubKey())
 }
 
+// TestSigningAlgos checks the ed25519 and secp256r1 keys sign, and are recovered from the mnemonic
+func TestSigningAlgos(t *testing.T) {
+	cstore := New(
+		dbm.NewMemDB(),
+	)
+	msg := []byte("msg")
+
+	for _, algo := range []SigningAlgo{Ed25519, Secp256r1} {
+		info, mnemonic, err := cstore.CreateMnemonic(string(algo), English, "1234", algo)
+		require.NoError(t, err)
+
+		sig, pub, err := cstore.Sign(string(algo), "1234", msg)
+		require.NoError(t, err)
+		require.Equal(t, info.GetPubKey(), pub)
+		require.True(t, pub.VerifyBytes(msg, sig))
+
+		// the key survives the armored export and import
+		armor, err := cstore.ExportPubKey(string(algo))
+		require.NoError(t, err)
+		require.NoError(t, cstore.ImportPubKey(string(algo)+"-pub", armor))
+		imported, err := cstore.Get(string(algo) + "-pub")
+		require.NoError(t, err)
+		require.Equal(t, info.GetPubKey(), imported.GetPubKey())
+
+		recovered, err := cstore.CreateAccount(string(algo)+"-recovered", mnemonic, defaultBIP39Passphrase, "5678", DefaultHDPath(algo), algo)
+		require.NoError(t, err)
+		require.Equal(t, info.GetPubKey(), recovered.GetPubKey())
+		secp256k1Info, err := cstore.CreateAccount(string(algo)+"-secp256k1", mnemonic, defaultBIP39Passphrase, "5678", hd.FullFundraiserPath, Secp256k1)
+		require.NoError(t, err)
+		require.NotEqual(t, info.GetAddress(), secp256k1Info.GetAddress())
+	}
+
+	// ed25519 keys are only derived at hardened paths
+	_, err := cstore.CreateAccount("ed25519-unhardened", "equip will roof matter pink blind book anxiety banner elbow sun young", defaultBIP39Passphrase, "5678", hd.FullFundraiserPath, Ed25519)
+	require.Error(t, err)
+
+	_, err = cstore.CreateAccount("sr25519", "", defaultBIP39Passphrase, "5678", hd.FullFundraiserPath, SigningAlgo("sr25519"))
+	require.Equal(t, ErrUnsupportedSigningAlgo, err)
+}
+
+// TestDeriveAddresses checks the addresses derived under one name are found by index
+// and address, and are updated and deleted with the name
+func TestDeriveAddresses(t *testing.T) {
+	cstore := New(
+		dbm.NewMemDB(),
+	)
+	_, mnemonic, err := cstore.CreateMnemonic("seed", English, "1234", Secp256k1)
+	require.NoError(t, err)
+
+	params := []hd.BIP44Params{*hd.NewFundraiserParams(0, 0), *hd.NewFundraiserParams(0, 3), *hd.NewFundraiserParams(1, 0)}
+	info, err := cstore.DeriveAddresses("multi", mnemonic, defaultBIP39Passphrase, "1234", params)
+	require.NoError(t, err)
+	seed, err := cstore.Get("seed")
+	require.NoError(t, err)
+	require.Equal(t, seed.GetAddress(), info.GetAddress())
+
+	singles := New(
+		dbm.NewMemDB(),
+	)
+	for i, p := range params {
+		derived, err := singles.Derive(fmt.Sprintf("single-%d", i), mnemonic, defaultBIP39Passphrase, "1234", p)
+		require.NoError(t, err)
+		indexed, err := cstore.GetIndex("multi", i)
+		require.NoError(t, err)
+		require.Equal(t, derived.GetPubKey(), indexed.GetPubKey())
+		require.Equal(t, indexed, mustGet(t, cstore, indexed.GetName()))
+
+		byAddress, err := cstore.GetByAddress(derived.GetAddress())
+		require.NoError(t, err)
+		require.Equal(t, derived.GetAddress(), byAddress.GetAddress())
+
+		// the key of the address signs, not the key of the name
+		msg := []byte("msg")
+		sig, pub, err := cstore.Sign(byAddress.GetName(), "1234", msg)
+		require.NoError(t, err)
+		require.Equal(t, derived.GetPubKey(), pub)
+		require.True(t, pub.VerifyBytes(msg, sig))
+	}
+	_, err = cstore.Get("multi#0")
+	require.Error(t, err)
+	_, err = cstore.Get("multi#3")
+	require.Error(t, err)
+	require.Error(t, cstore.Delete("multi#1", "1234", false))
+	require.Error(t, cstore.Update("multi#1", "1234", func() (string, error) { return "5678", nil }))
+	_, err = cstore.GetIndex("multi", len(params))
+	require.Error(t, err)
+	_, err = cstore.GetIndex("seed", 1)
+	require.Error(t, err)
+
+	// the derived keys take the new passphrase too
+	require.NoError(t, cstore.Update("multi", "1234", func() (string, error) { return "5678", nil }))
+	updated, err := cstore.Get("multi")
+	require.NoError(t, err)
+	for i := range params {
+		indexed, ok := updated.(localInfo).derivedInfo(i)
+		require.True(t, ok)
+		_, err = mintkey.UnarmorDecryptPrivKey(indexed.PrivKeyArmor, "5678")
+		require.NoError(t, err)
+	}
+
+	// the addresses of an overridden key are no longer found
+	derived, err := cstore.GetIndex("multi", 2)
+	require.NoError(t, err)
+	_, err = cstore.DeriveAddresses("multi", mnemonic, defaultBIP39Passphrase, "1234", params[:2])
+	require.NoError(t, err)
+	_, err = cstore.GetByAddress(derived.GetAddress())
+	require.Error(t, err)
+
+	derived, err = cstore.GetIndex("multi", 1)
+	require.NoError(t, err)
+	require.NoError(t, cstore.Delete("multi", "1234", false))
+	_, err = cstore.GetByAddress(derived.GetAddress())
+	require.Error(t, err)
+}
+
+func mustGet(t *testing.T, kb Keybase, name string) Info {
+	info, err := kb.Get(name)
+	require.NoError(t, err)
+	return info
+}
+
+// TestDiscoverAccounts checks the scan goes on up to the gap limit in an account, and
+// to the accounts after one with a used address
+func TestDiscoverAccounts(t *testing.T) {
+	mnemonic := "equip will roof matter pink blind book anxiety banner elbow sun young"
+	usedParams := []hd.BIP44Params{*hd.NewFundraiserParams(0, 0), *hd.NewFundraiserParams(0, 4), *hd.NewFundraiserParams(1, 1)}
+	unreachable := *hd.NewFundraiserParams(3, 0)
+
+	cstore := New(
+		dbm.NewMemDB(),
+	)
+	used := map[string]bool{}
+	for i, p := range append(usedParams, unreachable) {
+		info, err := cstore.Derive(fmt.Sprintf("used-%d", i), mnemonic, defaultBIP39Passphrase, "1234", p)
+		require.NoError(t, err)
+		used[info.GetAddress().String()] = true
+	}
+
+	scanned := 0
+	discovered, err := DiscoverAccounts(mnemonic, defaultBIP39Passphrase, 4, func(addr types.AccAddress) (bool, error) {
+		scanned++
+		return used[addr.String()], nil
+	})
+	require.NoError(t, err)
+	require.Len(t, discovered, len(usedParams))
+	for i, p := range usedParams {
+		require.Equal(t, p, discovered[i].Params)
+	}
+	// account 0 is scanned up to index 8, account 1 up to index 5 and account 2 up to index 3
+	require.Equal(t, 9+6+4, scanned)
+
+	_, err = DiscoverAccounts(mnemonic, defaultBIP39Passphrase, 4, func(types.AccAddress) (bool, error) {
+		return false, fmt.Errorf("node unreachable")
+	})
+	require.Error(t, err)
+}
+
 func ExampleNew() {
 	// Select the encryption and storage for your cryptostore
 	cstore := New(
@@ -1,5 +1,7 @@
 package keys
 
+import "github.com/NPC-Chain/npcchub/crypto/keys/hd"
+
 // SigningAlgo defines an algorithm to derive key-pairs which can be used for cryptographic signing.
 type SigningAlgo string
 
@@ -7,6 +9,28 @@ const (
 	// Secp256k1 uses the Bitcoin secp256k1 ECDSA parameters.
 	Secp256k1 = SigningAlgo("secp256k1")
 	// Ed25519 represents the Ed25519 signature system.
-	// It is currently not supported for end-user keys (wallets/ledgers).
+	// It is not supported for ledgers.
 	Ed25519 = SigningAlgo("ed25519")
+	// Secp256r1 uses the NIST P-256 ECDSA parameters, the curve of most hardware security modules.
+	// It is not supported for ledgers.
+	Secp256r1 = SigningAlgo("secp256r1")
 )
+
+// DefaultHDPath returns the HD path of the keys of the algo created from a mnemonic,
+// the FullFundraiserPath hardened at every level for ed25519.
+func DefaultHDPath(algo SigningAlgo) string {
+	if algo == Ed25519 {
+		return hd.FullHardenedFundraiserPath
+	}
+	return hd.FullFundraiserPath
+}
+
+// IsSupportedAlgo returns whether keys can be created with the algo
+func IsSupportedAlgo(algo SigningAlgo) bool {
+	switch algo {
+	case Secp256k1, Ed25519, Secp256r1:
+		return true
+	default:
+		return false
+	}
+}
@@ -8,9 +8,9 @@ import (
 
 	"github.com/tendermint/tendermint/crypto"
 	"github.com/tendermint/tendermint/crypto/armor"
-	"github.com/tendermint/tendermint/crypto/encoding/amino"
 	"github.com/tendermint/tendermint/crypto/xsalsa20symmetric"
 
+	ccrypto "github.com/NPC-Chain/npcchub/crypto"
 	"github.com/NPC-Chain/npcchub/crypto/keys/keyerror"
 	cmn "github.com/tendermint/tendermint/libs/common"
 )
@@ -150,6 +150,6 @@ func decryptPrivKey(saltBytes []byte, encBytes []byte, passphrase string) (privK
 	} else if err != nil {
 		return privKey, err
 	}
-	privKey, err = cryptoAmino.PrivKeyFromBytes(privKeyBytes)
+	privKey, err = ccrypto.PrivKeyFromBytes(privKeyBytes)
 	return privKey, err
 }
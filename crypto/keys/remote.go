@@ -0,0 +1,222 @@
+package keys
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	ccrypto "github.com/NPC-Chain/npcchub/crypto"
+	"github.com/pkg/errors"
+	tmcrypto "github.com/tendermint/tendermint/crypto"
+)
+
+// Paths served by a remote signer
+const (
+	RemoteSignPath   = "/sign"
+	RemotePubKeyPath = "/pubkey"
+)
+
+// remoteSignerTimeout bounds a request to a remote signer
+const remoteSignerTimeout = 30 * time.Second
+
+// RemoteSigner is the signing service holding a remote key and the mutual TLS
+// configuration to reach it
+type RemoteSigner struct {
+	// Endpoint is the base URL of the signer, https://host:port
+	Endpoint string `json:"endpoint"`
+	// Key is the name of the key in the signer
+	Key string `json:"key"`
+	// CACert is the file of the CA certificate the signer is verified with
+	CACert string `json:"ca_cert"`
+	// ClientCert and ClientKey are the files of the certificate and key presented to the signer
+	ClientCert string `json:"client_cert"`
+	ClientKey  string `json:"client_key"`
+}
+
+// RemoteSignRequest asks a remote signer to sign the bytes of a StdSignMsg with a key
+type RemoteSignRequest struct {
+	Key string `json:"key"`
+	Msg []byte `json:"msg"`
+}
+
+// RemoteSignResponse is the signature of a remote signer and the amino encoded public key of the key
+type RemoteSignResponse struct {
+	Signature []byte `json:"signature"`
+	PubKey    []byte `json:"pub_key"`
+}
+
+// RemotePubKeyRequest asks a remote signer for the public key of a key
+type RemotePubKeyRequest struct {
+	Key string `json:"key"`
+}
+
+// RemotePubKeyResponse is the amino encoded public key of a key of a remote signer
+type RemotePubKeyResponse struct {
+	PubKey []byte `json:"pub_key"`
+}
+
+// PubKey returns the public key of the key of the signer
+func (s RemoteSigner) PubKey() (tmcrypto.PubKey, error) {
+	var res RemotePubKeyResponse
+	if err := s.post(RemotePubKeyPath, RemotePubKeyRequest{Key: s.Key}, &res); err != nil {
+		return nil, err
+	}
+	return ccrypto.PubKeyFromBytes(res.PubKey)
+}
+
+// Sign has the signer sign the msg with its key and checks the signature
+func (s RemoteSigner) Sign(msg []byte) ([]byte, tmcrypto.PubKey, error) {
+	var res RemoteSignResponse
+	if err := s.post(RemoteSignPath, RemoteSignRequest{Key: s.Key, Msg: msg}, &res); err != nil {
+		return nil, nil, err
+	}
+	pub, err := ccrypto.PubKeyFromBytes(res.PubKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !pub.VerifyBytes(msg, res.Signature) {
+		return nil, nil, errors.New("invalid signature from the remote signer")
+	}
+	return res.Signature, pub, nil
+}
+
+func (s RemoteSigner) post(path string, req, res interface{}) error {
+	client, err := s.httpClient()
+	if err != nil {
+		return err
+	}
+	bz, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.Post(strings.TrimSuffix(s.Endpoint, "/")+path, "application/json", bytes.NewReader(bz))
+	if err != nil {
+		return errors.Wrap(err, "remote signer")
+	}
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return errors.Wrap(err, "remote signer")
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("remote signer: %s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+	return json.Unmarshal(body, res)
+}
+
+func (s RemoteSigner) httpClient() (*http.Client, error) {
+	tlsConfig := &tls.Config{}
+	if len(s.CACert) > 0 {
+		pool, err := loadCertPool(s.CACert)
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig.RootCAs = pool
+	}
+	if len(s.ClientCert) > 0 || len(s.ClientKey) > 0 {
+		cert, err := tls.LoadX509KeyPair(s.ClientCert, s.ClientKey)
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+	return &http.Client{
+		Timeout:   remoteSignerTimeout,
+		Transport: &http.Transport{TLSClientConfig: tlsConfig},
+	}, nil
+}
+
+// RemoteSignerTLSConfig returns the TLS configuration of a signer serving the certificate
+// and, if a CA certificate is given, requiring the clients to present a certificate it signed
+func RemoteSignerTLSConfig(caCert, cert, key string) (*tls.Config, error) {
+	serverCert, err := tls.LoadX509KeyPair(cert, key)
+	if err != nil {
+		return nil, err
+	}
+	tlsConfig := &tls.Config{Certificates: []tls.Certificate{serverCert}}
+	if len(caCert) > 0 {
+		pool, err := loadCertPool(caCert)
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig.ClientCAs = pool
+		tlsConfig.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+	return tlsConfig, nil
+}
+
+func loadCertPool(file string) (*x509.CertPool, error) {
+	bz, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(bz) {
+		return nil, fmt.Errorf("no certificate found in %s", file)
+	}
+	return pool, nil
+}
+
+// NewRemoteSignerHandler serves the keys of the keybase, all encrypted with the passphrase,
+// as a remote signer
+func NewRemoteSignerHandler(kb Keybase, passphrase string) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc(RemotePubKeyPath, func(w http.ResponseWriter, r *http.Request) {
+		var req RemotePubKeyRequest
+		if !readRemoteRequest(w, r, &req) {
+			return
+		}
+		info, err := kb.Get(req.Key)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		writeRemoteResponse(w, RemotePubKeyResponse{PubKey: info.GetPubKey().Bytes()})
+	})
+	mux.HandleFunc(RemoteSignPath, func(w http.ResponseWriter, r *http.Request) {
+		var req RemoteSignRequest
+		if !readRemoteRequest(w, r, &req) {
+			return
+		}
+		if _, err := kb.Get(req.Key); err != nil {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		sig, pub, err := kb.Sign(req.Key, passphrase, req.Msg)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		writeRemoteResponse(w, RemoteSignResponse{Signature: sig, PubKey: pub.Bytes()})
+	})
+	return mux
+}
+
+func readRemoteRequest(w http.ResponseWriter, r *http.Request, req interface{}) bool {
+	if r.Method != http.MethodPost {
+		http.Error(w, "POST required", http.StatusMethodNotAllowed)
+		return false
+	}
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return false
+	}
+	return true
+}
+
+func writeRemoteResponse(w http.ResponseWriter, res interface{}) {
+	bz, err := json.Marshal(res)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(bz)
+}
@@ -0,0 +1,62 @@
+package keys
+
+import (
+	"encoding/pem"
+	"io/ioutil"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRemoteSigner(t *testing.T) {
+	signerKb := NewInMemory()
+	signerInfo, _, err := signerKb.CreateMnemonic("treasury", English, "1234567890", Secp256k1)
+	require.NoError(t, err)
+	server := httptest.NewServer(NewRemoteSignerHandler(signerKb, "1234567890"))
+	defer server.Close()
+
+	kb := NewInMemory()
+	info, err := kb.CreateRemote("foo", RemoteSigner{Endpoint: server.URL, Key: "treasury"})
+	require.NoError(t, err)
+	require.Equal(t, TypeRemote, info.GetType())
+	require.Equal(t, signerInfo.GetPubKey(), info.GetPubKey())
+
+	// the remote key is stored and signs without a local passphrase
+	info, err = kb.Get("foo")
+	require.NoError(t, err)
+	msg := []byte("msg")
+	sig, pub, err := kb.Sign("foo", "", msg)
+	require.NoError(t, err)
+	require.Equal(t, signerInfo.GetPubKey(), pub)
+	require.True(t, pub.VerifyBytes(msg, sig))
+
+	_, err = kb.CreateRemote("bar", RemoteSigner{Endpoint: server.URL, Key: "unknown"})
+	require.Error(t, err)
+	_, err = kb.ExportPrivateKeyObject("foo", "")
+	require.Error(t, err)
+}
+
+func TestRemoteSignerTLS(t *testing.T) {
+	signerKb := NewInMemory()
+	_, _, err := signerKb.CreateMnemonic("treasury", English, "1234567890", Secp256k1)
+	require.NoError(t, err)
+	server := httptest.NewTLSServer(NewRemoteSignerHandler(signerKb, "1234567890"))
+	defer server.Close()
+
+	kb := NewInMemory()
+	_, err = kb.CreateRemote("foo", RemoteSigner{Endpoint: server.URL, Key: "treasury"})
+	require.Error(t, err, "the signer certificate isn't trusted")
+
+	caFile, err := ioutil.TempFile("", "ca")
+	require.NoError(t, err)
+	defer os.Remove(caFile.Name())
+	require.NoError(t, pem.Encode(caFile, &pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}))
+	caFile.Close()
+
+	_, err = kb.CreateRemote("foo", RemoteSigner{Endpoint: server.URL, Key: "treasury", CACert: caFile.Name()})
+	require.NoError(t, err)
+	_, _, err = kb.Sign("foo", "", []byte("msg"))
+	require.NoError(t, err)
+}
@@ -1,6 +1,10 @@
 package keys
 
 import (
+	"fmt"
+	"strconv"
+	"strings"
+
 	ccrypto "github.com/NPC-Chain/npcchub/crypto"
 	"github.com/NPC-Chain/npcchub/crypto/keys/hd"
 	"github.com/NPC-Chain/npcchub/types"
@@ -33,12 +37,25 @@ type Keybase interface {
 	// See https://github.com/NPC-Chain/npcchub/issues/2095
 	Derive(name, mnemonic, bip39Passwd,
 		encryptPasswd string, params hd.BIP44Params) (Info, error)
+	// CreateAccount derives the key of the algo at the HD path from the mnemonic and
+	// bip39Passwd, and encrypts it to disk using encryptPasswd
+	CreateAccount(name, mnemonic, bip39Passwd, encryptPasswd, hdPath string, algo SigningAlgo) (Info, error)
+	// DeriveAddresses derives the keys at the BIP44 params from the mnemonic and stores
+	// them under the one name, the key of the first params being the key of the name
+	DeriveAddresses(name, mnemonic, bip39Passwd, encryptPasswd string, params []hd.BIP44Params) (Info, error)
+	// GetIndex returns the info of the index-th address stored under the name,
+	// 0 being the key of the name. The info of a derived address is named
+	// <name>#<index>, which Get and Sign resolve to the derived key
+	GetIndex(name string, index int) (Info, error)
 	// Create, store, and return a new Ledger key reference
 	CreateLedger(name string, path ccrypto.DerivationPath, algo SigningAlgo) (info Info, err error)
 
 	// Create, store, and return a new offline key reference
 	CreateOffline(name string, pubkey crypto.PubKey) (info Info, err error)
 
+	// CreateRemote stores and returns a new reference to a key of a remote signer
+	CreateRemote(name string, signer RemoteSigner) (info Info, err error)
+
 	// CreateMulti creates, stores, and returns a new multsig (offline) key reference
 	CreateMulti(name string, pubkey crypto.PubKey) (info Info, err error)
 
@@ -68,6 +85,7 @@ const (
 	TypeLedger  KeyType = 1
 	TypeOffline KeyType = 2
 	TypeMulti   KeyType = 3
+	TypeRemote  KeyType = 4
 )
 
 var keyTypes = map[KeyType]string{
@@ -75,6 +93,7 @@ var keyTypes = map[KeyType]string{
 	TypeLedger:  "ledger",
 	TypeOffline: "offline",
 	TypeMulti:   "multi",
+	TypeRemote:  "remote",
 }
 
 // String implements the stringer interface for KeyType.
@@ -97,12 +116,24 @@ type Info interface {
 var _ Info = &localInfo{}
 var _ Info = &ledgerInfo{}
 var _ Info = &offlineInfo{}
+var _ Info = &remoteInfo{}
 
 // localInfo is the public information about a locally stored key
 type localInfo struct {
 	Name         string        `json:"name"`
 	PubKey       crypto.PubKey `json:"pubkey"`
 	PrivKeyArmor string        `json:"privkey.armor"`
+	// Path is the HD path of the key, if it was stored with the addresses derived
+	// from the same mnemonic
+	Path    string       `json:"path"`
+	Derived []derivedKey `json:"derived"`
+}
+
+// derivedKey is an address derived from the mnemonic of a local key and stored with it
+type derivedKey struct {
+	Path         string        `json:"path"`
+	PubKey       crypto.PubKey `json:"pubkey"`
+	PrivKeyArmor string        `json:"privkey.armor"`
 }
 
 func newLocalInfo(name string, pub crypto.PubKey, privArmor string) Info {
@@ -129,6 +160,41 @@ func (i localInfo) GetAddress() types.AccAddress {
 	return i.PubKey.Address().Bytes()
 }
 
+// derivedInfo returns the info of the index-th address of the key, 0 being the key itself
+func (i localInfo) derivedInfo(index int) (localInfo, bool) {
+	if index == 0 {
+		return i, true
+	}
+	if index < 0 || index > len(i.Derived) {
+		return localInfo{}, false
+	}
+	key := i.Derived[index-1]
+	return localInfo{
+		Name:         derivedName(i.Name, index),
+		PubKey:       key.PubKey,
+		PrivKeyArmor: key.PrivKeyArmor,
+		Path:         key.Path,
+	}, true
+}
+
+// derivedName returns the name of the index-th address stored under the name
+func derivedName(name string, index int) string {
+	return fmt.Sprintf("%s#%d", name, index)
+}
+
+// splitDerivedName returns the name and the index of a derived address name
+func splitDerivedName(name string) (string, int, bool) {
+	i := strings.LastIndex(name, "#")
+	if i < 0 {
+		return "", 0, false
+	}
+	index, err := strconv.Atoi(name[i+1:])
+	if err != nil || index <= 0 {
+		return "", 0, false
+	}
+	return name[:i], index, true
+}
+
 // ledgerInfo is the public information about a Ledger key
 type ledgerInfo struct {
 	Name   string                 `json:"name"`
@@ -189,6 +255,37 @@ func (i offlineInfo) GetAddress() types.AccAddress {
 	return i.PubKey.Address().Bytes()
 }
 
+// remoteInfo is the public information about a key of a remote signer
+type remoteInfo struct {
+	Name   string        `json:"name"`
+	PubKey crypto.PubKey `json:"pubkey"`
+	Signer RemoteSigner  `json:"signer"`
+}
+
+func newRemoteInfo(name string, pub crypto.PubKey, signer RemoteSigner) Info {
+	return &remoteInfo{
+		Name:   name,
+		PubKey: pub,
+		Signer: signer,
+	}
+}
+
+func (i remoteInfo) GetType() KeyType {
+	return TypeRemote
+}
+
+func (i remoteInfo) GetName() string {
+	return i.Name
+}
+
+func (i remoteInfo) GetPubKey() crypto.PubKey {
+	return i.PubKey
+}
+
+func (i remoteInfo) GetAddress() types.AccAddress {
+	return i.PubKey.Address().Bytes()
+}
+
 type multisigPubKeyInfo struct {
 	PubKey crypto.PubKey `json:"pubkey"`
 	Weight uint          `json:"weight"`
@@ -1,17 +1,19 @@
 package keystore
 
 import (
-	"crypto/sha256"
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
+	"strconv"
 
 	"github.com/NPC-Chain/npcchub/crypto/keystore/uuid"
+	"github.com/NPC-Chain/npcchub/crypto/secp256r1"
 	ctypes "github.com/NPC-Chain/npcchub/types"
 	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/ed25519"
 	"github.com/tendermint/tendermint/crypto/secp256k1"
-	"golang.org/x/crypto/pbkdf2"
+	"golang.org/x/crypto/scrypt"
 )
 
 type KeyManager interface {
@@ -29,7 +31,7 @@ func (m *keyManager) GetPrivKey() crypto.PrivKey {
 }
 
 func (m *keyManager) ExportAsKeyStore(password string) (*EncryptedKeyJSON, error) {
-	return generateKeyStore(m.GetPrivKey(), password)
+	return generateKeyStore(m.GetPrivKey(), password, ScryptN, ScryptP)
 }
 
 func NewKeyManager(privKey crypto.PrivKey) KeyManager {
@@ -46,19 +48,11 @@ func NewKeyStoreKeyManager(file string, auth string) (KeyManager, error) {
 }
 
 func (m *keyManager) recoveryFromKeyStore(keystoreFile string, auth string) error {
-	if auth == "" {
-		return fmt.Errorf("Password is missing ")
-	}
 	keyJson, err := ioutil.ReadFile(keystoreFile)
 	if err != nil {
 		return err
 	}
-	var encryptedKey EncryptedKeyJSON
-	err = json.Unmarshal(keyJson, &encryptedKey)
-	if err != nil {
-		return err
-	}
-	keyBytes, err := decryptKey(&encryptedKey, auth)
+	keyBytes, err := DecryptKeyJSON(keyJson, auth)
 	if err != nil {
 		return err
 	}
@@ -74,8 +68,51 @@ func (m *keyManager) recoveryFromKeyStore(keystoreFile string, auth string) erro
 	return nil
 }
 
-func generateKeyStore(privateKey crypto.PrivKey, password string) (*EncryptedKeyJSON, error) {
-	addr := ctypes.AccAddress(privateKey.PubKey().Address())
+// DecryptKeyJSON returns the raw key bytes of the keystore file, of the V3 format or of
+// the former pbkdf2 one
+func DecryptKeyJSON(keyJSON []byte, auth string) ([]byte, error) {
+	if auth == "" {
+		return nil, fmt.Errorf("Password is missing ")
+	}
+	var encryptedKey EncryptedKeyJSON
+	err := json.Unmarshal(keyJSON, &encryptedKey)
+	if err != nil {
+		return nil, err
+	}
+	return decryptKey(&encryptedKey, auth)
+}
+
+// PrivKeyBytes returns the raw bytes of the private key stored in a keystore file,
+// the 32 byte scalar of secp256k1 and secp256r1 keys and the 64 bytes of ed25519 ones
+func PrivKeyBytes(privateKey crypto.PrivKey) ([]byte, error) {
+	switch privKey := privateKey.(type) {
+	case secp256k1.PrivKeySecp256k1:
+		return privKey[:], nil
+	case secp256r1.PrivKeySecp256r1:
+		return privKey[:], nil
+	case ed25519.PrivKeyEd25519:
+		return privKey[:], nil
+	default:
+		return nil, fmt.Errorf("Unsupported private key type %T", privateKey)
+	}
+}
+
+func generateKeyStore(privateKey crypto.PrivKey, password string, scryptN, scryptP int) (*EncryptedKeyJSON, error) {
+	keyBytes, err := PrivKeyBytes(privateKey)
+	if err != nil {
+		return nil, err
+	}
+	return encryptKey(keyBytes, ctypes.AccAddress(privateKey.PubKey().Address()).String(), password, scryptN, scryptP)
+}
+
+// EncryptKey returns the V3 keystore file of the raw key bytes, encrypted with AES-128-CTR
+// under the scrypt key of the password
+func EncryptKey(keyBytes []byte, address string, password string) (*EncryptedKeyJSON, error) {
+	return encryptKey(keyBytes, address, password, ScryptN, ScryptP)
+}
+
+// encryptKey encrypts the key under the scrypt key of the password derived with the given cost parameters
+func encryptKey(keyBytes []byte, address string, password string, scryptN, scryptP int) (*EncryptedKeyJSON, error) {
 	salt, err := GenerateRandomBytes(32)
 	if err != nil {
 		return nil, err
@@ -84,45 +121,43 @@ func generateKeyStore(privateKey crypto.PrivKey, password string) (*EncryptedKey
 	if err != nil {
 		return nil, err
 	}
-	scryptParamsJSON := make(map[string]interface{}, 4)
-	scryptParamsJSON["prf"] = "hmac-sha256"
+	scryptParamsJSON := make(map[string]interface{}, 5)
+	scryptParamsJSON["n"] = scryptN
+	scryptParamsJSON["r"] = scryptR
+	scryptParamsJSON["p"] = scryptP
 	scryptParamsJSON["dklen"] = 32
 	scryptParamsJSON["salt"] = hex.EncodeToString(salt)
-	scryptParamsJSON["c"] = 262144
 
 	cipherParamsJSON := cipherparamsJSON{IV: hex.EncodeToString(iv)}
-	derivedKey := pbkdf2.Key([]byte(password), salt, 262144, 32, sha256.New)
-	encryptKey := derivedKey[:16]
-	secpPrivateKey, ok := privateKey.(secp256k1.PrivKeySecp256k1)
-	if !ok {
-		return nil, fmt.Errorf(" Only PrivKeySecp256k1 key is supported ")
+	derivedKey, err := scrypt.Key([]byte(password), salt, scryptN, scryptR, scryptP, 32)
+	if err != nil {
+		return nil, err
 	}
-	cipherText, err := aesCTRXOR(encryptKey, secpPrivateKey[:], iv)
+	encryptKey := derivedKey[:16]
+	cipherText, err := aesCTRXOR(encryptKey, keyBytes, iv)
 	if err != nil {
 		return nil, err
 	}
 
-	hasher := sha256.New()
-	hasher.Write(derivedKey[16:32])
-	hasher.Write(cipherText)
-	mac := hasher.Sum(nil)
+	version := json.Number(strconv.Itoa(Version))
+	mac := keyMAC(version, derivedKey, cipherText)
 
 	id, err := uuid.NewV4()
 	if err != nil {
 		return nil, err
 	}
 	cryptoStruct := CryptoJSON{
-		Cipher:       "aes-128-ctr",
+		Cipher:       cipherAES128CTR,
 		CipherText:   hex.EncodeToString(cipherText),
 		CipherParams: cipherParamsJSON,
-		KDF:          "pbkdf2",
+		KDF:          kdfScrypt,
 		KDFParams:    scryptParamsJSON,
 		MAC:          hex.EncodeToString(mac),
 	}
 	return &EncryptedKeyJSON{
-		Address: addr.String(),
+		Address: address,
 		Crypto:  cryptoStruct,
 		Id:      id.String(),
-		Version: "1",
+		Version: version,
 	}, nil
 }
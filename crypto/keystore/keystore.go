@@ -7,16 +7,38 @@ import (
 	"crypto/cipher"
 	"crypto/sha256"
 	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"hash"
+	"strconv"
 
 	"golang.org/x/crypto/pbkdf2"
+	"golang.org/x/crypto/scrypt"
+	"golang.org/x/crypto/sha3"
 )
 
 var (
 	ErrDecrypt = errors.New("could not decrypt key with given passphrase")
 )
 
+const (
+	// Version is the version of the keystore files written, those of Ethereum
+	Version = 3
+
+	cipherAES128CTR = "aes-128-ctr"
+	kdfScrypt       = "scrypt"
+	kdfPBKDF2       = "pbkdf2"
+)
+
+// Scrypt parameters of the keystore files written, the standard ones of Ethereum
+const (
+	ScryptN = 1 << 18
+	ScryptP = 1
+)
+
+const scryptR = 8
+
 type PlainKeyJSON struct {
 	Address    string `json:"address"`
 	PrivateKey string `json:"privatekey"`
@@ -28,7 +50,8 @@ type EncryptedKeyJSON struct {
 	Address string     `json:"address"`
 	Crypto  CryptoJSON `json:"crypto"`
 	Id      string     `json:"id"`
-	Version string     `json:"version"`
+	// Version is 3, or "1" for the files written before the V3 format
+	Version json.Number `json:"version"`
 }
 type CryptoJSON struct {
 	Cipher       string                 `json:"cipher"`
@@ -44,6 +67,9 @@ type cipherparamsJSON struct {
 }
 
 func decryptKey(keyProtected *EncryptedKeyJSON, auth string) ([]byte, error) {
+	if keyProtected.Crypto.Cipher != cipherAES128CTR {
+		return nil, fmt.Errorf("Unsupported cipher: %s", keyProtected.Crypto.Cipher)
+	}
 	mac, err := hex.DecodeString(keyProtected.Crypto.MAC)
 	if err != nil {
 		return nil, err
@@ -64,11 +90,7 @@ func decryptKey(keyProtected *EncryptedKeyJSON, auth string) ([]byte, error) {
 		return nil, err
 	}
 
-	bufferValue := make([]byte, len(cipherText)+16)
-	copy(bufferValue[0:16], derivedKey[16:32])
-	copy(bufferValue[16:], cipherText[:])
-	calculatedMAC := sha256.Sum256([]byte((bufferValue)))
-	if !bytes.Equal(calculatedMAC[:], mac) {
+	if !bytes.Equal(keyMAC(keyProtected.Version, derivedKey, cipherText), mac) {
 		return nil, ErrDecrypt
 	}
 
@@ -79,36 +101,69 @@ func decryptKey(keyProtected *EncryptedKeyJSON, auth string) ([]byte, error) {
 	return plainText, err
 }
 
+// keyMAC returns the MAC of the cipher text, keccak256 as in the V3 files of Ethereum,
+// and sha256 in the files written before
+func keyMAC(version json.Number, derivedKey, cipherText []byte) []byte {
+	var hasher hash.Hash
+	if version == json.Number(strconv.Itoa(Version)) {
+		hasher = sha3.NewLegacyKeccak256()
+	} else {
+		hasher = sha256.New()
+	}
+	hasher.Write(derivedKey[16:32])
+	hasher.Write(cipherText)
+	return hasher.Sum(nil)
+}
+
 func getKDFKey(cryptoJSON CryptoJSON, auth string) ([]byte, error) {
 	authArray := []byte(auth)
-	if cryptoJSON.KDFParams["salt"] == nil || cryptoJSON.KDFParams["dklen"] == nil ||
-		cryptoJSON.KDFParams["c"] == nil || cryptoJSON.KDFParams["prf"] == nil {
-		return nil, errors.New("invalid KDF params, must contains c, dklen, prf and salt")
+	saltHex, ok := cryptoJSON.KDFParams["salt"].(string)
+	if !ok {
+		return nil, errors.New("invalid KDF params, must contains salt")
 	}
-	salt, err := hex.DecodeString(cryptoJSON.KDFParams["salt"].(string))
+	salt, err := hex.DecodeString(saltHex)
 	if err != nil {
 		return nil, err
 	}
-	dkLen := ensureInt(cryptoJSON.KDFParams["dklen"])
+	dkLen, ok := ensureInt(cryptoJSON.KDFParams["dklen"])
+	if !ok || dkLen < 32 {
+		return nil, errors.New("invalid KDF params, dklen must be at least 32")
+	}
 
-	if cryptoJSON.KDF == "pbkdf2" {
-		c := ensureInt(cryptoJSON.KDFParams["c"])
-		prf := cryptoJSON.KDFParams["prf"].(string)
+	switch cryptoJSON.KDF {
+	case kdfScrypt:
+		n, okN := ensureInt(cryptoJSON.KDFParams["n"])
+		r, okR := ensureInt(cryptoJSON.KDFParams["r"])
+		p, okP := ensureInt(cryptoJSON.KDFParams["p"])
+		if !okN || !okR || !okP {
+			return nil, errors.New("invalid KDF params, must contains n, r, p, dklen and salt")
+		}
+		return scrypt.Key(authArray, salt, n, r, p, dkLen)
+	case kdfPBKDF2:
+		c, okC := ensureInt(cryptoJSON.KDFParams["c"])
+		prf, okPRF := cryptoJSON.KDFParams["prf"].(string)
+		if !okC || !okPRF {
+			return nil, errors.New("invalid KDF params, must contains c, dklen, prf and salt")
+		}
 		if prf != "hmac-sha256" {
 			return nil, fmt.Errorf("Unsupported PBKDF2 PRF: %s", prf)
 		}
 		key := pbkdf2.Key(authArray, salt, c, dkLen, sha256.New)
 		return key, nil
+	default:
+		return nil, fmt.Errorf("Unsupported KDF: %s", cryptoJSON.KDF)
 	}
-	return nil, fmt.Errorf("Unsupported KDF: %s", cryptoJSON.KDF)
 }
 
-func ensureInt(x interface{}) int {
-	res, ok := x.(int)
-	if !ok {
-		res = int(x.(float64))
+func ensureInt(x interface{}) (int, bool) {
+	switch x := x.(type) {
+	case int:
+		return x, true
+	case float64:
+		return int(x), true
+	default:
+		return 0, false
 	}
-	return res
 }
 
 func aesCTRXOR(key, inText, iv []byte) ([]byte, error) {
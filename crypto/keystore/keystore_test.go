@@ -2,16 +2,24 @@ package keystore
 
 import (
 	"bytes"
+	"crypto/sha256"
 	"encoding/hex"
 	"encoding/json"
 	"io/ioutil"
 	"os"
 	"testing"
 
+	"github.com/NPC-Chain/npcchub/crypto/secp256r1"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/ed25519"
 	"github.com/tendermint/tendermint/crypto/secp256k1"
 )
 
+// testScryptN is a low scrypt cost keeping the tests fast, the files written with it are read as any other
+const testScryptN = 1 << 4
+
 func TestKeystore(t *testing.T) {
 	defer os.Remove("TestGenerateKeyStoreNoError.json")
 
@@ -22,7 +30,7 @@ func TestKeystore(t *testing.T) {
 	encryPlain1, err := km.GetPrivKey().Sign([]byte("test plain"))
 	assert.NoError(t, err)
 
-	encryptedKeyJSON, err := km.ExportAsKeyStore("testpassword")
+	encryptedKeyJSON, err := generateKeyStore(km.GetPrivKey(), "testpassword", testScryptN, ScryptP)
 	assert.NoError(t, err)
 
 	bz, err := json.Marshal(encryptedKeyJSON)
@@ -41,3 +49,60 @@ func TestKeystore(t *testing.T) {
 	assert.Equal(t, km.GetPrivKey().Bytes(), newkm.GetPrivKey().Bytes())
 	assert.Equal(t, km.GetPrivKey().PubKey(), newkm.GetPrivKey().PubKey())
 }
+
+// the test vectors of the Web3 Secret Storage Definition
+var v3Vectors = []string{
+	`{"crypto":{"cipher":"aes-128-ctr","cipherparams":{"iv":"83dbcc02d8ccb40e466191a123791e0e"},"ciphertext":"d172bf743a674da9cdad04534d56926ef8358534d458fffccd4e6ad2fbde479c","kdf":"scrypt","kdfparams":{"dklen":32,"n":262144,"r":1,"p":8,"salt":"ab0c7876052600dd703518d6fc3fe8984592145b591fc8fb5c6d43190334ba19"},"mac":"2103ac29920d71da29f15d75b4a16dbe95cfd7ff8faea1056c33131d846e3097"},"id":"3198bc9c-6672-5ab3-d995-4942343ae5b6","version":3}`,
+	`{"crypto":{"cipher":"aes-128-ctr","cipherparams":{"iv":"6087dab2f9fdbbfaddc31a909735c1e6"},"ciphertext":"5318b4d5bcd28de64ee5559e671353e16f075ecae9f99c7a79a38af5f869aa46","kdf":"pbkdf2","kdfparams":{"c":262144,"dklen":32,"prf":"hmac-sha256","salt":"ae3cd4e7013836a3df6bd7241b12db061dbe2c6785853cce422d148a624ce0bd"},"mac":"517ead924a9d0dc3124507e3393d175ce3ff7c1e96529c6c555ce9e51205e9b2"},"id":"3198bc9c-6672-5ab3-d995-4942343ae5b6","version":3}`,
+}
+
+func TestDecryptV3Vectors(t *testing.T) {
+	for _, keyJSON := range v3Vectors {
+		keyBytes, err := DecryptKeyJSON([]byte(keyJSON), "testpassword")
+		require.NoError(t, err)
+		require.Equal(t, "7a28b5ba57c53603b0b07b56bba752f7784bf506fa95edc395f5cf6c7514fe9d", hex.EncodeToString(keyBytes))
+
+		_, err = DecryptKeyJSON([]byte(keyJSON), "wrongpassword")
+		require.Equal(t, ErrDecrypt, err)
+	}
+}
+
+func TestEncryptKey(t *testing.T) {
+	for _, privKey := range []crypto.PrivKey{secp256k1.GenPrivKey(), secp256r1.GenPrivKey(), ed25519.GenPrivKey()} {
+		keyBytes, err := PrivKeyBytes(privKey)
+		require.NoError(t, err)
+		encryptedKeyJSON, err := encryptKey(keyBytes, "address", "testpassword", testScryptN, ScryptP)
+		require.NoError(t, err)
+		require.Equal(t, "3", encryptedKeyJSON.Version.String())
+		require.Equal(t, "scrypt", encryptedKeyJSON.Crypto.KDF)
+		require.Len(t, encryptedKeyJSON.Id, 36)
+
+		keyJSON, err := json.Marshal(encryptedKeyJSON)
+		require.NoError(t, err)
+		require.Contains(t, string(keyJSON), `"version":3`)
+		decrypted, err := DecryptKeyJSON(keyJSON, "testpassword")
+		require.NoError(t, err)
+		require.Equal(t, keyBytes, decrypted)
+	}
+}
+
+// TestDecryptFormerKeystore checks the files written before the V3 format, of version "1" and with a sha256 MAC, are read
+func TestDecryptFormerKeystore(t *testing.T) {
+	keyBytes := secp256k1.GenPrivKey()
+	encryptedKeyJSON, err := encryptKey(keyBytes[:], "address", "testpassword", testScryptN, ScryptP)
+	require.NoError(t, err)
+	encryptedKeyJSON.Version = "1"
+	derivedKey, err := getKDFKey(encryptedKeyJSON.Crypto, "testpassword")
+	require.NoError(t, err)
+	cipherText, err := hex.DecodeString(encryptedKeyJSON.Crypto.CipherText)
+	require.NoError(t, err)
+	mac := sha256.Sum256(append(derivedKey[16:32], cipherText...))
+	encryptedKeyJSON.Crypto.MAC = hex.EncodeToString(mac[:])
+
+	keyJSON, err := json.Marshal(encryptedKeyJSON)
+	require.NoError(t, err)
+	require.Contains(t, string(keyJSON), `"version":1`)
+	decrypted, err := DecryptKeyJSON(bytes.Replace(keyJSON, []byte(`"version":1`), []byte(`"version":"1"`), 1), "testpassword")
+	require.NoError(t, err)
+	require.Equal(t, keyBytes[:], decrypted)
+}
@@ -0,0 +1,248 @@
+package secp256r1
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"fmt"
+	"io"
+	"math/big"
+
+	amino "github.com/tendermint/go-amino"
+	"github.com/tendermint/tendermint/crypto"
+)
+
+const (
+	PrivKeyAminoRoute = "irishub/PrivKeySecp256r1"
+	PubKeyAminoRoute  = "irishub/PubKeySecp256r1"
+)
+
+var cdc = amino.NewCodec()
+
+func init() {
+	cdc.RegisterInterface((*crypto.PubKey)(nil), nil)
+	cdc.RegisterConcrete(PubKeySecp256r1{},
+		PubKeyAminoRoute, nil)
+
+	cdc.RegisterInterface((*crypto.PrivKey)(nil), nil)
+	cdc.RegisterConcrete(PrivKeySecp256r1{},
+		PrivKeyAminoRoute, nil)
+}
+
+// SignatureSize is the size of a signature, the big-endian r and s padded to 32 bytes each
+const SignatureSize = 64
+
+var (
+	curve     = elliptic.P256()
+	halfOrder = new(big.Int).Rsh(curve.Params().N, 1)
+)
+
+//-------------------------------------
+
+var _ crypto.PrivKey = PrivKeySecp256r1{}
+
+// PrivKeySecp256r1 implements PrivKey, it is the big-endian scalar of an ECDSA key on curve P-256.
+type PrivKeySecp256r1 [32]byte
+
+// Bytes marshalls the private key using amino encoding.
+func (privKey PrivKeySecp256r1) Bytes() []byte {
+	return cdc.MustMarshalBinaryBare(privKey)
+}
+
+func (privKey PrivKeySecp256r1) ecdsa() *ecdsa.PrivateKey {
+	priv := &ecdsa.PrivateKey{D: new(big.Int).SetBytes(privKey[:])}
+	priv.PublicKey.Curve = curve
+	priv.PublicKey.X, priv.PublicKey.Y = curve.ScalarBaseMult(privKey[:])
+	return priv
+}
+
+// Sign creates an ECDSA signature on curve P-256, using SHA256 on the msg.
+// The s term is normalized to the lower half of the order, as VerifyBytes requires.
+func (privKey PrivKeySecp256r1) Sign(msg []byte) ([]byte, error) {
+	r, s, err := ecdsa.Sign(rand.Reader, privKey.ecdsa(), crypto.Sha256(msg))
+	if err != nil {
+		return nil, err
+	}
+	if s.Cmp(halfOrder) > 0 {
+		s.Sub(curve.Params().N, s)
+	}
+	sig := make([]byte, SignatureSize)
+	fillBytes(r, sig[:32])
+	fillBytes(s, sig[32:])
+	return sig, nil
+}
+
+// PubKey performs the point-scalar multiplication from the privKey on the
+// generator point to get the pubkey.
+func (privKey PrivKeySecp256r1) PubKey() crypto.PubKey {
+	priv := privKey.ecdsa()
+	var pubkeyBytes PubKeySecp256r1
+	copy(pubkeyBytes[:], marshalCompressed(priv.X, priv.Y))
+	return pubkeyBytes
+}
+
+// Equals - you probably don't need to use this.
+// Runs in constant time based on length of the keys.
+func (privKey PrivKeySecp256r1) Equals(other crypto.PrivKey) bool {
+	if otherSecp, ok := other.(PrivKeySecp256r1); ok {
+		return subtle.ConstantTimeCompare(privKey[:], otherSecp[:]) == 1
+	}
+	return false
+}
+
+// GenPrivKey generates a new ECDSA private key on curve P-256.
+func GenPrivKey() PrivKeySecp256r1 {
+	return genPrivKey(crypto.CReader())
+}
+
+// genPrivKey generates a new secp256r1 private key using the provided reader.
+func genPrivKey(rand io.Reader) PrivKeySecp256r1 {
+	for {
+		privKeyBytes := [32]byte{}
+		_, err := io.ReadFull(rand, privKeyBytes[:])
+		if err != nil {
+			panic(err)
+		}
+		if privKey, ok := PrivKeyFromScalar(privKeyBytes[:]); ok {
+			return privKey
+		}
+	}
+}
+
+// GenPrivKeySecp256r1 hashes the secret with SHA2 and uses the 32 byte output as the scalar
+// of the private key, hashing again in the negligible case it is not in [1, N-1].
+// NOTE: secret should be the output of a KDF like bcrypt,
+// if it's derived from user input.
+func GenPrivKeySecp256r1(secret []byte) PrivKeySecp256r1 {
+	for {
+		privKey32 := sha256.Sum256(secret)
+		if privKey, ok := PrivKeyFromScalar(privKey32[:]); ok {
+			return privKey
+		}
+		secret = privKey32[:]
+	}
+}
+
+// PrivKeyFromScalar returns the private key of the 32 byte big-endian scalar,
+// which must be in [1, N-1]
+func PrivKeyFromScalar(bz []byte) (PrivKeySecp256r1, bool) {
+	var privKey PrivKeySecp256r1
+	if len(bz) != len(privKey) {
+		return privKey, false
+	}
+	d := new(big.Int).SetBytes(bz)
+	if d.Sign() == 0 || d.Cmp(curve.Params().N) >= 0 {
+		return privKey, false
+	}
+	copy(privKey[:], bz)
+	return privKey, true
+}
+
+//-------------------------------------
+
+var _ crypto.PubKey = PubKeySecp256r1{}
+
+// PubKeySecp256r1Size is comprised of 32 bytes for the x-coordinate,
+// plus one byte for the parity of the y-coordinate.
+const PubKeySecp256r1Size = 33
+
+// PubKeySecp256r1 implements crypto.PubKey.
+// It is the compressed form of the pubkey, a 0x02 or 0x03 byte for the parity
+// of the y-coordinate followed with the x-coordinate.
+type PubKeySecp256r1 [PubKeySecp256r1Size]byte
+
+// Address returns the first 20 bytes of SHA256(pubkey)
+func (pubKey PubKeySecp256r1) Address() crypto.Address {
+	return crypto.AddressHash(pubKey[:])
+}
+
+// Bytes returns the pubkey marshalled with amino encoding.
+func (pubKey PubKeySecp256r1) Bytes() []byte {
+	bz, err := cdc.MarshalBinaryBare(pubKey)
+	if err != nil {
+		panic(err)
+	}
+	return bz
+}
+
+// VerifyBytes verifies a 64 byte r || s signature, rejecting the malleable
+// signatures whose s term is in the upper half of the order.
+func (pubKey PubKeySecp256r1) VerifyBytes(msg []byte, sig []byte) bool {
+	if len(sig) != SignatureSize {
+		return false
+	}
+	x, y := unmarshalCompressed(pubKey[:])
+	if x == nil {
+		return false
+	}
+	r := new(big.Int).SetBytes(sig[:32])
+	s := new(big.Int).SetBytes(sig[32:])
+	if s.Cmp(halfOrder) > 0 {
+		return false
+	}
+	return ecdsa.Verify(&ecdsa.PublicKey{Curve: curve, X: x, Y: y}, crypto.Sha256(msg), r, s)
+}
+
+func (pubKey PubKeySecp256r1) String() string {
+	return fmt.Sprintf("PubKeySecp256r1{%X}", pubKey[:])
+}
+
+func (pubKey PubKeySecp256r1) Equals(other crypto.PubKey) bool {
+	if otherSecp, ok := other.(PubKeySecp256r1); ok {
+		return bytes.Equal(pubKey[:], otherSecp[:])
+	}
+	return false
+}
+
+//-------------------------------------
+
+// fillBytes sets buf to the big-endian bytes of x, zero-padded on the left.
+func fillBytes(x *big.Int, buf []byte) {
+	for i := range buf {
+		buf[i] = 0
+	}
+	bz := x.Bytes()
+	copy(buf[len(buf)-len(bz):], bz)
+}
+
+// marshalCompressed converts the point (x, y) to the compressed form of SEC 1, section 2.3.3.
+func marshalCompressed(x, y *big.Int) []byte {
+	compressed := make([]byte, PubKeySecp256r1Size)
+	compressed[0] = byte(y.Bit(0)) | 2
+	fillBytes(x, compressed[1:])
+	return compressed
+}
+
+// unmarshalCompressed converts the compressed form of a point to (x, y).
+// It returns x = nil on error, if the bytes are not a valid point on the curve.
+func unmarshalCompressed(data []byte) (x, y *big.Int) {
+	params := curve.Params()
+	if len(data) != PubKeySecp256r1Size || (data[0] != 2 && data[0] != 3) {
+		return nil, nil
+	}
+	x = new(big.Int).SetBytes(data[1:])
+	if x.Cmp(params.P) >= 0 {
+		return nil, nil
+	}
+	// y² = x³ - 3x + b
+	y = new(big.Int).Mul(x, x)
+	y.Mul(y, x)
+	threeX := new(big.Int).Lsh(x, 1)
+	threeX.Add(threeX, x)
+	y.Sub(y, threeX)
+	y.Add(y, params.B)
+	y.Mod(y, params.P)
+	if y.ModSqrt(y, params.P) == nil {
+		return nil, nil
+	}
+	if byte(y.Bit(0)) != data[0]&1 {
+		y.Neg(y).Mod(y, params.P)
+	}
+	if !curve.IsOnCurve(x, y) {
+		return nil, nil
+	}
+	return x, y
+}
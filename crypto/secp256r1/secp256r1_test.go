@@ -0,0 +1,63 @@
+package secp256r1
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignAndVerify(t *testing.T) {
+	priv := GenPrivKey()
+	pub := priv.PubKey()
+	msg := []byte("msg")
+
+	sig, err := priv.Sign(msg)
+	require.NoError(t, err)
+	require.Len(t, sig, SignatureSize)
+	require.True(t, pub.VerifyBytes(msg, sig))
+	require.False(t, pub.VerifyBytes([]byte("another msg"), sig))
+	require.False(t, GenPrivKey().PubKey().VerifyBytes(msg, sig))
+
+	// the malleated signature of the upper s term is rejected
+	s := new(big.Int).SetBytes(sig[32:])
+	malleated := make([]byte, SignatureSize)
+	copy(malleated, sig[:32])
+	fillBytes(new(big.Int).Sub(curve.Params().N, s), malleated[32:])
+	require.False(t, pub.VerifyBytes(msg, malleated))
+}
+
+func TestPrivKeyFromScalar(t *testing.T) {
+	_, ok := PrivKeyFromScalar(make([]byte, 32))
+	require.False(t, ok)
+	_, ok = PrivKeyFromScalar(curve.Params().N.Bytes())
+	require.False(t, ok)
+	_, ok = PrivKeyFromScalar([]byte{1})
+	require.False(t, ok)
+
+	one := make([]byte, 32)
+	one[31] = 1
+	priv, ok := PrivKeyFromScalar(one)
+	require.True(t, ok)
+	require.Equal(t, GenPrivKeySecp256r1([]byte("secret")), GenPrivKeySecp256r1([]byte("secret")))
+	require.False(t, priv.Equals(GenPrivKeySecp256r1([]byte("secret"))))
+}
+
+func TestCompressedPubKey(t *testing.T) {
+	for i := 0; i < 16; i++ {
+		priv := GenPrivKey()
+		x, y := curve.ScalarBaseMult(priv[:])
+		pub := priv.PubKey().(PubKeySecp256r1)
+
+		ux, uy := unmarshalCompressed(pub[:])
+		require.Equal(t, x, ux)
+		require.Equal(t, y, uy)
+	}
+
+	var invalid PubKeySecp256r1
+	invalid[0] = 4
+	x, _ := unmarshalCompressed(invalid[:])
+	require.Nil(t, x)
+	x, _ = unmarshalCompressed(invalid[:32])
+	require.Nil(t, x)
+}
@@ -26,7 +26,7 @@ func SetPruning(pruning string) func(*BaseApp) {
 		panic(fmt.Sprintf("Invalid pruning strategy: %s", pruning))
 	}
 	return func(bap *BaseApp) {
-		bap.cms.SetPruning(pruningEnum)
+		bap.cms.SetPruning(pruningEnum.Options())
 	}
 }
 
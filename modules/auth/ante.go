@@ -4,9 +4,14 @@ import (
 	"bytes"
 	"encoding/hex"
 	"fmt"
+	"math"
+	"math/big"
+
+	"github.com/NPC-Chain/npcchub/crypto/secp256r1"
 	sdk "github.com/NPC-Chain/npcchub/types"
 	"github.com/tendermint/tendermint/crypto"
 	"github.com/tendermint/tendermint/crypto/ed25519"
+	"github.com/tendermint/tendermint/crypto/multisig"
 	"github.com/tendermint/tendermint/crypto/secp256k1"
 )
 
@@ -21,6 +26,15 @@ const (
 	txSigLimit = 7
 )
 
+// SigVerifyProtocolVersion is the first protocol version accepting the secp256r1 and multisig account keys
+// and pricing the signature verifications with the params. The blocks of the earlier versions keep the
+// fixed costs and the key types of before, so that they replay with the same results.
+const SigVerifyProtocolVersion = 3
+
+func sigVerifyParamsEnabled(ctx sdk.Context) bool {
+	return ctx.BlockHeader().Version.App >= SigVerifyProtocolVersion
+}
+
 // NewAnteHandler returns an AnteHandler that checks
 // and increments sequence numbers, checks signatures & account numbers,
 // and deducts fees from the first signer.
@@ -45,9 +59,16 @@ func NewAnteHandler(am AccountKeeper, fck FeeKeeper) sdk.AnteHandler {
 			if !res.IsOK() {
 				return newCtx, res, true
 			}
+			res = ensurePendingTxsLimit(ctx, stdTx)
+			if !res.IsOK() {
+				return newCtx, res, true
+			}
 		}
 
 		newCtx = setGasMeter(simulate, ctx, stdTx.Fee.Gas)
+		if ctx.IsCheckTx() && !simulate {
+			newCtx = newCtx.WithPriority(GetTxPriority(stdTx))
+		}
 
 		// AnteHandlers must have their own defer/recover in order
 		// for the BaseApp to know how much gas was used!
@@ -98,9 +119,14 @@ func NewAnteHandler(am AccountKeeper, fck FeeKeeper) sdk.AnteHandler {
 			fck.AddCollectedFees(newCtx, stdTx.Fee.Amount)
 		}
 
+		// the blocks before SigVerifyProtocolVersion don't read the costs from the params
+		var params Params
+		if sigVerifyParamsEnabled(ctx) {
+			params = fck.GetSigVerifyCosts(ctx)
+		}
 		for i := 0; i < len(stdSigs); i++ {
 			// check signature, return account with incremented nonce
-			signerAccs[i], res = processSig(newCtx, signerAccs[i], stdSigs[i], signBytesList[i], simulate)
+			signerAccs[i], res = processSig(newCtx, signerAccs[i], stdSigs[i], signBytesList[i], simulate, params)
 			if !res.IsOK() {
 				return newCtx, res, true
 			}
@@ -109,7 +135,8 @@ func NewAnteHandler(am AccountKeeper, fck FeeKeeper) sdk.AnteHandler {
 			am.SetAccount(newCtx, signerAccs[i])
 		}
 
-		// cache the signer accounts in the context
+		// cache the signer accounts in the context, the fee payer is counted in the pending txs
+		// by runTx once the tx can no longer fail the check
 		newCtx = WithSigners(newCtx, signerAccs)
 
 		// TODO: tx tags (?)
@@ -156,8 +183,8 @@ func validateAccNumAndSequence(ctx sdk.Context, accs []Account, sigs []StdSignat
 // verify the signature and increment the sequence.
 // if the account doesn't have a pubkey, set it.
 func processSig(ctx sdk.Context,
-	acc Account, sig StdSignature, signBytes []byte, simulate bool) (updatedAcc Account, res sdk.Result) {
-	pubKey, res := processPubKey(acc, sig, simulate)
+	acc Account, sig StdSignature, signBytes []byte, simulate bool, params Params) (updatedAcc Account, res sdk.Result) {
+	pubKey, res := processPubKey(ctx, acc, sig, simulate)
 	if !res.IsOK() {
 		return nil, res
 	}
@@ -166,7 +193,11 @@ func processSig(ctx sdk.Context,
 		return nil, sdk.ErrInternal("setting PubKey on signer's account").Result()
 	}
 
-	consumeSignatureVerificationGas(ctx.GasMeter(), pubKey)
+	if sigVerifyParamsEnabled(ctx) {
+		consumeSignatureVerificationGas(ctx.GasMeter(), sig.Signature, pubKey, params)
+	} else {
+		consumeLegacySignatureVerificationGas(ctx.GasMeter(), pubKey)
+	}
 	if !simulate && !pubKey.VerifyBytes(signBytes, sig.Signature) {
 		return nil, sdk.ErrUnauthorized("signature verification failed").Result()
 	}
@@ -188,7 +219,7 @@ func init() {
 	copy(dummySecp256k1Pubkey[:], bz)
 }
 
-func processPubKey(acc Account, sig StdSignature, simulate bool) (crypto.PubKey, sdk.Result) {
+func processPubKey(ctx sdk.Context, acc Account, sig StdSignature, simulate bool) (crypto.PubKey, sdk.Result) {
 	// If pubkey is not known for account,
 	// set it from the StdSignature.
 	pubKey := acc.GetPubKey()
@@ -211,11 +242,68 @@ func processPubKey(acc Account, sig StdSignature, simulate bool) (crypto.PubKey,
 			return nil, sdk.ErrInvalidPubKey(
 				fmt.Sprintf("PubKey does not match Signer address %v", acc.GetAddress())).Result()
 		}
+		if sigVerifyParamsEnabled(ctx) && !isSupportedPubKey(pubKey) {
+			return nil, sdk.ErrInvalidPubKey(
+				fmt.Sprintf("Unsupported PubKey type %T, expected ed25519, secp256k1, secp256r1 or multisig", pubKey)).Result()
+		}
 	}
 	return pubKey, sdk.Result{}
 }
 
-func consumeSignatureVerificationGas(meter sdk.GasMeter, pubkey crypto.PubKey) {
+// isSupportedPubKey returns whether the account pubkey, or all the pubkeys of the multisig, are
+// of a type consumeSignatureVerificationGas prices
+func isSupportedPubKey(pubkey crypto.PubKey) bool {
+	switch pubkey := pubkey.(type) {
+	case ed25519.PubKeyEd25519, secp256k1.PubKeySecp256k1, secp256r1.PubKeySecp256r1:
+		return true
+	case multisig.PubKeyMultisigThreshold:
+		for _, pk := range pubkey.PubKeys {
+			if !isSupportedPubKey(pk) {
+				return false
+			}
+		}
+		return true
+	default:
+		return false
+	}
+}
+
+func consumeSignatureVerificationGas(meter sdk.GasMeter, sig []byte, pubkey crypto.PubKey, params Params) {
+	switch pubkey := pubkey.(type) {
+	case ed25519.PubKeyEd25519:
+		meter.ConsumeGas(params.SigVerifyCostEd25519, "ante verify: ed25519")
+	case secp256k1.PubKeySecp256k1:
+		meter.ConsumeGas(params.SigVerifyCostSecp256k1, "ante verify: secp256k1")
+	case secp256r1.PubKeySecp256r1:
+		meter.ConsumeGas(params.SigVerifyCostSecp256r1, "ante verify: secp256r1")
+	case multisig.PubKeyMultisigThreshold:
+		var multisignature multisig.Multisignature
+		if err := msgCdc.UnmarshalBinaryBare(sig, &multisignature); err != nil || multisignature.BitArray == nil {
+			// no valid multisignature when simulating, price the signatures of all the keys
+			for _, pk := range pubkey.PubKeys {
+				consumeSignatureVerificationGas(meter, nil, pk, params)
+			}
+			return
+		}
+		sigIndex := 0
+		for i, pk := range pubkey.PubKeys {
+			if !multisignature.BitArray.GetIndex(i) {
+				continue
+			}
+			var subSig []byte
+			if sigIndex < len(multisignature.Sigs) {
+				subSig = multisignature.Sigs[sigIndex]
+			}
+			consumeSignatureVerificationGas(meter, subSig, pk, params)
+			sigIndex++
+		}
+	default:
+		panic("Unrecognized signature type")
+	}
+}
+
+// consumeLegacySignatureVerificationGas prices the signatures of the blocks before SigVerifyProtocolVersion
+func consumeLegacySignatureVerificationGas(meter sdk.GasMeter, pubkey crypto.PubKey) {
 	switch pubkey.(type) {
 	case ed25519.PubKeyEd25519:
 		meter.ConsumeGas(ed25519VerifyCost, "ante verify: ed25519")
@@ -280,6 +368,32 @@ func ensureSufficientMempoolFees(ctx sdk.Context, stdTx StdTx) sdk.Result {
 	return sdk.Result{}
 }
 
+// ensurePendingTxsLimit rejects the tx if its fee payer has reached the limit of txs
+// pending in the local mempool, so that a single sender can't fill it.
+func ensurePendingTxsLimit(ctx sdk.Context, stdTx StdTx) sdk.Result {
+	pendingTxs := ctx.PendingTxs()
+	payer := stdTx.GetSigners()[0]
+	if pendingTxs.Full(payer) {
+		return sdk.ErrTooManyPendingTxs(fmt.Sprintf(
+			"%s has %d pending txs, limit is %d", payer, pendingTxs.Count(payer), pendingTxs.Limit())).Result()
+	}
+	return sdk.Result{}
+}
+
+// GetTxPriority returns the priority of the tx in the mempool: its gas price in iris-atto
+// per unit of gas, capped to the int64 range. Fees in other denoms don't count.
+func GetTxPriority(stdTx StdTx) int64 {
+	if stdTx.Fee.Gas == 0 {
+		return 0
+	}
+	gas := sdk.NewIntFromBigInt(new(big.Int).SetUint64(stdTx.Fee.Gas))
+	price := stdTx.Fee.Amount.AmountOf(sdk.IrisAtto).Div(gas)
+	if !price.IsInt64() {
+		return math.MaxInt64
+	}
+	return price.Int64()
+}
+
 func setGasMeter(simulate bool, ctx sdk.Context, gasLimit uint64) sdk.Context {
 	// In various cases such as simulation and during the genesis block, we do not
 	// meter any gas utilization.
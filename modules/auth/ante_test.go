@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/NPC-Chain/npcchub/codec"
+	"github.com/NPC-Chain/npcchub/crypto/secp256r1"
 	"github.com/NPC-Chain/npcchub/modules/params"
 	sdk "github.com/NPC-Chain/npcchub/types"
 	"github.com/stretchr/testify/require"
@@ -408,6 +409,57 @@ func TestAnteHandlerMultiSigner(t *testing.T) {
 	checkValidTx(t, anteHandler, ctx, tx, false)
 }
 
+// Test a tx of a multisig account, as broadcast by iriscli tx multisig.
+func TestAnteHandlerMultisigAccount(t *testing.T) {
+	// setup
+	ms, capKey, capKey2, paramsKey, tParamsKey := setupMultiStore()
+	cdc := codec.New()
+	RegisterBaseAccount(cdc)
+	mapper := NewAccountKeeper(cdc, capKey, ProtoBaseAccount)
+	paramsKeeper := params.NewKeeper(cdc, paramsKey, tParamsKey)
+	feeCollector := NewFeeKeeper(cdc, capKey2, paramsKeeper.Subspace(DefaultParamSpace))
+	anteHandler := NewAnteHandler(mapper, feeCollector)
+	header := abci.Header{ChainID: "mychainid", Version: abci.Version{App: SigVerifyProtocolVersion}}
+	ctx := sdk.NewContext(ms, header, false, log.NewNopLogger())
+	ctx = ctx.WithBlockHeight(1)
+
+	// a 2 of 3 multisig account
+	privs := []crypto.PrivKey{secp256k1.GenPrivKey(), secp256k1.GenPrivKey(), secp256k1.GenPrivKey()}
+	pubs := []crypto.PubKey{privs[0].PubKey(), privs[1].PubKey(), privs[2].PubKey()}
+	multisigKey := multisig.NewPubKeyMultisigThreshold(2, pubs)
+	addr := sdk.AccAddress(multisigKey.Address())
+	acc := mapper.NewAccountWithAddress(ctx, addr)
+	acc.SetCoins(newCoins())
+	mapper.SetAccount(ctx, acc)
+
+	msgs := []sdk.Msg{newTestMsg(addr)}
+	fee := newStdFee()
+	signBytes := StdSignBytes(ctx.ChainID(), 0, 0, fee, msgs, "")
+	multisigTx := func(signers ...crypto.PrivKey) sdk.Tx {
+		multisignature := multisig.NewMultisig(len(pubs))
+		for _, priv := range signers {
+			sig, err := priv.Sign(signBytes)
+			require.NoError(t, err)
+			require.NoError(t, multisignature.AddSignatureFromPubKey(sig, priv.PubKey(), pubs))
+		}
+		sig := StdSignature{PubKey: multisigKey, Signature: msgCdc.MustMarshalBinaryBare(multisignature)}
+		return NewStdTx(msgs, fee, []StdSignature{sig}, "")
+	}
+
+	// the threshold is not met
+	checkInvalidTx(t, anteHandler, ctx, multisigTx(privs[0]), false, sdk.CodeUnauthorized)
+
+	// the multisig accounts are not supported before SigVerifyProtocolVersion
+	legacyCtx := ctx.WithBlockHeader(abci.Header{ChainID: "mychainid", Version: abci.Version{App: SigVerifyProtocolVersion - 1}})
+	require.Panics(t, func() { anteHandler(legacyCtx, multisigTx(privs[0], privs[2]), false) })
+
+	// the verification of the signatures of the multisig is charged per signature
+	newCtx, result, abort := anteHandler(ctx, multisigTx(privs[0], privs[2]), false)
+	require.False(t, abort)
+	require.True(t, result.IsOK())
+	require.True(t, newCtx.GasMeter().GasConsumed() >= 2*DefaultParams().SigVerifyCostSecp256k1)
+}
+
 func TestAnteHandlerBadSignBytes(t *testing.T) {
 	// setup
 	ms, capKey, capKey2, paramsKey, tParamsKey := setupMultiStore()
@@ -574,15 +626,57 @@ func TestProcessPubKey(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			_, err := processPubKey(tt.args.acc, tt.args.sig, tt.args.simulate)
+			_, err := processPubKey(ctx, tt.args.acc, tt.args.sig, tt.args.simulate)
 			require.Equal(t, tt.wantErr, !err.IsOK())
 		})
 	}
+
+	// the unsupported keys are rejected from SigVerifyProtocolVersion, and fail the signature pricing before
+	pubKey := dummyPubKey{secp256k1.GenPrivKey().PubKey().(secp256k1.PubKeySecp256k1)}
+	acc3 := mapper.NewAccountWithAddress(ctx, sdk.AccAddress(pubKey.Address()))
+	_, err := processPubKey(ctx, acc3, StdSignature{PubKey: pubKey}, false)
+	require.True(t, err.IsOK())
+	upgradedCtx := ctx.WithBlockHeader(abci.Header{Version: abci.Version{App: SigVerifyProtocolVersion}})
+	_, err = processPubKey(upgradedCtx, acc3, StdSignature{PubKey: pubKey}, false)
+	require.Equal(t, sdk.CodeInvalidPubKey, err.Code)
+}
+
+// dummyPubKey is a key of a type the signatures aren't priced for
+type dummyPubKey struct{ secp256k1.PubKeySecp256k1 }
+
+func TestConsumeLegacySignatureVerificationGas(t *testing.T) {
+	meter := sdk.NewInfiniteGasMeter()
+	consumeLegacySignatureVerificationGas(meter, ed25519.GenPrivKey().PubKey())
+	consumeLegacySignatureVerificationGas(meter, secp256k1.GenPrivKey().PubKey())
+	require.Equal(t, uint64(ed25519VerifyCost+secp256k1VerifyCost), meter.GasConsumed())
+
+	// the secp256r1 and multisig keys are only priced from SigVerifyProtocolVersion
+	pubKey := secp256r1.GenPrivKey().PubKey()
+	require.Panics(t, func() { consumeLegacySignatureVerificationGas(meter, pubKey) })
+	require.Panics(t, func() {
+		consumeLegacySignatureVerificationGas(meter, multisig.NewPubKeyMultisigThreshold(1, []crypto.PubKey{pubKey}))
+	})
 }
 
 func TestConsumeSignatureVerificationGas(t *testing.T) {
+	params := DefaultParams()
+	msg := []byte("msg")
+
+	// a 2 of 3 multisig of a secp256k1, an ed25519 and a secp256r1 key signed by the first two
+	privs := []crypto.PrivKey{secp256k1.GenPrivKey(), ed25519.GenPrivKey(), secp256r1.GenPrivKey()}
+	pubs := []crypto.PubKey{privs[0].PubKey(), privs[1].PubKey(), privs[2].PubKey()}
+	multisigKey := multisig.NewPubKeyMultisigThreshold(2, pubs)
+	multisignature := multisig.NewMultisig(len(pubs))
+	for _, priv := range privs[:2] {
+		sig, err := priv.Sign(msg)
+		require.NoError(t, err)
+		require.NoError(t, multisignature.AddSignatureFromPubKey(sig, priv.PubKey(), pubs))
+	}
+	multisig := msgCdc.MustMarshalBinaryBare(multisignature)
+
 	type args struct {
 		meter  sdk.GasMeter
+		sig    []byte
 		pubkey crypto.PubKey
 	}
 	tests := []struct {
@@ -591,16 +685,19 @@ func TestConsumeSignatureVerificationGas(t *testing.T) {
 		gasConsumed uint64
 		wantPanic   bool
 	}{
-		{"PubKeyEd25519", args{sdk.NewInfiniteGasMeter(), ed25519.GenPrivKey().PubKey()}, ed25519VerifyCost, false},
-		{"PubKeySecp256k1", args{sdk.NewInfiniteGasMeter(), secp256k1.GenPrivKey().PubKey()}, secp256k1VerifyCost, false},
-		{"unknown key", args{sdk.NewInfiniteGasMeter(), nil}, 0, true},
+		{"PubKeyEd25519", args{sdk.NewInfiniteGasMeter(), nil, ed25519.GenPrivKey().PubKey()}, params.SigVerifyCostEd25519, false},
+		{"PubKeySecp256k1", args{sdk.NewInfiniteGasMeter(), nil, secp256k1.GenPrivKey().PubKey()}, params.SigVerifyCostSecp256k1, false},
+		{"PubKeySecp256r1", args{sdk.NewInfiniteGasMeter(), nil, secp256r1.GenPrivKey().PubKey()}, params.SigVerifyCostSecp256r1, false},
+		{"Multisig", args{sdk.NewInfiniteGasMeter(), multisig, multisigKey}, params.SigVerifyCostSecp256k1 + params.SigVerifyCostEd25519, false},
+		{"Multisig, simulate", args{sdk.NewInfiniteGasMeter(), nil, multisigKey}, params.SigVerifyCostSecp256k1 + params.SigVerifyCostEd25519 + params.SigVerifyCostSecp256r1, false},
+		{"unknown key", args{sdk.NewInfiniteGasMeter(), nil, nil}, 0, true},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			if tt.wantPanic {
-				require.Panics(t, func() { consumeSignatureVerificationGas(tt.args.meter, tt.args.pubkey) })
+				require.Panics(t, func() { consumeSignatureVerificationGas(tt.args.meter, tt.args.sig, tt.args.pubkey, params) })
 			} else {
-				consumeSignatureVerificationGas(tt.args.meter, tt.args.pubkey)
+				consumeSignatureVerificationGas(tt.args.meter, tt.args.sig, tt.args.pubkey, params)
 				require.Equal(t, tt.args.meter.GasConsumed(), tt.gasConsumed)
 			}
 		})
@@ -696,3 +793,48 @@ func TestAnteHandlerSigLimitExceeded(t *testing.T) {
 	tx = newTestTx(ctx, msgs, privs, accnums, seqs, fee)
 	checkInvalidTx(t, anteHandler, ctx, tx, false, sdk.CodeTooManySignatures)
 }
+
+func TestAnteHandlerPendingTxs(t *testing.T) {
+	// setup
+	ms, capKey, capKey2, paramsKey, tParamsKey := setupMultiStore()
+	cdc := codec.New()
+	RegisterBaseAccount(cdc)
+	mapper := NewAccountKeeper(cdc, capKey, ProtoBaseAccount)
+	paramsKeeper := params.NewKeeper(cdc, paramsKey, tParamsKey)
+	feeCollector := NewFeeKeeper(cdc, capKey2, paramsKeeper.Subspace(DefaultParamSpace))
+	anteHandler := NewAnteHandler(mapper, feeCollector)
+	ctx := sdk.NewContext(ms, abci.Header{ChainID: "mychainid"}, true, log.NewNopLogger())
+	ctx = ctx.WithBlockHeight(1).WithPendingTxs(sdk.NewPendingTxCounter(2))
+
+	// keys and addresses
+	priv1, addr1 := privAndAddr()
+
+	// set the accounts
+	acc1 := mapper.NewAccountWithAddress(ctx, addr1)
+	acc1.SetCoins(sdk.Coins{sdk.NewInt64Coin(sdk.IrisAtto, 10000000)})
+	mapper.SetAccount(ctx, acc1)
+
+	msgs := []sdk.Msg{newTestMsg(addr1)}
+	privs, accnums := []crypto.PrivKey{priv1}, []uint64{0}
+	fee := NewStdFee(5000, sdk.NewInt64Coin(sdk.IrisAtto, 50000))
+
+	// the priority is the gas price
+	tx := newTestTx(ctx, msgs, privs, accnums, []uint64{0}, fee)
+	newCtx, result, abort := anteHandler(ctx, tx, false)
+	require.False(t, abort)
+	require.True(t, result.IsOK())
+	require.Equal(t, int64(10), newCtx.Priority())
+
+	// the tx is counted by runTx after the ante handler, which may still reject it
+	require.Equal(t, uint64(0), ctx.PendingTxs().Count(addr1))
+	ctx.PendingTxs().Incr(addr1)
+
+	tx = newTestTx(ctx, msgs, privs, accnums, []uint64{1}, fee)
+	checkValidTx(t, anteHandler, ctx, tx, false)
+	ctx.PendingTxs().Incr(addr1)
+
+	// the sender reached the limit of pending txs
+	tx = newTestTx(ctx, msgs, privs, accnums, []uint64{2}, fee)
+	checkInvalidTx(t, anteHandler, ctx, tx, false, sdk.CodeTooManyPendingTxs)
+	require.Equal(t, uint64(2), ctx.PendingTxs().Count(addr1))
+}
@@ -0,0 +1,152 @@
+package auth
+
+import (
+	"math/big"
+
+	sdk "github.com/NPC-Chain/npcchub/types"
+)
+
+// BaseFeeBurnPool is the pool the base fees are burned from
+const BaseFeeBurnPool = "baseFee"
+
+// BaseFeeProtocolVersion is the first protocol version adjusting the base gas price and burning
+// the base fees. The blocks of the earlier versions keep the gas price threshold as the minimum
+// gas price and all the fees for the distribution, so that they replay with the same results.
+const BaseFeeProtocolVersion = 3
+
+var (
+	baseGasPriceKey  = []byte("baseGasPrice")
+	blockBaseFeesKey = []byte("blockBaseFees")
+)
+
+// BankKeeper burns the base fees
+type BankKeeper interface {
+	BurnCoinsFromPool(ctx sdk.Context, pool string, amt sdk.Coins) (sdk.Tags, sdk.Error)
+}
+
+// GetBaseGasPrice returns the gas price in iris-atto a tx must pay at least, which is burned.
+// Before the first adjustment it is the gas price threshold.
+func (fk FeeKeeper) GetBaseGasPrice(ctx sdk.Context) sdk.Int {
+	store := ctx.KVStore(fk.storeKey)
+	bz := store.Get(baseGasPriceKey)
+	if bz == nil {
+		return fk.GetParamSet(ctx).GasPriceThreshold
+	}
+
+	var price sdk.Int
+	fk.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &price)
+	return price
+}
+
+// minimumGasPrice returns the gas price a tx must pay at least in the block of the context,
+// the base gas price from BaseFeeProtocolVersion and the gas price threshold before
+func (fk FeeKeeper) minimumGasPrice(ctx sdk.Context) sdk.Int {
+	if !baseFeeEnabled(ctx) {
+		var threshold sdk.Int
+		fk.paramSpace.Get(ctx, gasPriceThresholdKey, &threshold)
+		return threshold
+	}
+	return fk.GetBaseGasPrice(ctx)
+}
+
+func baseFeeEnabled(ctx sdk.Context) bool {
+	return ctx.BlockHeader().Version.App >= BaseFeeProtocolVersion
+}
+
+func (fk FeeKeeper) SetBaseGasPrice(ctx sdk.Context, price sdk.Int) {
+	bz := fk.cdc.MustMarshalBinaryLengthPrefixed(price)
+	store := ctx.KVStore(fk.storeKey)
+	store.Set(baseGasPriceKey, bz)
+}
+
+// GetBlockBaseFees returns the base fees of the txs of the block, part of the collected fees until burned
+func (fk FeeKeeper) GetBlockBaseFees(ctx sdk.Context) sdk.Coins {
+	store := ctx.KVStore(fk.storeKey)
+	bz := store.Get(blockBaseFeesKey)
+	if bz == nil {
+		return sdk.Coins{}
+	}
+
+	fees := &(sdk.Coins{})
+	fk.cdc.MustUnmarshalBinaryLengthPrefixed(bz, fees)
+	return *fees
+}
+
+func (fk FeeKeeper) setBlockBaseFees(ctx sdk.Context, coins sdk.Coins) {
+	bz := fk.cdc.MustMarshalBinaryLengthPrefixed(coins)
+	store := ctx.KVStore(fk.storeKey)
+	store.Set(blockBaseFeesKey, bz)
+}
+
+func (fk FeeKeeper) addBlockBaseFees(ctx sdk.Context, coins sdk.Coins) {
+	fk.setBlockBaseFees(ctx, fk.GetBlockBaseFees(ctx).Add(coins))
+}
+
+// addBaseFee adds the base fee of the gas used out of the fee to the base fees of the block
+func (fk FeeKeeper) addBaseFee(ctx sdk.Context, gasUsed uint64, fee sdk.Coin) {
+	if !baseFeeEnabled(ctx) {
+		return
+	}
+	fk.addBlockBaseFees(ctx, baseFee(fk.GetBaseGasPrice(ctx), gasUsed, fee))
+}
+
+// AdjustBaseGasPrice moves the base gas price towards the price at which the blocks use the
+// target gas: up if the block used more, down if it used less, by at most 1/denominator.
+// The price stays in [gas price threshold, 10^18iris-atto].
+func (fk FeeKeeper) AdjustBaseGasPrice(ctx sdk.Context, blockGasUsed uint64) sdk.Int {
+	params := fk.GetParamSet(ctx)
+	price := fk.GetBaseGasPrice(ctx)
+
+	target := sdk.NewIntFromBigInt(new(big.Int).SetUint64(params.TargetBlockGas))
+	used := sdk.NewIntFromBigInt(new(big.Int).SetUint64(blockGasUsed))
+	denominator := sdk.NewIntFromBigInt(new(big.Int).SetUint64(params.BaseGasPriceChangeDenominator))
+
+	switch {
+	case used.GT(target):
+		delta := price.Mul(used.Sub(target)).Div(target).Div(denominator)
+		if delta.IsZero() {
+			delta = sdk.OneInt()
+		}
+		price = price.Add(delta)
+	case used.LT(target):
+		delta := price.Mul(target.Sub(used)).Div(target).Div(denominator)
+		price = price.Sub(delta)
+	}
+
+	if price.LT(params.GasPriceThreshold) {
+		price = params.GasPriceThreshold
+	}
+	if price.GT(MaximumGasPrice) {
+		price = MaximumGasPrice
+	}
+	fk.SetBaseGasPrice(ctx, price)
+	return price
+}
+
+// EndBlocker burns the base fees of the block, leaving the tips in the collected fees for
+// the distribution, and adjusts the base gas price to the gas used by the block.
+// It does nothing before BaseFeeProtocolVersion.
+func EndBlocker(ctx sdk.Context, fk FeeKeeper, bk BankKeeper) sdk.Tags {
+	tags := sdk.EmptyTags()
+	if !baseFeeEnabled(ctx) {
+		return tags
+	}
+
+	baseFees := fk.GetBlockBaseFees(ctx)
+	if !baseFees.IsZero() {
+		fk.setCollectedFees(ctx, fk.GetCollectedFees(ctx).Sub(baseFees))
+		fk.setBlockBaseFees(ctx, sdk.Coins{})
+		burnTags, err := bk.BurnCoinsFromPool(ctx, BaseFeeBurnPool, baseFees)
+		if err != nil {
+			panic(err)
+		}
+		tags = tags.AppendTags(burnTags)
+	}
+
+	var blockGasUsed uint64
+	if ctx.BlockGasMeter() != nil {
+		blockGasUsed = ctx.BlockGasMeter().GasConsumed()
+	}
+	fk.AdjustBaseGasPrice(ctx, blockGasUsed)
+	return tags
+}
@@ -0,0 +1,124 @@
+package auth
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/libs/log"
+
+	codec "github.com/NPC-Chain/npcchub/codec"
+	"github.com/NPC-Chain/npcchub/modules/params"
+	sdk "github.com/NPC-Chain/npcchub/types"
+)
+
+type burnKeeper struct {
+	burned sdk.Coins
+}
+
+func (bk *burnKeeper) BurnCoinsFromPool(ctx sdk.Context, pool string, amt sdk.Coins) (sdk.Tags, sdk.Error) {
+	bk.burned = bk.burned.Add(amt)
+	return sdk.EmptyTags(), nil
+}
+
+func TestAdjustBaseGasPrice(t *testing.T) {
+	ms, _, capKey2, paramsKey, tParamsKey := setupMultiStore()
+	cdc := codec.New()
+
+	ctx := sdk.NewContext(ms, abci.Header{}, false, log.NewNopLogger())
+	paramsKeeper := params.NewKeeper(cdc, paramsKey, tParamsKey)
+	fck := NewFeeKeeper(cdc, capKey2, paramsKeeper.Subspace(DefaultParamSpace))
+	feeParams := DefaultParams()
+	fck.SetParamSet(ctx, feeParams)
+
+	// the threshold until the first adjustment
+	threshold := feeParams.GasPriceThreshold
+	require.Equal(t, threshold, fck.GetBaseGasPrice(ctx))
+
+	// full blocks raise the price by 1/8
+	target := feeParams.TargetBlockGas
+	price := fck.AdjustBaseGasPrice(ctx, 2*target)
+	require.Equal(t, threshold.Add(threshold.DivRaw(8)), price)
+	require.Equal(t, price, fck.GetBaseGasPrice(ctx))
+
+	// blocks at the target keep it
+	require.Equal(t, price, fck.AdjustBaseGasPrice(ctx, target))
+
+	// half full blocks lower it by 1/16, empty blocks by 1/8 down to the threshold
+	require.Equal(t, price.Sub(price.DivRaw(16)), fck.AdjustBaseGasPrice(ctx, target/2))
+	for i := 0; i < 10; i++ {
+		price = fck.AdjustBaseGasPrice(ctx, 0)
+	}
+	require.Equal(t, threshold, price)
+}
+
+func TestBaseFeeBurn(t *testing.T) {
+	ms, _, capKey2, paramsKey, tParamsKey := setupMultiStore()
+	cdc := codec.New()
+
+	header := abci.Header{Version: abci.Version{App: BaseFeeProtocolVersion}}
+	ctx := sdk.NewContext(ms, header, false, log.NewNopLogger())
+	paramsKeeper := params.NewKeeper(cdc, paramsKey, tParamsKey)
+	fck := NewFeeKeeper(cdc, capKey2, paramsKeeper.Subspace(DefaultParamSpace))
+	fck.SetParamSet(ctx, DefaultParams())
+	baseGasPrice := fck.GetBaseGasPrice(ctx)
+
+	// a fee at twice the base gas price, half of it is burned
+	fee := sdk.NewCoin(sdk.IrisAtto, baseGasPrice.MulRaw(2*1000))
+	fck.AddCollectedFees(ctx, sdk.Coins{fee})
+	fck.addBlockBaseFees(ctx, baseFee(baseGasPrice, 1000, fee))
+
+	bk := &burnKeeper{}
+	EndBlocker(ctx.WithBlockGasMeter(sdk.NewInfiniteGasMeter()), fck, bk)
+
+	tip := sdk.Coins{sdk.NewCoin(sdk.IrisAtto, baseGasPrice.MulRaw(1000))}
+	require.True(t, bk.burned.IsEqual(tip))
+	require.True(t, fck.GetCollectedFees(ctx).IsEqual(tip))
+	require.True(t, fck.GetBlockBaseFees(ctx).IsZero())
+
+	// the base fee is capped to the fee
+	require.True(t, baseFee(baseGasPrice, 1000, sdk.NewCoin(sdk.IrisAtto, sdk.OneInt())).IsEqual(sdk.Coins{sdk.NewCoin(sdk.IrisAtto, sdk.OneInt())}))
+}
+
+// TestBaseFeeBeforeUpgrade checks the blocks before BaseFeeProtocolVersion keep the threshold
+// and the fees, reading the params of a store without the params added by the upgrade
+func TestBaseFeeBeforeUpgrade(t *testing.T) {
+	ms, _, capKey2, paramsKey, tParamsKey := setupMultiStore()
+	cdc := codec.New()
+
+	ctx := sdk.NewContext(ms, abci.Header{Version: abci.Version{App: BaseFeeProtocolVersion - 1}}, false, log.NewNopLogger())
+	paramsKeeper := params.NewKeeper(cdc, paramsKey, tParamsKey)
+	fck := NewFeeKeeper(cdc, capKey2, paramsKeeper.Subspace(DefaultParamSpace))
+	threshold := sdk.NewIntWithDecimal(2, 12)
+	fck.paramSpace.Set(ctx, gasPriceThresholdKey, threshold)
+	fck.paramSpace.Set(ctx, TxSizeLimitKey, uint64(1000))
+
+	feeParams := fck.GetParamSet(ctx)
+	require.Equal(t, threshold, feeParams.GasPriceThreshold)
+	require.Equal(t, DefaultParams().TargetBlockGas, feeParams.TargetBlockGas)
+	require.Equal(t, DefaultParams().BaseGasPriceChangeDenominator, feeParams.BaseGasPriceChangeDenominator)
+	require.Equal(t, DefaultParams().SigVerifyCostEd25519, feeParams.SigVerifyCostEd25519)
+	require.Equal(t, DefaultParams().SigVerifyCostSecp256k1, feeParams.SigVerifyCostSecp256k1)
+	require.Equal(t, DefaultParams().SigVerifyCostSecp256r1, feeParams.SigVerifyCostSecp256r1)
+
+	fck.SetBaseGasPrice(ctx, threshold.MulRaw(2))
+	require.Equal(t, threshold, fck.minimumGasPrice(ctx))
+
+	fee := sdk.NewCoin(sdk.IrisAtto, threshold.MulRaw(1000))
+	fck.AddCollectedFees(ctx, sdk.Coins{fee})
+	fck.addBaseFee(ctx, 1000, fee)
+	require.True(t, fck.GetBlockBaseFees(ctx).IsZero())
+
+	bk := &burnKeeper{}
+	EndBlocker(ctx.WithBlockGasMeter(sdk.NewInfiniteGasMeter()), fck, bk)
+	require.True(t, bk.burned.IsZero())
+	require.True(t, fck.GetCollectedFees(ctx).IsEqual(sdk.Coins{fee}))
+	require.Equal(t, threshold.MulRaw(2), fck.GetBaseGasPrice(ctx))
+
+	// the genesis files exported before the upgrade have no target block gas
+	genesis := DefaultGenesisState()
+	genesis.Params.TargetBlockGas = 0
+	genesis.Params.SigVerifyCostSecp256r1 = 0
+	require.NoError(t, ValidateGenesis(genesis))
+}
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"math"
+	"math/big"
 
 	"github.com/NPC-Chain/npcchub/types"
 	sdk "github.com/NPC-Chain/npcchub/types"
@@ -42,11 +43,10 @@ func NewFeePreprocessHandler(fk FeeKeeper) types.FeePreprocessHandler {
 		}
 
 		fa := fk.GetFeeAuth(ctx)
-		feeParams := fk.GetParamSet(ctx)
 
 		totalNativeFee := fa.getNativeFeeToken(ctx, stdTx.Fee.Amount)
 
-		return fa.feePreprocess(ctx, feeParams, sdk.Coins{totalNativeFee}, stdTx.Fee.Gas)
+		return fa.feePreprocess(ctx, fk.minimumGasPrice(ctx), sdk.Coins{totalNativeFee}, stdTx.Fee.Gas)
 	}
 }
 
@@ -75,6 +75,7 @@ func NewFeeRefundHandler(am AccountKeeper, fk FeeKeeper) types.FeeRefundHandler
 		//If all gas has been consumed, then there is no necessary to run fee refund process
 		if txResult.GasWanted <= txResult.GasUsed {
 			actualCostFee = totalNativeFee
+			fk.addBaseFee(ctx, txResult.GasWanted, actualCostFee)
 			return actualCostFee, nil
 		}
 
@@ -92,10 +93,24 @@ func NewFeeRefundHandler(am AccountKeeper, fk FeeKeeper) types.FeeRefundHandler
 		fk.RefundCollectedFees(ctx, sdk.Coins{refundCoin})
 
 		actualCostFee = sdk.NewCoin(totalNativeFee.Denom, totalNativeFee.Amount.Sub(refundCoin.Amount))
+		fk.addBaseFee(ctx, txResult.GasUsed, actualCostFee)
 		return actualCostFee, nil
 	}
 }
 
+// baseFee returns the part of the fee paid for the gas used at the base gas price, to be burned.
+// The rest of the fee is the tip going to the distribution.
+func baseFee(baseGasPrice sdk.Int, gasUsed uint64, fee sdk.Coin) sdk.Coins {
+	amount := baseGasPrice.Mul(sdk.NewIntFromBigInt(new(big.Int).SetUint64(gasUsed)))
+	if amount.GT(fee.Amount) {
+		amount = fee.Amount
+	}
+	if !amount.IsPositive() {
+		return sdk.Coins{}
+	}
+	return sdk.Coins{sdk.NewCoin(fee.Denom, amount)}
+}
+
 func (fa FeeAuth) getNativeFeeToken(ctx sdk.Context, coins sdk.Coins) sdk.Coin {
 	if coins == nil {
 		return sdk.NewCoin(sdk.IrisAtto, sdk.ZeroInt())
@@ -103,12 +118,11 @@ func (fa FeeAuth) getNativeFeeToken(ctx sdk.Context, coins sdk.Coins) sdk.Coin {
 	return sdk.NewCoin(sdk.IrisAtto, coins.AmountOf(sdk.IrisAtto))
 }
 
-func (fa FeeAuth) feePreprocess(ctx sdk.Context, params Params, coins sdk.Coins, gasLimit uint64) sdk.Error {
+func (fa FeeAuth) feePreprocess(ctx sdk.Context, threshold sdk.Int, coins sdk.Coins, gasLimit uint64) sdk.Error {
 	if gasLimit == 0 || int64(gasLimit) < 0 {
 		return sdk.ErrInvalidGas(fmt.Sprintf("gaslimit %d should be positive and no more than %d", gasLimit, math.MaxInt64))
 	}
 	nativeFeeToken := fa.NativeFeeDenom
-	threshold := params.GasPriceThreshold
 
 	if len(coins) < 1 || coins[0].Denom != nativeFeeToken {
 		return sdk.ErrInvalidTxFee(fmt.Sprintf("no native fee token, expected native token is %s", nativeFeeToken))
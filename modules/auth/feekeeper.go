@@ -98,3 +98,13 @@ func (fk FeeKeeper) GetParamSet(ctx sdk.Context) Params {
 func (fk FeeKeeper) SetParamSet(ctx sdk.Context, feeParams Params) {
 	fk.paramSpace.SetParamSet(ctx, &feeParams)
 }
+
+// GetSigVerifyCosts returns the params with the signature verification costs, the
+// default costs for those not set in the store yet
+func (fk FeeKeeper) GetSigVerifyCosts(ctx sdk.Context) Params {
+	feeParams := DefaultParams()
+	fk.paramSpace.GetIfExists(ctx, SigVerifyCostEd25519Key, &feeParams.SigVerifyCostEd25519)
+	fk.paramSpace.GetIfExists(ctx, SigVerifyCostSecp256k1Key, &feeParams.SigVerifyCostSecp256k1)
+	fk.paramSpace.GetIfExists(ctx, SigVerifyCostSecp256r1Key, &feeParams.SigVerifyCostSecp256r1)
+	return feeParams
+}
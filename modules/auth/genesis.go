@@ -1,6 +1,9 @@
 package auth
 
 import (
+	"fmt"
+
+	"github.com/NPC-Chain/npcchub/modules/params"
 	sdk "github.com/NPC-Chain/npcchub/types"
 )
 
@@ -9,14 +12,16 @@ type GenesisState struct {
 	CollectedFees sdk.Coins `json:"collected_fee"`
 	FeeAuth       FeeAuth   `json:"data"`
 	Params        Params    `json:"params"`
+	BaseGasPrice  sdk.Int   `json:"base_gas_price"` // the gas price threshold if not set
 }
 
 // Create a new genesis state
-func NewGenesisState(collectedFees sdk.Coins, feeAuth FeeAuth, params Params) GenesisState {
+func NewGenesisState(collectedFees sdk.Coins, feeAuth FeeAuth, params Params, baseGasPrice sdk.Int) GenesisState {
 	return GenesisState{
 		CollectedFees: collectedFees,
 		FeeAuth:       feeAuth,
 		Params:        params,
+		BaseGasPrice:  baseGasPrice,
 	}
 }
 
@@ -26,11 +31,13 @@ func DefaultGenesisState() GenesisState {
 		CollectedFees: nil,
 		FeeAuth:       InitialFeeAuth(),
 		Params:        DefaultParams(),
+		BaseGasPrice:  DefaultParams().GasPriceThreshold,
 	}
 }
 
 // Init store state from genesis data
 func InitGenesis(ctx sdk.Context, keeper FeeKeeper, accountKeeper AccountKeeper, data GenesisState) {
+	params.SetUpgradeDefaults(&data.Params)
 	if err := ValidateGenesis(data); err != nil {
 		panic(err)
 	}
@@ -40,6 +47,10 @@ func InitGenesis(ctx sdk.Context, keeper FeeKeeper, accountKeeper AccountKeeper,
 
 	keeper.SetFeeAuth(ctx, data.FeeAuth)
 	keeper.SetParamSet(ctx, data.Params)
+	if data.BaseGasPrice.IsNil() {
+		data.BaseGasPrice = data.Params.GasPriceThreshold
+	}
+	keeper.SetBaseGasPrice(ctx, data.BaseGasPrice)
 }
 
 // ExportGenesis returns a GenesisState for a given context and keeper
@@ -47,10 +58,12 @@ func ExportGenesis(ctx sdk.Context, keeper FeeKeeper) GenesisState {
 	collectedFees := keeper.GetCollectedFees(ctx)
 	feeAuth := keeper.GetFeeAuth(ctx)
 	params := keeper.GetParamSet(ctx)
-	return NewGenesisState(collectedFees, feeAuth, params)
+	baseGasPrice := keeper.GetBaseGasPrice(ctx)
+	return NewGenesisState(collectedFees, feeAuth, params, baseGasPrice)
 }
 
 func ValidateGenesis(data GenesisState) error {
+	params.SetUpgradeDefaults(&data.Params)
 	err := validateParams(data.Params)
 	if err != nil {
 		return err
@@ -59,5 +72,8 @@ func ValidateGenesis(data GenesisState) error {
 	if err != nil {
 		return err
 	}
+	if !data.BaseGasPrice.IsNil() && (data.BaseGasPrice.LT(data.Params.GasPriceThreshold) || data.BaseGasPrice.GT(MaximumGasPrice)) {
+		return fmt.Errorf("base gas price (%s) should be [%s, 10^18iris-atto]", data.BaseGasPrice, data.Params.GasPriceThreshold)
+	}
 	return nil
 }
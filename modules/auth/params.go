@@ -20,6 +20,12 @@ var (
 	MaximumGasPrice    = sdk.NewIntWithDecimal(1, 18) //1iris, 10^18iris-atto
 	MinimumTxSizeLimit = uint64(500)
 	MaximumTxSizeLimit = uint64(1500)
+
+	MinimumBaseGasPriceChangeDenominator = uint64(1)
+	MaximumBaseGasPriceChangeDenominator = uint64(100)
+
+	MinimumSigVerifyCost = uint64(1)
+	MaximumSigVerifyCost = uint64(10000)
 )
 
 //Parameter store key
@@ -27,6 +33,13 @@ var (
 	// params store for inflation params
 	gasPriceThresholdKey = []byte("gasPriceThreshold")
 	TxSizeLimitKey       = []byte("txSizeLimit")
+
+	TargetBlockGasKey                = []byte("targetBlockGas")
+	BaseGasPriceChangeDenominatorKey = []byte("baseGasPriceChangeDenominator")
+
+	SigVerifyCostEd25519Key   = []byte("sigVerifyCostEd25519")
+	SigVerifyCostSecp256k1Key = []byte("sigVerifyCostSecp256k1")
+	SigVerifyCostSecp256r1Key = []byte("sigVerifyCostSecp256r1")
 )
 
 // ParamTable for auth module
@@ -36,15 +49,26 @@ func ParamTypeTable() params.TypeTable {
 
 // auth parameters
 type Params struct {
-	GasPriceThreshold sdk.Int `json:"gas_price_threshold"` // gas price threshold
-	TxSizeLimit       uint64  `json:"tx_size"`             // tx size limit
+	GasPriceThreshold             sdk.Int `json:"gas_price_threshold"`               // gas price threshold, the floor of the base gas price
+	TxSizeLimit                   uint64  `json:"tx_size"`                           // tx size limit
+	TargetBlockGas                uint64  `json:"target_block_gas"`                  // block gas usage the base gas price is adjusted to
+	BaseGasPriceChangeDenominator uint64  `json:"base_gas_price_change_denominator"` // bounds the change of the base gas price per block to 1/denominator
+	SigVerifyCostEd25519          uint64  `json:"sig_verify_cost_ed25519"`           // gas consumed to verify an ed25519 signature
+	SigVerifyCostSecp256k1        uint64  `json:"sig_verify_cost_secp256k1"`         // gas consumed to verify a secp256k1 signature
+	SigVerifyCostSecp256r1        uint64  `json:"sig_verify_cost_secp256r1"`         // gas consumed to verify a secp256r1 signature
 }
 
 func (p Params) String() string {
 	return fmt.Sprintf(`Auth Params:
-  Gas Price Threshold:    %s
-  Tx Size Limit:          %d`,
-		p.GasPriceThreshold, p.TxSizeLimit)
+  Gas Price Threshold:                %s
+  Tx Size Limit:                      %d
+  Target Block Gas:                   %d
+  Base Gas Price Change Denominator:  %d
+  Sig Verify Cost Ed25519:            %d
+  Sig Verify Cost Secp256k1:          %d
+  Sig Verify Cost Secp256r1:          %d`,
+		p.GasPriceThreshold, p.TxSizeLimit, p.TargetBlockGas, p.BaseGasPriceChangeDenominator,
+		p.SigVerifyCostEd25519, p.SigVerifyCostSecp256k1, p.SigVerifyCostSecp256r1)
 }
 
 // Implements params.ParamStruct
@@ -56,6 +80,23 @@ func (p *Params) KeyValuePairs() params.KeyValuePairs {
 	return params.KeyValuePairs{
 		{gasPriceThresholdKey, &p.GasPriceThreshold},
 		{TxSizeLimitKey, &p.TxSizeLimit},
+		{TargetBlockGasKey, &p.TargetBlockGas},
+		{BaseGasPriceChangeDenominatorKey, &p.BaseGasPriceChangeDenominator},
+		{SigVerifyCostEd25519Key, &p.SigVerifyCostEd25519},
+		{SigVerifyCostSecp256k1Key, &p.SigVerifyCostSecp256k1},
+		{SigVerifyCostSecp256r1Key, &p.SigVerifyCostSecp256r1},
+	}
+}
+
+// Implements params.UpgradeParamSet
+func (p *Params) UpgradeParams() params.KeyValuePairs {
+	defaults := DefaultParams()
+	return params.KeyValuePairs{
+		{TargetBlockGasKey, &defaults.TargetBlockGas},
+		{BaseGasPriceChangeDenominatorKey, &defaults.BaseGasPriceChangeDenominator},
+		{SigVerifyCostEd25519Key, &defaults.SigVerifyCostEd25519},
+		{SigVerifyCostSecp256k1Key, &defaults.SigVerifyCostSecp256k1},
+		{SigVerifyCostSecp256r1Key, &defaults.SigVerifyCostSecp256r1},
 	}
 }
 
@@ -79,6 +120,33 @@ func (p *Params) Validate(key string, value string) (interface{}, sdk.Error) {
 			return nil, sdk.NewError(params.DefaultCodespace, params.CodeInvalidTxSizeLimit, fmt.Sprintf("Tx size limit (%s) should be [500, 1500]", value))
 		}
 		return txsize, nil
+	case string(TargetBlockGasKey):
+		targetGas, err := strconv.ParseUint(value, 10, 64)
+		if err != nil {
+			return nil, params.ErrInvalidString(value)
+		}
+		if err := validateTargetBlockGas(targetGas); err != nil {
+			return nil, err
+		}
+		return targetGas, nil
+	case string(BaseGasPriceChangeDenominatorKey):
+		denominator, err := strconv.ParseUint(value, 10, 64)
+		if err != nil {
+			return nil, params.ErrInvalidString(value)
+		}
+		if err := validateBaseGasPriceChangeDenominator(denominator); err != nil {
+			return nil, err
+		}
+		return denominator, nil
+	case string(SigVerifyCostEd25519Key), string(SigVerifyCostSecp256k1Key), string(SigVerifyCostSecp256r1Key):
+		cost, err := strconv.ParseUint(value, 10, 64)
+		if err != nil {
+			return nil, params.ErrInvalidString(value)
+		}
+		if err := validateSigVerifyCost(cost); err != nil {
+			return nil, err
+		}
+		return cost, nil
 	default:
 		return nil, sdk.NewError(params.DefaultCodespace, params.CodeInvalidKey, fmt.Sprintf("%s is not found", key))
 	}
@@ -92,6 +160,21 @@ func (p *Params) StringFromBytes(cdc *codec.Codec, key string, bytes []byte) (st
 	case string(TxSizeLimitKey):
 		err := cdc.UnmarshalJSON(bytes, &p.TxSizeLimit)
 		return strconv.FormatUint(uint64(p.TxSizeLimit), 10), err
+	case string(TargetBlockGasKey):
+		err := cdc.UnmarshalJSON(bytes, &p.TargetBlockGas)
+		return strconv.FormatUint(p.TargetBlockGas, 10), err
+	case string(BaseGasPriceChangeDenominatorKey):
+		err := cdc.UnmarshalJSON(bytes, &p.BaseGasPriceChangeDenominator)
+		return strconv.FormatUint(p.BaseGasPriceChangeDenominator, 10), err
+	case string(SigVerifyCostEd25519Key):
+		err := cdc.UnmarshalJSON(bytes, &p.SigVerifyCostEd25519)
+		return strconv.FormatUint(p.SigVerifyCostEd25519, 10), err
+	case string(SigVerifyCostSecp256k1Key):
+		err := cdc.UnmarshalJSON(bytes, &p.SigVerifyCostSecp256k1)
+		return strconv.FormatUint(p.SigVerifyCostSecp256k1, 10), err
+	case string(SigVerifyCostSecp256r1Key):
+		err := cdc.UnmarshalJSON(bytes, &p.SigVerifyCostSecp256r1)
+		return strconv.FormatUint(p.SigVerifyCostSecp256r1, 10), err
 	default:
 		return "", fmt.Errorf("%s is not existed", key)
 	}
@@ -102,6 +185,13 @@ func DefaultParams() Params {
 	return Params{
 		GasPriceThreshold: sdk.NewIntWithDecimal(6, 12), // 0.000006iris, 6000iris-nano, 6*10^12iris-atto
 		TxSizeLimit:       uint64(1000),
+
+		TargetBlockGas:                uint64(10000000),
+		BaseGasPriceChangeDenominator: uint64(8),
+
+		SigVerifyCostEd25519:   uint64(59),
+		SigVerifyCostSecp256k1: uint64(100),
+		SigVerifyCostSecp256r1: uint64(130),
 	}
 }
 
@@ -112,6 +202,38 @@ func validateParams(p Params) error {
 	if p.TxSizeLimit < MinimumTxSizeLimit || p.TxSizeLimit > MaximumTxSizeLimit {
 		return sdk.NewError(params.DefaultCodespace, params.CodeInvalidTxSizeLimit, fmt.Sprintf("Tx size limit (%s) should be [500, 1500]", strconv.FormatUint(uint64(p.TxSizeLimit), 10)))
 	}
+	if err := validateTargetBlockGas(p.TargetBlockGas); err != nil {
+		return err
+	}
+	if err := validateBaseGasPriceChangeDenominator(p.BaseGasPriceChangeDenominator); err != nil {
+		return err
+	}
+	for _, cost := range []uint64{p.SigVerifyCostEd25519, p.SigVerifyCostSecp256k1, p.SigVerifyCostSecp256r1} {
+		if err := validateSigVerifyCost(cost); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func validateTargetBlockGas(targetGas uint64) sdk.Error {
+	if targetGas == 0 {
+		return sdk.NewError(params.DefaultCodespace, params.CodeInvalidTargetBlockGas, "Target block gas should be positive")
+	}
+	return nil
+}
+
+func validateBaseGasPriceChangeDenominator(denominator uint64) sdk.Error {
+	if denominator < MinimumBaseGasPriceChangeDenominator || denominator > MaximumBaseGasPriceChangeDenominator {
+		return sdk.NewError(params.DefaultCodespace, params.CodeInvalidBaseFeeChangeRate, fmt.Sprintf("Base gas price change denominator (%d) should be [%d, %d]", denominator, MinimumBaseGasPriceChangeDenominator, MaximumBaseGasPriceChangeDenominator))
+	}
+	return nil
+}
+
+func validateSigVerifyCost(cost uint64) sdk.Error {
+	if cost < MinimumSigVerifyCost || cost > MaximumSigVerifyCost {
+		return sdk.NewError(params.DefaultCodespace, params.CodeInvalidSigVerifyCost, fmt.Sprintf("Signature verification cost (%d) should be [%d, %d]", cost, MinimumSigVerifyCost, MaximumSigVerifyCost))
+	}
 	return nil
 }
 
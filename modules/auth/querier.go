@@ -12,6 +12,11 @@ const (
 	QueryTokenStats = "tokenStats"
 )
 
+// query endpoints supported by the fee Querier
+const (
+	QueryBaseGasPrice = "baseGasPrice"
+)
+
 // creates a querier for auth REST endpoints
 func NewQuerier(keeper AccountKeeper) sdk.Querier {
 	return func(ctx sdk.Context, path []string, req abci.RequestQuery) ([]byte, sdk.Error) {
@@ -27,6 +32,19 @@ func NewQuerier(keeper AccountKeeper) sdk.Querier {
 	}
 }
 
+// creates a querier for the fee REST endpoints
+func NewFeeQuerier(keeper FeeKeeper) sdk.Querier {
+	return func(ctx sdk.Context, path []string, req abci.RequestQuery) ([]byte, sdk.Error) {
+		switch path[0] {
+		case QueryBaseGasPrice:
+			return queryBaseGasPrice(ctx, keeper)
+
+		default:
+			return nil, sdk.ErrUnknownRequest("unknown fee query endpoint")
+		}
+	}
+}
+
 // defines the params for query: "custom/acc/account"
 type QueryAccountParams struct {
 	Address sdk.AccAddress
@@ -74,3 +92,25 @@ type TokenStats struct {
 	LooseTokens  sdk.Coins `json:"loose_tokens"`
 	BurnedTokens sdk.Coins `json:"burned_tokens"`
 }
+
+func queryBaseGasPrice(ctx sdk.Context, keeper FeeKeeper) ([]byte, sdk.Error) {
+	params := keeper.GetParamSet(ctx)
+	baseGasPrice := BaseGasPrice{
+		BaseGasPrice:      keeper.GetBaseGasPrice(ctx),
+		GasPriceThreshold: params.GasPriceThreshold,
+		TargetBlockGas:    params.TargetBlockGas,
+	}
+	bz, err := codec.MarshalJSONIndent(keeper.cdc, baseGasPrice)
+	if err != nil {
+		return nil, sdk.MarshalResultErr(err)
+	}
+
+	return bz, nil
+}
+
+// BaseGasPrice is the result of the base gas price query, prices are in iris-atto
+type BaseGasPrice struct {
+	BaseGasPrice      sdk.Int `json:"base_gas_price"`
+	GasPriceThreshold sdk.Int `json:"gas_price_threshold"`
+	TargetBlockGas    uint64  `json:"target_block_gas"`
+}
@@ -9,8 +9,12 @@ func RegisterCodec(cdc *codec.Codec) {
 	cdc.RegisterConcrete(MsgSubmitProposal{}, "irishub/gov/MsgSubmitProposal", nil)
 	cdc.RegisterConcrete(MsgSubmitTxTaxUsageProposal{}, "irishub/gov/MsgSubmitTxTaxUsageProposal", nil)
 	cdc.RegisterConcrete(MsgSubmitSoftwareUpgradeProposal{}, "irishub/gov/MsgSubmitSoftwareUpgradeProposal", nil)
+	cdc.RegisterConcrete(MsgSubmitMsgExecutionProposal{}, "irishub/gov/MsgSubmitMsgExecutionProposal", nil)
+	cdc.RegisterConcrete(MsgSubmitGuardianChangeProposal{}, "irishub/gov/MsgSubmitGuardianChangeProposal", nil)
+	cdc.RegisterConcrete(MsgSubmitSystemHaltProposal{}, "irishub/gov/MsgSubmitSystemHaltProposal", nil)
 	cdc.RegisterConcrete(MsgDeposit{}, "irishub/gov/MsgDeposit", nil)
 	cdc.RegisterConcrete(MsgVote{}, "irishub/gov/MsgVote", nil)
+	cdc.RegisterConcrete(MsgVoteWeighted{}, "irishub/gov/MsgVoteWeighted", nil)
 
 	cdc.RegisterInterface((*Proposal)(nil), nil)
 	cdc.RegisterConcrete(&BasicProposal{}, "irishub/gov/BasicProposal", nil)
@@ -18,6 +22,8 @@ func RegisterCodec(cdc *codec.Codec) {
 	cdc.RegisterConcrete(&SoftwareUpgradeProposal{}, "irishub/gov/SoftwareUpgradeProposal", nil)
 	cdc.RegisterConcrete(&SystemHaltProposal{}, "irishub/gov/SystemHaltProposal", nil)
 	cdc.RegisterConcrete(&TaxUsageProposal{}, "irishub/gov/TaxUsageProposal", nil)
+	cdc.RegisterConcrete(&MsgExecutionProposal{}, "irishub/gov/MsgExecutionProposal", nil)
+	cdc.RegisterConcrete(&GuardianChangeProposal{}, "irishub/gov/GuardianChangeProposal", nil)
 	cdc.RegisterConcrete(&Vote{}, "irishub/gov/Vote", nil)
 }
 
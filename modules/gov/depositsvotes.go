@@ -3,6 +3,7 @@ package gov
 import (
 	"encoding/json"
 	"fmt"
+	"strings"
 
 	sdk "github.com/NPC-Chain/npcchub/types"
 	"github.com/pkg/errors"
@@ -10,29 +11,134 @@ import (
 
 // Vote
 type Vote struct {
-	Voter      sdk.AccAddress `json:"voter"`       //  address of the voter
-	ProposalID uint64         `json:"proposal_id"` //  proposalID of the proposal
-	Option     VoteOption     `json:"option"`      //  option from OptionSet chosen by the voter
+	Voter      sdk.AccAddress      `json:"voter"`             //  address of the voter
+	ProposalID uint64              `json:"proposal_id"`       //  proposalID of the proposal
+	Option     VoteOption          `json:"option"`            //  option from OptionSet chosen by the voter, empty for a weighted vote
+	Options    WeightedVoteOptions `json:"options,omitempty"` //  weighted options chosen by the voter of a weighted vote
 }
 
 func (v Vote) String() string {
+	if len(v.Options) > 0 {
+		return fmt.Sprintf("Voter %s voted with options %s on proposal %d", v.Voter, v.Options, v.ProposalID)
+	}
 	return fmt.Sprintf("Voter %s voted with option %s on proposal %d", v.Voter, v.Option, v.ProposalID)
 }
 
+// Returns the weighted options of a vote, a regular vote has its option with the full weight
+func (v Vote) GetOptions() WeightedVoteOptions {
+	if len(v.Options) > 0 {
+		return v.Options
+	}
+	return WeightedVoteOptions{NewWeightedVoteOption(v.Option, sdk.OneDec())}
+}
+
 // Votes is a collection of Vote
 type Votes []Vote
 
 func (v Votes) String() string {
 	out := fmt.Sprintf("Votes for Proposal %d:", v[0].ProposalID)
 	for _, vot := range v {
-		out += fmt.Sprintf("\n  %s: %s", vot.Voter, vot.Option)
+		if len(vot.Options) > 0 {
+			out += fmt.Sprintf("\n  %s: %s", vot.Voter, vot.Options)
+		} else {
+			out += fmt.Sprintf("\n  %s: %s", vot.Voter, vot.Option)
+		}
 	}
 	return out
 }
 
 // Returns whether 2 votes are equal
 func (voteA Vote) Equals(voteB Vote) bool {
-	return voteA.Voter.Equals(voteB.Voter) && voteA.ProposalID == voteB.ProposalID && voteA.Option == voteB.Option
+	return voteA.Voter.Equals(voteB.Voter) && voteA.ProposalID == voteB.ProposalID && voteA.Option == voteB.Option &&
+		voteA.Options.Equals(voteB.Options)
+}
+
+// WeightedVoteOption is an option of a weighted vote with the proportion of the voting power given to it
+type WeightedVoteOption struct {
+	Option VoteOption `json:"option"`
+	Weight sdk.Dec    `json:"weight"`
+}
+
+func NewWeightedVoteOption(option VoteOption, weight sdk.Dec) WeightedVoteOption {
+	return WeightedVoteOption{
+		Option: option,
+		Weight: weight,
+	}
+}
+
+func (wo WeightedVoteOption) String() string {
+	return fmt.Sprintf("%s=%s", wo.Option, wo.Weight)
+}
+
+// WeightedVoteOptions is a collection of WeightedVoteOption
+type WeightedVoteOptions []WeightedVoteOption
+
+func (wos WeightedVoteOptions) String() string {
+	var strs []string
+	for _, wo := range wos {
+		strs = append(strs, wo.String())
+	}
+	return strings.Join(strs, ",")
+}
+
+// Returns whether 2 collections of weighted options are equal
+func (wos WeightedVoteOptions) Equals(other WeightedVoteOptions) bool {
+	if len(wos) != len(other) {
+		return false
+	}
+	for i, wo := range wos {
+		if wo.Option != other[i].Option || !wo.Weight.Equal(other[i].Weight) {
+			return false
+		}
+	}
+	return true
+}
+
+// Validate checks that the options are valid and distinct and that the weights are positive and sum up to 1
+func (wos WeightedVoteOptions) Validate(codespace sdk.CodespaceType) sdk.Error {
+	if len(wos) == 0 {
+		return ErrInvalidWeightedVote(codespace, "options can't be empty")
+	}
+	totalWeight := sdk.ZeroDec()
+	usedOptions := make(map[VoteOption]bool)
+	for _, wo := range wos {
+		if !ValidVoteOption(wo.Option) {
+			return ErrInvalidVote(codespace, wo.Option)
+		}
+		if usedOptions[wo.Option] {
+			return ErrInvalidWeightedVote(codespace, fmt.Sprintf("option %s is duplicated", wo.Option))
+		}
+		usedOptions[wo.Option] = true
+		if wo.Weight.IsNil() || !wo.Weight.IsPositive() || wo.Weight.GT(sdk.OneDec()) {
+			return ErrInvalidWeightedVote(codespace, fmt.Sprintf("weight of option %s should be (0,1]", wo.Option))
+		}
+		totalWeight = totalWeight.Add(wo.Weight)
+	}
+	if !totalWeight.Equal(sdk.OneDec()) {
+		return ErrInvalidWeightedVote(codespace, fmt.Sprintf("the sum of the weights should be 1, got %s", totalWeight))
+	}
+	return nil
+}
+
+// Parses weighted options from a string like "Yes=0.7,No=0.3"
+func WeightedVoteOptionsFromString(str string) (WeightedVoteOptions, error) {
+	var wos WeightedVoteOptions
+	for _, s := range strings.Split(strings.TrimSpace(str), ",") {
+		fields := strings.Split(strings.TrimSpace(s), "=")
+		if len(fields) != 2 {
+			return nil, errors.Errorf("'%s' is not a valid weighted vote option, eg. Yes=0.7", s)
+		}
+		option, err := VoteOptionFromString(strings.TrimSpace(fields[0]))
+		if err != nil {
+			return nil, err
+		}
+		weight, err := sdk.NewDecFromStr(strings.TrimSpace(fields[1]))
+		if err != nil {
+			return nil, err
+		}
+		wos = append(wos, NewWeightedVoteOption(option, weight))
+	}
+	return wos, nil
 }
 
 // Returns whether a vote is empty
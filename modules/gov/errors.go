@@ -39,6 +39,11 @@ const (
 	CodeMoreThanMaxProposal     sdk.CodeType = 27
 	CodeInvalidUpgradeParams    sdk.CodeType = 28
 	CodeEmptyParam              sdk.CodeType = 29
+	CodeDuplicateParam          sdk.CodeType = 30
+	CodeInvalidExecutionMsg     sdk.CodeType = 31
+	CodeInvalidGuardianChange   sdk.CodeType = 32
+	CodeInvalidWeightedVote     sdk.CodeType = 33
+	CodeInvalidHaltHeight       sdk.CodeType = 34
 )
 
 //----------------------------------------
@@ -92,6 +97,26 @@ func ErrEmptyParam(codespace sdk.CodespaceType) sdk.Error {
 	return sdk.NewError(codespace, CodeEmptyParam, fmt.Sprintf("Params can't be empty"))
 }
 
+func ErrDuplicateParam(codespace sdk.CodespaceType, subspace string, key string) sdk.Error {
+	return sdk.NewError(codespace, CodeDuplicateParam, fmt.Sprintf("Param %s/%s is changed more than once", subspace, key))
+}
+
+func ErrInvalidExecutionMsg(codespace sdk.CodespaceType, msg string) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidExecutionMsg, fmt.Sprintf("Invalid msg in MsgExecutionProposal: %s", msg))
+}
+
+func ErrInvalidHaltHeight(codespace sdk.CodespaceType, haltHeight int64) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidHaltHeight, fmt.Sprintf("Halt height [%d] is invalid", haltHeight))
+}
+
+func ErrInvalidWeightedVote(codespace sdk.CodespaceType, msg string) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidWeightedVote, fmt.Sprintf("Invalid weighted vote: %s", msg))
+}
+
+func ErrInvalidGuardianChange(codespace sdk.CodespaceType, msg string) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidGuardianChange, fmt.Sprintf("Invalid guardian change: %s", msg))
+}
+
 func ErrInvalidParamOp(codespace sdk.CodespaceType, opStr string) sdk.Error {
 	return sdk.NewError(codespace, CodeInvalidParamOp, fmt.Sprintf("Op '%s' is not valid", opStr))
 }
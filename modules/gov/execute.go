@@ -10,15 +10,36 @@ func Execute(ctx sdk.Context, gk Keeper, p Proposal) (err error) {
 	case ProposalTypeParameterChange:
 		return ParameterProposalExecute(ctx, gk, p.(*ParameterProposal))
 	case ProposalTypeSystemHalt:
-		return SystemHaltProposalExecute(ctx, gk)
+		return SystemHaltProposalExecute(ctx, gk, p.(*SystemHaltProposal))
 	case ProposalTypeTxTaxUsage:
 		return TaxUsageProposalExecute(ctx, gk, p.(*TaxUsageProposal))
 	case ProposalTypeSoftwareUpgrade:
 		return SoftwareUpgradeProposalExecute(ctx, gk, p.(*SoftwareUpgradeProposal))
+	case ProposalTypeMsgExecution:
+		return MsgExecutionProposalExecute(ctx, gk, p.(*MsgExecutionProposal))
+	case ProposalTypeGuardianChange:
+		return GuardianChangeProposalExecute(ctx, gk, p.(*GuardianChangeProposal))
 	}
 	return nil
 }
 
+// executeProposal executes the passed proposal in a cached context,
+// the changes are written only if the execution succeeds
+func executeProposal(ctx sdk.Context, gk Keeper, p Proposal) (err error) {
+	cacheCtx, writeCache := ctx.CacheContext()
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("failed to execute the proposal: %v", r)
+		}
+	}()
+
+	if err = Execute(cacheCtx, gk, p); err != nil {
+		return err
+	}
+	writeCache()
+	return nil
+}
+
 func TaxUsageProposalExecute(ctx sdk.Context, gk Keeper, p *TaxUsageProposal) (err error) {
 	burn := false
 	if p.TaxUsage.Usage == UsageTypeBurn {
@@ -37,20 +58,13 @@ func TaxUsageProposalExecute(ctx sdk.Context, gk Keeper, p *TaxUsageProposal) (e
 
 func ParameterProposalExecute(ctx sdk.Context, gk Keeper, pp *ParameterProposal) (err error) {
 	ctx.Logger().Info("Execute ParameterProposal begin")
+	if err := gk.applyParams(ctx, pp.Params); err != nil {
+		ctx.Logger().Error("Execute ParameterProposal Failed", "err", err.Error())
+		return err
+	}
 	for _, param := range pp.Params {
-		paramSet, _ := gk.paramsKeeper.GetParamSet(param.Subspace)
-		value, _ := paramSet.Validate(param.Key, param.Value)
-		subspace, found := gk.paramsKeeper.GetSubspace(param.Subspace)
-		if found {
-			SetParameterMetrics(gk.metrics, param.Key, value)
-			subspace.Set(ctx, []byte(param.Key), value)
-			ctx.Logger().Info("Execute ParameterProposal Successed", "key", param.Key, "value", param.Value)
-		} else {
-			ctx.Logger().Info("Execute ParameterProposal Failed", "key", param.Key, "value", param.Value)
-		}
-
+		ctx.Logger().Info("Execute ParameterProposal Successed", "key", param.Key, "value", param.Value)
 	}
-
 	return
 }
 
@@ -79,12 +93,22 @@ func SoftwareUpgradeProposalExecute(ctx sdk.Context, gk Keeper, sp *SoftwareUpgr
 	return nil
 }
 
-func SystemHaltProposalExecute(ctx sdk.Context, gk Keeper) error {
+func SystemHaltProposalExecute(ctx sdk.Context, gk Keeper, sp *SystemHaltProposal) error {
 	logger := ctx.Logger()
 
 	if gk.GetSystemHaltHeight(ctx) == -1 {
-		gk.SetSystemHaltHeight(ctx, ctx.BlockHeight()+gk.GetSystemHaltPeriod(ctx))
-		logger.Info("Execute SystemHaltProposal begin", "SystemHaltHeight", gk.GetSystemHaltHeight(ctx))
+		haltHeight := ctx.BlockHeight() + gk.GetSystemHaltPeriod(ctx)
+		if sp.HaltHeight > 0 {
+			if sp.HaltHeight <= ctx.BlockHeight() {
+				logger.Info("Execute SystemHaltProposal Failure", "info",
+					fmt.Sprintf("halt height [%d] must be more than blockHeight [%d]", sp.HaltHeight, ctx.BlockHeight()))
+				return nil
+			}
+			haltHeight = sp.HaltHeight
+		}
+		gk.SetSystemHaltHeight(ctx, haltHeight)
+		gk.SetSystemHaltExport(ctx, sp.ExportGenesis)
+		logger.Info("Execute SystemHaltProposal begin", "SystemHaltHeight", gk.GetSystemHaltHeight(ctx), "ExportGenesis", sp.ExportGenesis)
 	} else {
 		logger.Info("SystemHalt Period is in process", "SystemHaltHeight", gk.GetSystemHaltHeight(ctx))
 
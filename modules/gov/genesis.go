@@ -1,6 +1,7 @@
 package gov
 
 import (
+	"github.com/NPC-Chain/npcchub/modules/params"
 	sdk "github.com/NPC-Chain/npcchub/types"
 )
 
@@ -26,6 +27,7 @@ func DefaultGenesisState() GenesisState {
 
 // InitGenesis - store genesis parameters
 func InitGenesis(ctx sdk.Context, k Keeper, data GenesisState) {
+	params.SetUpgradeDefaults(&data.Params)
 	err := ValidateGenesis(data)
 	if err != nil {
 		// TODO: Handle this with #870
@@ -51,6 +53,7 @@ func ExportGenesis(ctx sdk.Context, k Keeper) GenesisState {
 }
 
 func ValidateGenesis(data GenesisState) error {
+	params.SetUpgradeDefaults(&data.Params)
 	err := validateParams(data.Params)
 	if err != nil {
 		return err
@@ -1,7 +1,10 @@
 package gov
 
 import (
+	"encoding/json"
+
 	sdk "github.com/NPC-Chain/npcchub/types"
+	"github.com/pkg/errors"
 )
 
 //-----------------------------------------------------------
@@ -31,6 +34,57 @@ func (p ProposalLevel) string() string {
 	}
 }
 
+// String to ProposalLevel byte. Returns ff if invalid.
+func ProposalLevelFromString(str string) (ProposalLevel, error) {
+	switch str {
+	case CRITICAL:
+		return ProposalLevelCritical, nil
+	case IMPORTANT:
+		return ProposalLevelImportant, nil
+	case NORMAL:
+		return ProposalLevelNormal, nil
+	case "":
+		return ProposalLevelNil, nil
+	default:
+		return ProposalLevel(0xff), errors.Errorf("'%s' is not a valid proposal level", str)
+	}
+}
+
+// Turns ProposalLevel byte to String
+func (p ProposalLevel) String() string {
+	switch p {
+	case ProposalLevelCritical:
+		return CRITICAL
+	case ProposalLevelImportant:
+		return IMPORTANT
+	case ProposalLevelNormal:
+		return NORMAL
+	default:
+		return ""
+	}
+}
+
+// Marshals to JSON using string
+func (p ProposalLevel) MarshalJSON() ([]byte, error) {
+	return json.Marshal(p.String())
+}
+
+// Unmarshals from JSON
+func (p *ProposalLevel) UnmarshalJSON(data []byte) error {
+	var s string
+	err := json.Unmarshal(data, &s)
+	if err != nil {
+		return err
+	}
+
+	bz2, err := ProposalLevelFromString(s)
+	if err != nil {
+		return err
+	}
+	*p = bz2
+	return nil
+}
+
 func GetProposalLevel(p Proposal) ProposalLevel {
 	return GetProposalLevelByProposalKind(p.GetProposalType())
 }
@@ -45,6 +99,10 @@ func GetProposalLevelByProposalKind(p ProposalKind) ProposalLevel {
 		return ProposalLevelCritical
 	case ProposalTypeSoftwareUpgrade:
 		return ProposalLevelCritical
+	case ProposalTypeMsgExecution:
+		return ProposalLevelCritical
+	case ProposalTypeGuardianChange:
+		return ProposalLevelCritical
 	default:
 		return ProposalLevelNil
 	}
@@ -77,6 +135,11 @@ func (Keeper Keeper) GetDepositProcedure(ctx sdk.Context, p Proposal) DepositPro
 // Returns the current Voting Procedure from the global param store
 func (Keeper Keeper) GetVotingProcedure(ctx sdk.Context, p Proposal) VotingProcedure {
 	params := Keeper.GetParamSet(ctx)
+	if p.IsExpedited() {
+		return VotingProcedure{
+			VotingPeriod: params.ExpeditedVotingPeriod,
+		}
+	}
 	switch GetProposalLevel(p) {
 	case ProposalLevelCritical:
 		return VotingProcedure{
@@ -111,8 +174,17 @@ func (Keeper Keeper) GetMaxNumByProposalLevel(ctx sdk.Context, pl ProposalLevel)
 	}
 }
 
-// Returns the current Tallying Procedure from the global param store
+// Returns the current Tallying Procedure from the global param store,
+// an expedited proposal is tallied with the expedited threshold and the other rules of its level
 func (Keeper Keeper) GetTallyingProcedure(ctx sdk.Context, p Proposal) TallyingProcedure {
+	tp := Keeper.getLevelTallyingProcedure(ctx, p)
+	if p.IsExpedited() {
+		tp.Threshold = Keeper.GetParamSet(ctx).ExpeditedThreshold
+	}
+	return tp
+}
+
+func (Keeper Keeper) getLevelTallyingProcedure(ctx sdk.Context, p Proposal) TallyingProcedure {
 	params := Keeper.GetParamSet(ctx)
 	switch GetProposalLevel(p) {
 	case ProposalLevelCritical:
@@ -21,8 +21,16 @@ func NewHandler(keeper Keeper) sdk.Handler {
 			return handleMsgSubmitTxTaxUsageProposal(ctx, keeper, msg)
 		case MsgSubmitSoftwareUpgradeProposal:
 			return handleMsgSubmitSoftwareUpgradeProposal(ctx, keeper, msg)
+		case MsgSubmitMsgExecutionProposal:
+			return handleMsgSubmitMsgExecutionProposal(ctx, keeper, msg)
+		case MsgSubmitSystemHaltProposal:
+			return handleMsgSubmitSystemHaltProposal(ctx, keeper, msg)
+		case MsgSubmitGuardianChangeProposal:
+			return handleMsgSubmitGuardianChangeProposal(ctx, keeper, msg)
 		case MsgVote:
 			return handleMsgVote(ctx, keeper, msg)
+		case MsgVoteWeighted:
+			return handleMsgVoteWeighted(ctx, keeper, msg)
 		default:
 			errMsg := "Unrecognized gov msg type"
 			return sdk.ErrUnknownRequest(errMsg).Result()
@@ -31,31 +39,22 @@ func NewHandler(keeper Keeper) sdk.Handler {
 }
 
 func handleMsgSubmitProposal(ctx sdk.Context, keeper Keeper, msg MsgSubmitProposal) sdk.Result {
+	// the SystemHaltProposals submitted before the halt height and the genesis export are handled the same way
+	if msg.ProposalType == ProposalTypeSystemHalt {
+		return handleMsgSubmitSystemHaltProposal(ctx, keeper, NewMsgSubmitSystemHaltProposal(msg, 0, false))
+	}
 
 	proposalLevel := GetProposalLevelByProposalKind(msg.ProposalType)
 	if num, ok := keeper.HasReachedTheMaxProposalNum(ctx, proposalLevel); ok {
 		return ErrMoreThanMaxProposal(keeper.codespace, num, proposalLevel.string()).Result()
 	}
 
-	if msg.ProposalType == ProposalTypeSystemHalt {
-		_, found := keeper.guardianKeeper.GetProfiler(ctx, msg.Proposer)
-		if !found {
-			return ErrNotProfiler(keeper.codespace, msg.Proposer).Result()
-		}
-	}
-
 	if msg.ProposalType == ProposalTypeParameterChange {
-		for _, param := range msg.Params {
-			if p, ok := keeper.paramsKeeper.GetParamSet(param.Subspace); ok {
-				if _, err := p.Validate(param.Key, param.Value); err != nil {
-					return err.Result()
-				}
-			} else {
-				return ErrInvalidParam(DefaultCodespace, param.Subspace).Result()
-			}
+		if _, err := keeper.ValidateParams(ctx, msg.Params); err != nil {
+			return err.Result()
 		}
 	}
-	proposal := keeper.NewProposal(ctx, msg.Title, msg.Description, msg.ProposalType, msg.Params)
+	proposal := keeper.NewProposal(ctx, msg.Title, msg.Description, msg.ProposalType, msg.Params, msg.Expedited)
 
 	err, votingStarted := keeper.AddInitialDeposit(ctx, proposal, msg.Proposer, msg.InitialDeposit)
 	if err != nil {
@@ -176,6 +175,119 @@ func handleMsgSubmitSoftwareUpgradeProposal(ctx sdk.Context, keeper Keeper, msg
 	}
 }
 
+func handleMsgSubmitMsgExecutionProposal(ctx sdk.Context, keeper Keeper, msg MsgSubmitMsgExecutionProposal) sdk.Result {
+	proposalLevel := GetProposalLevelByProposalKind(msg.ProposalType)
+	if num, ok := keeper.HasReachedTheMaxProposalNum(ctx, proposalLevel); ok {
+		return ErrMoreThanMaxProposal(keeper.codespace, num, proposalLevel.string()).Result()
+	}
+
+	for _, m := range msg.Msgs {
+		if keeper.router.Route(m.Route()) == nil {
+			return sdk.ErrUnknownRequest("Unrecognized Msg type: " + m.Route()).Result()
+		}
+	}
+
+	proposal := keeper.NewMsgExecutionProposal(ctx, msg)
+
+	err, votingStarted := keeper.AddInitialDeposit(ctx, proposal, msg.Proposer, msg.InitialDeposit)
+	if err != nil {
+		return err.Result()
+	}
+	proposalIDBytes := []byte(strconv.FormatUint(proposal.GetProposalID(), 10))
+
+	resTags := sdk.NewTags(
+		tags.Proposer, []byte(msg.Proposer.String()),
+		tags.ProposalID, proposalIDBytes,
+	)
+
+	if votingStarted {
+		resTags = resTags.AppendTag(tags.VotingPeriodStart, proposalIDBytes)
+	}
+
+	keeper.AddProposalNum(ctx, proposal)
+	return sdk.Result{
+		Data: proposalIDBytes,
+		Tags: resTags,
+	}
+}
+
+func handleMsgSubmitSystemHaltProposal(ctx sdk.Context, keeper Keeper, msg MsgSubmitSystemHaltProposal) sdk.Result {
+	proposalLevel := GetProposalLevelByProposalKind(msg.ProposalType)
+	if num, ok := keeper.HasReachedTheMaxProposalNum(ctx, proposalLevel); ok {
+		return ErrMoreThanMaxProposal(keeper.codespace, num, proposalLevel.string()).Result()
+	}
+
+	_, found := keeper.guardianKeeper.GetProfiler(ctx, msg.Proposer)
+	if !found {
+		return ErrNotProfiler(keeper.codespace, msg.Proposer).Result()
+	}
+
+	if msg.HaltHeight > 0 && msg.HaltHeight <= ctx.BlockHeight() {
+		return ErrInvalidHaltHeight(keeper.codespace, msg.HaltHeight).Result()
+	}
+
+	proposal := keeper.NewSystemHaltProposal(ctx, msg.Title, msg.Description, msg.ProposalType, msg.Expedited, msg.HaltHeight, msg.ExportGenesis)
+
+	err, votingStarted := keeper.AddInitialDeposit(ctx, proposal, msg.Proposer, msg.InitialDeposit)
+	if err != nil {
+		return err.Result()
+	}
+	proposalIDBytes := []byte(strconv.FormatUint(proposal.GetProposalID(), 10))
+
+	resTags := sdk.NewTags(
+		tags.Proposer, []byte(msg.Proposer.String()),
+		tags.ProposalID, proposalIDBytes,
+		tags.HaltHeight, []byte(strconv.FormatInt(msg.HaltHeight, 10)),
+	)
+
+	if votingStarted {
+		resTags = resTags.AppendTag(tags.VotingPeriodStart, proposalIDBytes)
+	}
+
+	keeper.AddProposalNum(ctx, proposal)
+	return sdk.Result{
+		Data: proposalIDBytes,
+		Tags: resTags,
+	}
+}
+
+func handleMsgSubmitGuardianChangeProposal(ctx sdk.Context, keeper Keeper, msg MsgSubmitGuardianChangeProposal) sdk.Result {
+	proposalLevel := GetProposalLevelByProposalKind(msg.ProposalType)
+	if num, ok := keeper.HasReachedTheMaxProposalNum(ctx, proposalLevel); ok {
+		return ErrMoreThanMaxProposal(keeper.codespace, num, proposalLevel.string()).Result()
+	}
+
+	if err := keeper.validateGuardianChange(ctx, msg.GuardianChange); err != nil {
+		return err.Result()
+	}
+
+	proposal := keeper.NewGuardianChangeProposal(ctx, msg)
+
+	err, votingStarted := keeper.AddInitialDeposit(ctx, proposal, msg.Proposer, msg.InitialDeposit)
+	if err != nil {
+		return err.Result()
+	}
+	proposalIDBytes := []byte(strconv.FormatUint(proposal.GetProposalID(), 10))
+
+	resTags := sdk.NewTags(
+		tags.Proposer, []byte(msg.Proposer.String()),
+		tags.ProposalID, proposalIDBytes,
+		tags.GuardianOp, []byte(msg.GuardianChange.Op.String()),
+		tags.GuardianRole, []byte(msg.GuardianChange.Role.String()),
+		tags.GuardianAddress, []byte(msg.GuardianChange.Address.String()),
+	)
+
+	if votingStarted {
+		resTags = resTags.AppendTag(tags.VotingPeriodStart, proposalIDBytes)
+	}
+
+	keeper.AddProposalNum(ctx, proposal)
+	return sdk.Result{
+		Data: proposalIDBytes,
+		Tags: resTags,
+	}
+}
+
 func handleMsgDeposit(ctx sdk.Context, keeper Keeper, msg MsgDeposit) sdk.Result {
 
 	err, votingStarted := keeper.AddDeposit(ctx, msg.ProposalID, msg.Depositor, msg.Amount)
@@ -218,6 +330,24 @@ func handleMsgVote(ctx sdk.Context, keeper Keeper, msg MsgVote) sdk.Result {
 	}
 }
 
+func handleMsgVoteWeighted(ctx sdk.Context, keeper Keeper, msg MsgVoteWeighted) sdk.Result {
+
+	err := keeper.AddWeightedVote(ctx, msg.ProposalID, msg.Voter, msg.Options)
+	if err != nil {
+		return err.Result()
+	}
+
+	proposalIDBytes := []byte(strconv.FormatUint(msg.ProposalID, 10))
+
+	resTags := sdk.NewTags(
+		tags.Voter, []byte(msg.Voter.String()),
+		tags.ProposalID, proposalIDBytes,
+	)
+	return sdk.Result{
+		Tags: resTags,
+	}
+}
+
 // Called every block, process inflation, update validator set
 func EndBlocker(ctx sdk.Context, keeper Keeper) (resTags sdk.Tags) {
 	ctx = ctx.WithCoinFlowTrigger(sdk.GovEndBlocker)
@@ -226,7 +356,10 @@ func EndBlocker(ctx sdk.Context, keeper Keeper) (resTags sdk.Tags) {
 
 	if ctx.BlockHeight() == keeper.GetSystemHaltHeight(ctx) {
 		resTags = resTags.AppendTag(tmstate.HaltTagKey, []byte(tmstate.HaltTagValue))
-		ctx.Logger().Info("SystemHalt Start!!!")
+		if keeper.GetSystemHaltExport(ctx) {
+			resTags = resTags.AppendTag(sdk.ExportGenesisTag, []byte("true"))
+		}
+		ctx.Logger().Info("SystemHalt Start!!!", "ExportGenesis", keeper.GetSystemHaltExport(ctx))
 	}
 
 	inactiveIterator := keeper.InactiveProposalQueueIterator(ctx, ctx.BlockHeader().Time)
@@ -257,13 +390,35 @@ func EndBlocker(ctx sdk.Context, keeper Keeper) (resTags sdk.Tags) {
 		activeProposal := keeper.GetProposal(ctx, proposalID)
 		result, tallyResults, votingVals := tally(ctx, keeper, activeProposal)
 
+		// an expedited proposal that fails its tally falls back to the voting rules of its level
+		if result != PASS && activeProposal.IsExpedited() {
+			keeper.RemoveFromActiveProposalQueue(ctx, activeProposal.GetVotingEndTime(), activeProposal.GetProposalID())
+			activeProposal.SetExpedited(false)
+			votingPeriod := keeper.GetVotingProcedure(ctx, activeProposal).VotingPeriod
+			activeProposal.SetVotingEndTime(activeProposal.GetVotingStartTime().Add(votingPeriod))
+			activeProposal.SetTallyResult(tallyResults)
+			keeper.SetProposal(ctx, activeProposal)
+			keeper.InsertActiveProposalQueue(ctx, activeProposal.GetVotingEndTime(), activeProposal.GetProposalID())
+			ctx.Logger().Info("Expedited proposal failed; fallback to normal voting period", "ProposalID", activeProposal.GetProposalID(), "result", result,
+				"VotingEndTime", activeProposal.GetVotingEndTime())
+			resTags = resTags.AppendTag(tags.Action, tags.ActionProposalExpeditedFallback)
+			resTags = resTags.AppendTag(tags.ProposalID, []byte(strconv.FormatUint(proposalID, 10)))
+			continue
+		}
+
 		var action []byte
 		if result == PASS {
-			keeper.metrics.ProposalStatus.With(ProposalIDLabel, strconv.FormatUint(proposalID, 10)).Set(2)
 			keeper.RefundDeposits(ctx, activeProposal.GetProposalID())
 			activeProposal.SetStatus(StatusPassed)
 			action = tags.ActionProposalPassed
-			Execute(ctx, keeper, activeProposal)
+			if err := executeProposal(ctx, keeper, activeProposal); err != nil {
+				ctx.Logger().Error("Proposal failed to execute", "ProposalID", activeProposal.GetProposalID(), "err", err.Error())
+				activeProposal.SetStatus(StatusFailed)
+				action = tags.ActionProposalFailed
+				keeper.metrics.ProposalStatus.With(ProposalIDLabel, strconv.FormatUint(proposalID, 10)).Set(5)
+			} else {
+				keeper.metrics.ProposalStatus.With(ProposalIDLabel, strconv.FormatUint(proposalID, 10)).Set(2)
+			}
 		} else if result == REJECT {
 			keeper.metrics.ProposalStatus.With(ProposalIDLabel, strconv.FormatUint(proposalID, 10)).Set(3)
 			keeper.RefundDeposits(ctx, activeProposal.GetProposalID())
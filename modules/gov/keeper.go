@@ -3,6 +3,7 @@ package gov
 import (
 	"time"
 
+	"github.com/NPC-Chain/npcchub/app/protocol"
 	"github.com/NPC-Chain/npcchub/codec"
 	"github.com/NPC-Chain/npcchub/modules/bank"
 	"github.com/NPC-Chain/npcchub/modules/distribution"
@@ -18,6 +19,7 @@ import (
 // nolint
 var (
 	DepositedCoinsAccAddr = sdk.AccAddress(crypto.AddressHash([]byte("govDepositedCoins")))
+	GovModuleAccAddr      = guardian.GovModuleAccAddr
 	BurnRate              = sdk.NewDecWithPrec(2, 1)
 	MinDepositRate        = sdk.NewDecWithPrec(3, 1)
 )
@@ -49,6 +51,9 @@ type Keeper struct {
 	// The reference to the DelegationSet to get information about delegators
 	ds sdk.DelegationSet
 
+	// The router to execute the msgs of passed MsgExecutionProposals
+	router protocol.Router
+
 	// Reserved codespace
 	codespace sdk.CodespaceType
 
@@ -60,7 +65,7 @@ type Keeper struct {
 // - depositing funds into proposals, and activating upon sufficient funds being deposited
 // - users voting on proposals, with weight proportional to stake in the system
 // - and tallying the result of the vote.
-func NewKeeper(key sdk.StoreKey, cdc *codec.Codec, paramSpace params.Subspace, paramsKeeper params.Keeper, protocolKeeper sdk.ProtocolKeeper, ck bank.Keeper, dk distribution.Keeper, guardianKeeper guardian.Keeper, ds sdk.DelegationSet, codespace sdk.CodespaceType, metrics *Metrics) Keeper {
+func NewKeeper(key sdk.StoreKey, cdc *codec.Codec, paramSpace params.Subspace, paramsKeeper params.Keeper, protocolKeeper sdk.ProtocolKeeper, ck bank.Keeper, dk distribution.Keeper, guardianKeeper guardian.Keeper, ds sdk.DelegationSet, router protocol.Router, codespace sdk.CodespaceType, metrics *Metrics) Keeper {
 	return Keeper{
 		key,
 		cdc,
@@ -72,6 +77,7 @@ func NewKeeper(key sdk.StoreKey, cdc *codec.Codec, paramSpace params.Subspace, p
 		guardianKeeper,
 		ds.GetValidatorSet(),
 		ds,
+		router,
 		codespace,
 		metrics,
 	}
@@ -80,12 +86,12 @@ func NewKeeper(key sdk.StoreKey, cdc *codec.Codec, paramSpace params.Subspace, p
 // =====================================================
 // Proposals
 
-func (keeper Keeper) NewProposal(ctx sdk.Context, title string, description string, proposalType ProposalKind, param Params) Proposal {
+func (keeper Keeper) NewProposal(ctx sdk.Context, title string, description string, proposalType ProposalKind, param Params, expedited bool) Proposal {
 	switch proposalType {
 	case ProposalTypeParameterChange:
-		return keeper.NewParametersProposal(ctx, title, description, proposalType, param)
+		return keeper.NewParametersProposal(ctx, title, description, proposalType, param, expedited)
 	case ProposalTypeSystemHalt:
-		return keeper.NewSystemHaltProposal(ctx, title, description, proposalType)
+		return keeper.NewSystemHaltProposal(ctx, title, description, proposalType, expedited, 0, false)
 	}
 	return nil
 }
@@ -95,7 +101,7 @@ func (keeper Keeper) NewProposal(ctx sdk.Context, title string, description stri
 
 // Creates a SubmitProposal
 
-func (keeper Keeper) NewParametersProposal(ctx sdk.Context, title string, description string, proposalType ProposalKind, params Params) Proposal {
+func (keeper Keeper) NewParametersProposal(ctx sdk.Context, title string, description string, proposalType ProposalKind, params Params, expedited bool) Proposal {
 	proposalID, err := keeper.getNewProposalID(ctx)
 	if err != nil {
 		return nil
@@ -109,6 +115,8 @@ func (keeper Keeper) NewParametersProposal(ctx sdk.Context, title string, descri
 		TallyResult:  EmptyTallyResult(),
 		TotalDeposit: sdk.Coins{},
 		SubmitTime:   ctx.BlockHeader().Time,
+		Level:        GetProposalLevelByProposalKind(proposalType),
+		Expedited:    expedited,
 	}
 
 	var proposal Proposal = &ParameterProposal{
@@ -123,7 +131,9 @@ func (keeper Keeper) NewParametersProposal(ctx sdk.Context, title string, descri
 	return proposal
 }
 
-func (keeper Keeper) NewSystemHaltProposal(ctx sdk.Context, title string, description string, proposalType ProposalKind) Proposal {
+// NewSystemHaltProposal creates a SystemHaltProposal, halting the chain SystemHaltPeriod blocks after it passes
+// if haltHeight is 0, and exporting the genesis at the halt height if exportGenesis is set
+func (keeper Keeper) NewSystemHaltProposal(ctx sdk.Context, title string, description string, proposalType ProposalKind, expedited bool, haltHeight int64, exportGenesis bool) Proposal {
 	proposalID, err := keeper.getNewProposalID(ctx)
 	if err != nil {
 		return nil
@@ -137,15 +147,15 @@ func (keeper Keeper) NewSystemHaltProposal(ctx sdk.Context, title string, descri
 		TallyResult:  EmptyTallyResult(),
 		TotalDeposit: sdk.Coins{},
 		SubmitTime:   ctx.BlockHeader().Time,
+		Level:        GetProposalLevelByProposalKind(proposalType),
+		Expedited:    expedited,
 	}
 	var proposal Proposal = &SystemHaltProposal{
 		textProposal,
+		haltHeight,
+		exportGenesis,
 	}
-
-	depositPeriod := keeper.GetDepositProcedure(ctx, proposal).MaxDepositPeriod
-	proposal.SetDepositEndTime(proposal.GetSubmitTime().Add(depositPeriod))
-	keeper.SetProposal(ctx, proposal)
-	keeper.InsertInactiveProposalQueue(ctx, proposal.GetDepositEndTime(), proposalID)
+	keeper.saveProposal(ctx, proposal)
 	return proposal
 }
 
@@ -163,6 +173,8 @@ func (keeper Keeper) NewUsageProposal(ctx sdk.Context, msg MsgSubmitTxTaxUsagePr
 		TallyResult:  EmptyTallyResult(),
 		TotalDeposit: sdk.Coins{},
 		SubmitTime:   ctx.BlockHeader().Time,
+		Level:        GetProposalLevelByProposalKind(msg.ProposalType),
+		Expedited:    msg.Expedited,
 	}
 	var proposal Proposal = &TaxUsageProposal{
 		textProposal,
@@ -189,6 +201,8 @@ func (keeper Keeper) NewSoftwareUpgradeProposal(ctx sdk.Context, msg MsgSubmitSo
 		TallyResult:  EmptyTallyResult(),
 		TotalDeposit: sdk.Coins{},
 		SubmitTime:   ctx.BlockHeader().Time,
+		Level:        GetProposalLevelByProposalKind(msg.ProposalType),
+		Expedited:    msg.Expedited,
 	}
 	var proposal Proposal = &SoftwareUpgradeProposal{
 		textProposal,
@@ -202,6 +216,56 @@ func (keeper Keeper) NewSoftwareUpgradeProposal(ctx sdk.Context, msg MsgSubmitSo
 	return proposal
 }
 
+func (keeper Keeper) NewGuardianChangeProposal(ctx sdk.Context, msg MsgSubmitGuardianChangeProposal) Proposal {
+	proposalID, err := keeper.getNewProposalID(ctx)
+	if err != nil {
+		return nil
+	}
+	var textProposal = BasicProposal{
+		ProposalID:   proposalID,
+		Title:        msg.Title,
+		Description:  msg.Description,
+		ProposalType: msg.ProposalType,
+		Status:       StatusDepositPeriod,
+		TallyResult:  EmptyTallyResult(),
+		TotalDeposit: sdk.Coins{},
+		SubmitTime:   ctx.BlockHeader().Time,
+		Level:        GetProposalLevelByProposalKind(msg.ProposalType),
+		Expedited:    msg.Expedited,
+	}
+	var proposal Proposal = &GuardianChangeProposal{
+		textProposal,
+		msg.GuardianChange,
+	}
+	keeper.saveProposal(ctx, proposal)
+	return proposal
+}
+
+func (keeper Keeper) NewMsgExecutionProposal(ctx sdk.Context, msg MsgSubmitMsgExecutionProposal) Proposal {
+	proposalID, err := keeper.getNewProposalID(ctx)
+	if err != nil {
+		return nil
+	}
+	var textProposal = BasicProposal{
+		ProposalID:   proposalID,
+		Title:        msg.Title,
+		Description:  msg.Description,
+		ProposalType: msg.ProposalType,
+		Status:       StatusDepositPeriod,
+		TallyResult:  EmptyTallyResult(),
+		TotalDeposit: sdk.Coins{},
+		SubmitTime:   ctx.BlockHeader().Time,
+		Level:        GetProposalLevelByProposalKind(msg.ProposalType),
+		Expedited:    msg.Expedited,
+	}
+	var proposal Proposal = &MsgExecutionProposal{
+		textProposal,
+		msg.Msgs,
+	}
+	keeper.saveProposal(ctx, proposal)
+	return proposal
+}
+
 func (keeper Keeper) saveProposal(ctx sdk.Context, proposal Proposal) {
 	depositPeriod := keeper.GetDepositProcedure(ctx, proposal).MaxDepositPeriod
 	proposal.SetDepositEndTime(proposal.GetSubmitTime().Add(depositPeriod))
@@ -348,6 +412,31 @@ func (keeper Keeper) activateVotingPeriod(ctx sdk.Context, proposal Proposal) {
 
 // Adds a vote on a specific proposal
 func (keeper Keeper) AddVote(ctx sdk.Context, proposalID uint64, voterAddr sdk.AccAddress, option VoteOption) sdk.Error {
+	if !ValidVoteOption(option) {
+		return ErrInvalidVote(keeper.codespace, option)
+	}
+	return keeper.addVote(ctx, Vote{
+		ProposalID: proposalID,
+		Voter:      voterAddr,
+		Option:     option,
+	})
+}
+
+// Adds a weighted vote on a specific proposal, which splits the voting power of the voter across the options
+func (keeper Keeper) AddWeightedVote(ctx sdk.Context, proposalID uint64, voterAddr sdk.AccAddress, options WeightedVoteOptions) sdk.Error {
+	if err := options.Validate(keeper.codespace); err != nil {
+		return err
+	}
+	return keeper.addVote(ctx, Vote{
+		ProposalID: proposalID,
+		Voter:      voterAddr,
+		Option:     OptionEmpty,
+		Options:    options,
+	})
+}
+
+func (keeper Keeper) addVote(ctx sdk.Context, vote Vote) sdk.Error {
+	proposalID, voterAddr := vote.ProposalID, vote.Voter
 	proposal := keeper.GetProposal(ctx, proposalID)
 	if proposal == nil {
 		return ErrUnknownProposal(keeper.codespace, proposalID)
@@ -365,16 +454,16 @@ func (keeper Keeper) AddVote(ctx sdk.Context, proposalID uint64, voterAddr sdk.A
 		return ErrAlreadyVote(keeper.codespace, voterAddr, proposalID)
 	}
 
-	if !ValidVoteOption(option) {
-		return ErrInvalidVote(keeper.codespace, option)
-	}
+	keeper.setVote(ctx, proposalID, voterAddr, vote)
 
-	vote := Vote{
-		ProposalID: proposalID,
-		Voter:      voterAddr,
-		Option:     option,
+	// a weighted vote is recorded with its option of the largest weight
+	option := vote.Option
+	weight := sdk.ZeroDec()
+	for _, wo := range vote.Options {
+		if wo.Weight.GT(weight) {
+			option, weight = wo.Option, wo.Weight
+		}
 	}
-	keeper.setVote(ctx, proposalID, voterAddr, vote)
 	keeper.metrics.Vote.With(ValidatorLabel, validator.GetConsAddr().String(), ProposalIDLabel, strconv.FormatUint(proposalID, 10)).Set(float64(option))
 	return nil
 }
@@ -615,6 +704,25 @@ func (keeper Keeper) SetSystemHaltHeight(ctx sdk.Context, height int64) {
 	store.Set(KeySystemHaltHeight, bz)
 }
 
+// Returns whether the genesis is exported when the system halts
+func (keeper Keeper) GetSystemHaltExport(ctx sdk.Context) bool {
+	store := ctx.KVStore(keeper.storeKey)
+	bz := store.Get(KeySystemHaltExport)
+	if bz == nil {
+		return false
+	}
+	var export bool
+	keeper.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &export)
+
+	return export
+}
+
+func (keeper Keeper) SetSystemHaltExport(ctx sdk.Context, export bool) {
+	store := ctx.KVStore(keeper.storeKey)
+	bz := keeper.cdc.MustMarshalBinaryLengthPrefixed(export)
+	store.Set(KeySystemHaltExport, bz)
+}
+
 func (keeper Keeper) GetCriticalProposalID(ctx sdk.Context) (uint64, bool) {
 	store := ctx.KVStore(keeper.storeKey)
 	bz := store.Get(KeyCriticalProposal)
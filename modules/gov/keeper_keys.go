@@ -81,6 +81,7 @@ func KeyInactiveProposalQueueProposal(endTime time.Time, proposalID uint64) []by
 // Key for getting a the next available proposalID from the store
 var (
 	KeySystemHaltHeight     = []byte("SystemHaltHeight")
+	KeySystemHaltExport     = []byte("SystemHaltExport")
 	KeyCriticalProposal     = []byte("CriticalProposal")
 	KeyImportantProposalNum = []byte("ImportantProposalNum")
 	KeyNormalProposalNum    = []byte("NormalProposalNum")
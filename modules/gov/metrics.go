@@ -22,7 +22,7 @@ const (
 )
 
 type Metrics struct {
-	ProposalStatus metrics.Gauge // 0:DepositPeriod 1:VotingPeriod 2:Pass 3:Reject 4:Other
+	ProposalStatus metrics.Gauge // 0:DepositPeriod 1:VotingPeriod 2:Pass 3:Reject 4:Other 5:Failed
 	Vote           metrics.Gauge // 0:Yes 1:No 2:NoWithVeto 3:Abstain
 	Param          metrics.Gauge
 }
@@ -1,6 +1,7 @@
 package gov
 
 import (
+	"encoding/json"
 	"fmt"
 
 	sdk "github.com/NPC-Chain/npcchub/types"
@@ -9,7 +10,7 @@ import (
 // name to idetify transaction types
 const MsgRoute = "gov"
 
-var _, _, _, _ sdk.Msg = MsgSubmitProposal{}, MsgSubmitTxTaxUsageProposal{}, MsgDeposit{}, MsgVote{}
+var _, _, _, _, _, _, _, _ sdk.Msg = MsgSubmitProposal{}, MsgSubmitTxTaxUsageProposal{}, MsgSubmitMsgExecutionProposal{}, MsgSubmitGuardianChangeProposal{}, MsgSubmitSystemHaltProposal{}, MsgDeposit{}, MsgVote{}, MsgVoteWeighted{}
 
 //-----------------------------------------------------------
 // MsgSubmitProposal
@@ -20,6 +21,7 @@ type MsgSubmitProposal struct {
 	Proposer       sdk.AccAddress `json:"proposer"`        //  Address of the proposer
 	InitialDeposit sdk.Coins      `json:"initial_deposit"` //  Initial deposit paid by sender. Must be strictly positive.
 	Params         Params         `json:"params"`
+	Expedited      bool           `json:"expedited,omitempty"` //  Vote with the expedited voting period and threshold, falls back to the normal ones if it fails
 }
 
 func NewMsgSubmitProposal(title string, description string, proposalType ProposalKind, proposer sdk.AccAddress, initialDeposit sdk.Coins, params Params) MsgSubmitProposal {
@@ -69,7 +71,7 @@ func (msg MsgSubmitProposal) ValidateBasic() sdk.Error {
 }
 
 func (msg MsgSubmitProposal) String() string {
-	return fmt.Sprintf("MsgSubmitProposal{%s, %s, %s, %v}", msg.Title, msg.Description, msg.ProposalType, msg.InitialDeposit)
+	return fmt.Sprintf("MsgSubmitProposal{%s, %s, %s, %v, %v}", msg.Title, msg.Description, msg.ProposalType, msg.InitialDeposit, msg.Expedited)
 }
 
 // Implements Msg.
@@ -295,6 +297,53 @@ func (msg MsgVote) GetSigners() []sdk.AccAddress {
 	return []sdk.AccAddress{msg.Voter}
 }
 
+//-----------------------------------------------------------
+// MsgVoteWeighted
+type MsgVoteWeighted struct {
+	ProposalID uint64              `json:"proposal_id"` // ID of the proposal
+	Voter      sdk.AccAddress      `json:"voter"`       //  address of the voter
+	Options    WeightedVoteOptions `json:"options"`     //  options chosen by the voter with the weights of the voting power, which sum up to 1
+}
+
+func NewMsgVoteWeighted(voter sdk.AccAddress, proposalID uint64, options WeightedVoteOptions) MsgVoteWeighted {
+	return MsgVoteWeighted{
+		ProposalID: proposalID,
+		Voter:      voter,
+		Options:    options,
+	}
+}
+
+// Implements Msg.
+// nolint
+func (msg MsgVoteWeighted) Route() string { return MsgRoute }
+func (msg MsgVoteWeighted) Type() string  { return "vote_weighted" }
+
+// Implements Msg.
+func (msg MsgVoteWeighted) ValidateBasic() sdk.Error {
+	if len(msg.Voter.Bytes()) == 0 {
+		return sdk.ErrInvalidAddress(msg.Voter.String())
+	}
+	return msg.Options.Validate(DefaultCodespace)
+}
+
+func (msg MsgVoteWeighted) String() string {
+	return fmt.Sprintf("MsgVoteWeighted{%v - %s}", msg.ProposalID, msg.Options)
+}
+
+// Implements Msg.
+func (msg MsgVoteWeighted) GetSignBytes() []byte {
+	b, err := msgCdc.MarshalJSON(msg)
+	if err != nil {
+		panic(err)
+	}
+	return sdk.MustSortJSON(b)
+}
+
+// Implements Msg.
+func (msg MsgVoteWeighted) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Voter}
+}
+
 func (msg MsgSubmitProposal) EnsureLength() sdk.Error {
 	if len(msg.Title) > 70 {
 		return sdk.ErrInvalidLength(DefaultCodespace, CodeInvalidProposal, "title", len(msg.Title), 70)
@@ -304,3 +353,113 @@ func (msg MsgSubmitProposal) EnsureLength() sdk.Error {
 	}
 	return nil
 }
+
+type MsgSubmitMsgExecutionProposal struct {
+	MsgSubmitProposal
+	Msgs []sdk.Msg `json:"msgs"`
+}
+
+func NewMsgSubmitMsgExecutionProposal(msgSubmitProposal MsgSubmitProposal, msgs []sdk.Msg) MsgSubmitMsgExecutionProposal {
+	return MsgSubmitMsgExecutionProposal{
+		MsgSubmitProposal: msgSubmitProposal,
+		Msgs:              msgs,
+	}
+}
+
+func (msg MsgSubmitMsgExecutionProposal) ValidateBasic() sdk.Error {
+	err := msg.MsgSubmitProposal.ValidateBasic()
+	if err != nil {
+		return err
+	}
+	if msg.ProposalType != ProposalTypeMsgExecution {
+		return ErrInvalidProposalType(DefaultCodespace, msg.ProposalType)
+	}
+	return validateExecutionMsgs(msg.Msgs)
+}
+
+// the inner msgs are encoded with their own sign bytes,
+// so msgs of other modules don't need to be registered on msgCdc
+func (msg MsgSubmitMsgExecutionProposal) GetSignBytes() []byte {
+	var msgsBytes []json.RawMessage
+	for _, m := range msg.Msgs {
+		msgsBytes = append(msgsBytes, json.RawMessage(m.GetSignBytes()))
+	}
+	b, err := json.Marshal(struct {
+		MsgSubmitProposal
+		Msgs []json.RawMessage `json:"msgs"`
+	}{
+		MsgSubmitProposal: msg.MsgSubmitProposal,
+		Msgs:              msgsBytes,
+	})
+	if err != nil {
+		panic(err)
+	}
+	return sdk.MustSortJSON(b)
+}
+
+type MsgSubmitGuardianChangeProposal struct {
+	MsgSubmitProposal
+	GuardianChange GuardianChange `json:"guardian_change"`
+}
+
+func NewMsgSubmitGuardianChangeProposal(msgSubmitProposal MsgSubmitProposal, guardianChange GuardianChange) MsgSubmitGuardianChangeProposal {
+	return MsgSubmitGuardianChangeProposal{
+		MsgSubmitProposal: msgSubmitProposal,
+		GuardianChange:    guardianChange,
+	}
+}
+
+func (msg MsgSubmitGuardianChangeProposal) ValidateBasic() sdk.Error {
+	err := msg.MsgSubmitProposal.ValidateBasic()
+	if err != nil {
+		return err
+	}
+	if msg.ProposalType != ProposalTypeGuardianChange {
+		return ErrInvalidProposalType(DefaultCodespace, msg.ProposalType)
+	}
+	return msg.GuardianChange.ValidateBasic()
+}
+
+func (msg MsgSubmitGuardianChangeProposal) GetSignBytes() []byte {
+	b, err := msgCdc.MarshalJSON(msg)
+	if err != nil {
+		panic(err)
+	}
+	return sdk.MustSortJSON(b)
+}
+
+type MsgSubmitSystemHaltProposal struct {
+	MsgSubmitProposal
+	HaltHeight    int64 `json:"halt_height"`    //  Height to halt the chain at, 0 means SystemHaltPeriod blocks after the proposal passes
+	ExportGenesis bool  `json:"export_genesis"` //  Whether every node exports the genesis for zero height at the halt height and then stops
+}
+
+func NewMsgSubmitSystemHaltProposal(msgSubmitProposal MsgSubmitProposal, haltHeight int64, exportGenesis bool) MsgSubmitSystemHaltProposal {
+	return MsgSubmitSystemHaltProposal{
+		MsgSubmitProposal: msgSubmitProposal,
+		HaltHeight:        haltHeight,
+		ExportGenesis:     exportGenesis,
+	}
+}
+
+func (msg MsgSubmitSystemHaltProposal) ValidateBasic() sdk.Error {
+	err := msg.MsgSubmitProposal.ValidateBasic()
+	if err != nil {
+		return err
+	}
+	if msg.ProposalType != ProposalTypeSystemHalt {
+		return ErrInvalidProposalType(DefaultCodespace, msg.ProposalType)
+	}
+	if msg.HaltHeight < 0 {
+		return ErrInvalidHaltHeight(DefaultCodespace, msg.HaltHeight)
+	}
+	return nil
+}
+
+func (msg MsgSubmitSystemHaltProposal) GetSignBytes() []byte {
+	b, err := msgCdc.MarshalJSON(msg)
+	if err != nil {
+		panic(err)
+	}
+	return sdk.MustSortJSON(b)
+}
@@ -17,6 +17,7 @@ const (
 	CRITICAL              = "Critical"
 	IMPORTANT             = "Important"
 	NORMAL                = "Normal"
+	EXPEDITED             = "Expedited"
 	LOWER_BOUND_AMOUNT    = 10
 	UPPER_BOUND_AMOUNT    = 10000
 	STABLE_CRITIACAL_NUM  = 1
@@ -63,6 +64,9 @@ var (
 	KeyNormalPenalty       = []byte(NORMAL + "Penalty")
 
 	KeySystemHaltPeriod = []byte("SystemHaltPeriod")
+
+	KeyExpeditedVotingPeriod = []byte(EXPEDITED + "VotingPeriod")
+	KeyExpeditedThreshold    = []byte(EXPEDITED + "Threshold")
 )
 
 // ParamTable for mint module
@@ -100,10 +104,15 @@ type GovParams struct {
 	NormalPenalty       sdk.Dec       `json:"normal_penalty"`       //  Penalty if validator does not vote
 
 	SystemHaltPeriod int64 `json:"system_halt_period"`
+
+	ExpeditedVotingPeriod time.Duration `json:"expedited_voting_period"` //  Length of the voting period of expedited proposals, must be shorter than the voting period of every level
+	ExpeditedThreshold    sdk.Dec       `json:"expedited_threshold"`     //  Minimum propotion of Yes votes for an expedited proposal to pass, must be no less than the threshold of every level
 }
 
 func (p GovParams) String() string {
 	return fmt.Sprintf(`System Halt Period: %v
+Expedited Voting Period: %v
+Expedited Threshold: %s
 
 Proposal Parameter:    [Critical]    [Important]    [Normal]
   DepositPeriod:        %v    %v    %v
@@ -114,7 +123,7 @@ Proposal Parameter:    [Critical]    [Important]    [Normal]
   Veto:                 %s    %s    %s
   Participation:        %s    %s    %s
   Penalty:              %s    %s    %s
-`, p.SystemHaltPeriod,
+`, p.SystemHaltPeriod, p.ExpeditedVotingPeriod, p.ExpeditedThreshold,
 		p.CriticalDepositPeriod, p.ImportantDepositPeriod, p.NormalDepositPeriod,
 		p.CriticalMinDeposit, p.ImportantMinDeposit, p.NormalMinDeposit,
 		p.CriticalVotingPeriod, p.ImportantVotingPeriod, p.NormalVotingPeriod,
@@ -160,6 +169,18 @@ func (p *GovParams) KeyValuePairs() params.KeyValuePairs {
 		{KeyNormalPenalty, &p.NormalPenalty},
 
 		{KeySystemHaltPeriod, &p.SystemHaltPeriod},
+
+		{KeyExpeditedVotingPeriod, &p.ExpeditedVotingPeriod},
+		{KeyExpeditedThreshold, &p.ExpeditedThreshold},
+	}
+}
+
+// Implements params.UpgradeParamSet
+func (p *GovParams) UpgradeParams() params.KeyValuePairs {
+	defaults := DefaultParams()
+	return params.KeyValuePairs{
+		{KeyExpeditedVotingPeriod, &defaults.ExpeditedVotingPeriod},
+		{KeyExpeditedThreshold, &defaults.ExpeditedThreshold},
 	}
 }
 
@@ -247,6 +268,13 @@ func (p *GovParams) StringFromBytes(cdc *codec.Codec, key string, bytes []byte)
 	case string(KeySystemHaltPeriod):
 		err := cdc.UnmarshalJSON(bytes, &p.SystemHaltPeriod)
 		return strconv.FormatInt(p.SystemHaltPeriod, 10), err
+
+	case string(KeyExpeditedVotingPeriod):
+		err := cdc.UnmarshalJSON(bytes, &p.ExpeditedVotingPeriod)
+		return p.ExpeditedVotingPeriod.String(), err
+	case string(KeyExpeditedThreshold):
+		err := cdc.UnmarshalJSON(bytes, &p.ExpeditedThreshold)
+		return p.ExpeditedThreshold.String(), err
 	default:
 		return "", fmt.Errorf("%s is not existed", key)
 	}
@@ -287,6 +315,9 @@ func DefaultParams() GovParams {
 			NormalParticipation: sdk.NewDecWithPrec(75, 2),
 			NormalPenalty:       sdk.ZeroDec(),
 			SystemHaltPeriod:    20000,
+
+			ExpeditedVotingPeriod: time.Duration(sdk.Day),
+			ExpeditedThreshold:    sdk.NewDecWithPrec(9, 1),
 		}
 	} else {
 		return GovParams{
@@ -317,6 +348,9 @@ func DefaultParams() GovParams {
 			NormalParticipation: sdk.NewDecWithPrec(75, 2),
 			NormalPenalty:       sdk.ZeroDec(),
 			SystemHaltPeriod:    60,
+
+			ExpeditedVotingPeriod: time.Duration(time.Minute),
+			ExpeditedThreshold:    sdk.NewDecWithPrec(9, 1),
 		}
 	}
 }
@@ -354,6 +388,9 @@ func DefaultParamsForTest() GovParams {
 		NormalParticipation: sdk.NewDecWithPrec(75, 2),
 		NormalPenalty:       sdk.ZeroDec(),
 		SystemHaltPeriod:    60,
+
+		ExpeditedVotingPeriod: time.Duration(20 * time.Second),
+		ExpeditedThreshold:    sdk.NewDecWithPrec(9, 1),
 	}
 }
 
@@ -432,6 +469,10 @@ func validateParams(p GovParams) sdk.Error {
 		return sdk.NewError(params.DefaultCodespace, params.CodeInvalidSystemHaltPeriod, fmt.Sprintf("SystemHaltPeriod should be between [0, 50000]"))
 	}
 
+	if err := validateExpedited(p); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -518,3 +559,25 @@ func validateMaxNum(gp GovParams) sdk.Error {
 	}
 	return nil
 }
+
+func validateExpedited(gp GovParams) sdk.Error {
+	if err := validatorVotingProcedure(VotingProcedure{
+		VotingPeriod: gp.ExpeditedVotingPeriod,
+	}, EXPEDITED); err != nil {
+		return err
+	}
+	if gp.ExpeditedVotingPeriod >= gp.CriticalVotingPeriod ||
+		gp.ExpeditedVotingPeriod >= gp.ImportantVotingPeriod ||
+		gp.ExpeditedVotingPeriod >= gp.NormalVotingPeriod {
+		return sdk.NewError(params.DefaultCodespace, params.CodeInvalidVotingPeriod, fmt.Sprintf(EXPEDITED+"VotingPeriod (%s) should be shorter than the VotingPeriod of every level", gp.ExpeditedVotingPeriod.String()))
+	}
+	if gp.ExpeditedThreshold.IsNil() || gp.ExpeditedThreshold.LTE(sdk.ZeroDec()) || gp.ExpeditedThreshold.GTE(sdk.NewDec(1)) {
+		return sdk.NewError(params.DefaultCodespace, params.CodeInvalidThreshold, fmt.Sprintf("Invalid "+EXPEDITED+" Threshold should be (0,1)"))
+	}
+	if gp.ExpeditedThreshold.LT(gp.CriticalThreshold) ||
+		gp.ExpeditedThreshold.LT(gp.ImportantThreshold) ||
+		gp.ExpeditedThreshold.LT(gp.NormalThreshold) {
+		return sdk.NewError(params.DefaultCodespace, params.CodeInvalidThreshold, fmt.Sprintf("Invalid "+EXPEDITED+" Threshold ( "+gp.ExpeditedThreshold.String()+" ) should be no less than the Threshold of every level"))
+	}
+	return nil
+}
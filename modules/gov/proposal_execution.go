@@ -0,0 +1,77 @@
+package gov
+
+import (
+	"fmt"
+
+	sdk "github.com/NPC-Chain/npcchub/types"
+)
+
+// the maximum number of msgs in a MsgExecutionProposal
+const MaxExecutionMsgs = 10
+
+var _ Proposal = (*MsgExecutionProposal)(nil)
+
+// MsgExecutionProposal carries msgs which are executed by GovModuleAccAddr once the proposal passes
+type MsgExecutionProposal struct {
+	BasicProposal
+	Msgs []sdk.Msg `json:"msgs"`
+}
+
+// validateExecutionMsgs checks the msgs can be executed on behalf of the gov module account
+func validateExecutionMsgs(msgs []sdk.Msg) sdk.Error {
+	if len(msgs) == 0 {
+		return ErrInvalidExecutionMsg(DefaultCodespace, "msgs can't be empty")
+	}
+	if len(msgs) > MaxExecutionMsgs {
+		return ErrInvalidExecutionMsg(DefaultCodespace, fmt.Sprintf("the number of msgs can't be more than %d", MaxExecutionMsgs))
+	}
+	for _, msg := range msgs {
+		if msg == nil {
+			return ErrInvalidExecutionMsg(DefaultCodespace, "msg can't be nil")
+		}
+		if msg.Route() == MsgRoute {
+			return ErrInvalidExecutionMsg(DefaultCodespace, fmt.Sprintf("%s msgs can't be executed by a proposal", MsgRoute))
+		}
+		if err := msg.ValidateBasic(); err != nil {
+			return err
+		}
+		signers := msg.GetSigners()
+		if len(signers) != 1 || !signers[0].Equals(GovModuleAccAddr) {
+			return ErrInvalidExecutionMsg(DefaultCodespace, fmt.Sprintf("the only signer of %s must be %s", msg.Type(), GovModuleAccAddr))
+		}
+	}
+	return nil
+}
+
+// executeMsgs runs all msgs in a cached context, the changes are written only if every msg succeeds
+func (keeper Keeper) executeMsgs(ctx sdk.Context, msgs []sdk.Msg) (err sdk.Error) {
+	cacheCtx, writeCache := ctx.CacheContext()
+	defer func() {
+		if r := recover(); r != nil {
+			err = sdk.ErrInternal(fmt.Sprintf("failed to execute msgs: %v", r))
+		}
+	}()
+
+	for i, msg := range msgs {
+		handler := keeper.router.Route(msg.Route())
+		if handler == nil {
+			return sdk.ErrUnknownRequest("Unrecognized Msg type: " + msg.Route())
+		}
+		result := handler(cacheCtx, msg)
+		if !result.IsOK() {
+			return ErrInvalidExecutionMsg(keeper.codespace, fmt.Sprintf("msg %d failed: %s", i, result.Log))
+		}
+	}
+	writeCache()
+	return nil
+}
+
+func MsgExecutionProposalExecute(ctx sdk.Context, gk Keeper, mp *MsgExecutionProposal) error {
+	ctx.Logger().Info("Execute MsgExecutionProposal begin", "ProposalID", mp.ProposalID)
+	if err := gk.executeMsgs(ctx, mp.Msgs); err != nil {
+		ctx.Logger().Error("Execute MsgExecutionProposal Failure", "ProposalID", mp.ProposalID, "err", err.Error())
+		return err
+	}
+	ctx.Logger().Info("Execute MsgExecutionProposal Success", "ProposalID", mp.ProposalID)
+	return nil
+}
@@ -0,0 +1,70 @@
+package gov
+
+import (
+	"testing"
+
+	"github.com/NPC-Chain/npcchub/modules/guardian"
+	sdk "github.com/NPC-Chain/npcchub/types"
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+)
+
+func TestMsgExecutionProposalExecute(t *testing.T) {
+	mapp, keeper, _, addrs, _, _ := getMockApp(t, 3)
+	mapp.BeginBlock(abci.RequestBeginBlock{})
+	ctx := mapp.BaseApp.NewContext(false, abci.Header{})
+
+	// only the gov module account can sign the msgs
+	require.Error(t, validateExecutionMsgs([]sdk.Msg{guardian.NewMsgAddTrustee("trustee", addrs[0], addrs[1])}))
+
+	// the gov module account has the authority of a genesis trustee
+	addTrustee := guardian.NewMsgAddTrustee("trustee", addrs[0], GovModuleAccAddr)
+	require.Nil(t, validateExecutionMsgs([]sdk.Msg{addTrustee}))
+	proposal := &MsgExecutionProposal{
+		BasicProposal: BasicProposal{ProposalID: 1, ProposalType: ProposalTypeMsgExecution},
+		Msgs:          []sdk.Msg{addTrustee},
+	}
+	require.NoError(t, executeProposal(ctx, keeper, proposal))
+	trustee, found := keeper.guardianKeeper.GetTrustee(ctx, addrs[0])
+	require.True(t, found)
+	require.Equal(t, GovModuleAccAddr, trustee.AddedBy)
+
+	// a failed msg reverts the msgs executed before it
+	proposal.Msgs = []sdk.Msg{
+		guardian.NewMsgAddTrustee("trustee", addrs[1], GovModuleAccAddr),
+		guardian.NewMsgAddTrustee("trustee", addrs[0], GovModuleAccAddr),
+	}
+	require.Error(t, executeProposal(ctx, keeper, proposal))
+	_, found = keeper.guardianKeeper.GetTrustee(ctx, addrs[1])
+	require.False(t, found)
+
+	// the ordinary guardians added by the gov module account are deleted by it
+	proposal.Msgs = []sdk.Msg{guardian.NewMsgDeleteTrustee(addrs[0], GovModuleAccAddr)}
+	require.NoError(t, executeProposal(ctx, keeper, proposal))
+	_, found = keeper.guardianKeeper.GetTrustee(ctx, addrs[0])
+	require.False(t, found)
+}
+
+func TestExecuteProposalFailure(t *testing.T) {
+	mapp, keeper, _, _, _, _ := getMockApp(t, 1)
+	mapp.BeginBlock(abci.RequestBeginBlock{})
+	ctx := mapp.BaseApp.NewContext(false, abci.Header{})
+
+	// the params are left unchanged by a proposal failing to apply them
+	before := keeper.GetParamSet(ctx)
+	proposal := &ParameterProposal{
+		BasicProposal: BasicProposal{ProposalID: 1, ProposalType: ProposalTypeParameterChange},
+		Params:        Params{{Subspace: DefaultParamSpace, Key: "unknown", Value: "1"}},
+	}
+	require.Error(t, executeProposal(ctx, keeper, proposal))
+	require.Equal(t, before, keeper.GetParamSet(ctx))
+
+	require.Equal(t, StatusFailed, mustProposalStatus(t, "Failed"))
+	require.True(t, ValidProposalStatus(StatusFailed))
+}
+
+func mustProposalStatus(t *testing.T, str string) ProposalStatus {
+	status, err := ProposalStatusFromString(str)
+	require.NoError(t, err)
+	return status
+}
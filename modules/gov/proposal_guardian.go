@@ -0,0 +1,250 @@
+package gov
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/NPC-Chain/npcchub/modules/guardian"
+	sdk "github.com/NPC-Chain/npcchub/types"
+	"github.com/pkg/errors"
+)
+
+// the maximum length of the description of a guardian added by a GuardianChangeProposal
+const MaxGuardianDescriptionLength = 70
+
+type GuardianOp byte
+
+const (
+	GuardianOpAdd    GuardianOp = 0x01
+	GuardianOpDelete GuardianOp = 0x02
+)
+
+// String to GuardianOp byte.  Returns ff if invalid.
+func GuardianOpFromString(str string) (GuardianOp, error) {
+	switch str {
+	case "Add":
+		return GuardianOpAdd, nil
+	case "Delete":
+		return GuardianOpDelete, nil
+	default:
+		return GuardianOp(0xff), errors.Errorf("'%s' is not a valid guardian op", str)
+	}
+}
+
+// is defined GuardianOp?
+func ValidGuardianOp(op GuardianOp) bool {
+	if op == GuardianOpAdd ||
+		op == GuardianOpDelete {
+		return true
+	}
+	return false
+}
+
+// Marshals to JSON using string
+func (op GuardianOp) MarshalJSON() ([]byte, error) {
+	return json.Marshal(op.String())
+}
+
+// Unmarshals from JSON
+func (op *GuardianOp) UnmarshalJSON(data []byte) error {
+	var s string
+	err := json.Unmarshal(data, &s)
+	if err != nil {
+		return err
+	}
+
+	bz2, err := GuardianOpFromString(s)
+	if err != nil {
+		return err
+	}
+	*op = bz2
+	return nil
+}
+
+// Turns GuardianOp byte to String
+func (op GuardianOp) String() string {
+	switch op {
+	case GuardianOpAdd:
+		return "Add"
+	case GuardianOpDelete:
+		return "Delete"
+	default:
+		return ""
+	}
+}
+
+type GuardianRole byte
+
+const (
+	GuardianRoleProfiler GuardianRole = 0x01
+	GuardianRoleTrustee  GuardianRole = 0x02
+)
+
+// String to GuardianRole byte.  Returns ff if invalid.
+func GuardianRoleFromString(str string) (GuardianRole, error) {
+	switch str {
+	case "Profiler":
+		return GuardianRoleProfiler, nil
+	case "Trustee":
+		return GuardianRoleTrustee, nil
+	default:
+		return GuardianRole(0xff), errors.Errorf("'%s' is not a valid guardian role", str)
+	}
+}
+
+// is defined GuardianRole?
+func ValidGuardianRole(role GuardianRole) bool {
+	if role == GuardianRoleProfiler ||
+		role == GuardianRoleTrustee {
+		return true
+	}
+	return false
+}
+
+// Marshals to JSON using string
+func (role GuardianRole) MarshalJSON() ([]byte, error) {
+	return json.Marshal(role.String())
+}
+
+// Unmarshals from JSON
+func (role *GuardianRole) UnmarshalJSON(data []byte) error {
+	var s string
+	err := json.Unmarshal(data, &s)
+	if err != nil {
+		return err
+	}
+
+	bz2, err := GuardianRoleFromString(s)
+	if err != nil {
+		return err
+	}
+	*role = bz2
+	return nil
+}
+
+// Turns GuardianRole byte to String
+func (role GuardianRole) String() string {
+	switch role {
+	case GuardianRoleProfiler:
+		return "Profiler"
+	case GuardianRoleTrustee:
+		return "Trustee"
+	default:
+		return ""
+	}
+}
+
+// GuardianChange adds a guardian to or deletes a guardian from the profilers or the trustees
+type GuardianChange struct {
+	Op          GuardianOp     `json:"op"`
+	Role        GuardianRole   `json:"role"`
+	Address     sdk.AccAddress `json:"address"`
+	Description string         `json:"description"`
+}
+
+func (gc GuardianChange) String() string {
+	return fmt.Sprintf("%s %s %s (%s)", gc.Op, gc.Role, gc.Address, gc.Description)
+}
+
+// ValidateBasic checks the stateless fields of a GuardianChange
+func (gc GuardianChange) ValidateBasic() sdk.Error {
+	if !ValidGuardianOp(gc.Op) {
+		return ErrInvalidGuardianChange(DefaultCodespace, fmt.Sprintf("invalid op %v", byte(gc.Op)))
+	}
+	if !ValidGuardianRole(gc.Role) {
+		return ErrInvalidGuardianChange(DefaultCodespace, fmt.Sprintf("invalid role %v", byte(gc.Role)))
+	}
+	if len(gc.Address) == 0 {
+		return sdk.ErrInvalidAddress(gc.Address.String())
+	}
+	if len(gc.Description) > MaxGuardianDescriptionLength {
+		return sdk.ErrInvalidLength(DefaultCodespace, CodeInvalidGuardianChange, "description", len(gc.Description), MaxGuardianDescriptionLength)
+	}
+	return nil
+}
+
+// Implements Proposal Interface
+var _ Proposal = (*GuardianChangeProposal)(nil)
+
+type GuardianChangeProposal struct {
+	BasicProposal
+	GuardianChange GuardianChange `json:"guardian_change"`
+}
+
+func (gp GuardianChangeProposal) String() string {
+	return fmt.Sprintf("%s\n  Guardian Change:    %s", gp.BasicProposal.String(), gp.GuardianChange)
+}
+
+// validateGuardianChange checks a GuardianChange against the current guardians, genesis guardians
+// can be deleted too but the last profiler or trustee and the last genesis one can't
+func (keeper Keeper) validateGuardianChange(ctx sdk.Context, gc GuardianChange) sdk.Error {
+	var deleted guardian.Guardian
+	var found bool
+	var iterator sdk.Iterator
+	if gc.Role == GuardianRoleProfiler {
+		deleted, found = keeper.guardianKeeper.GetProfiler(ctx, gc.Address)
+		iterator = keeper.guardianKeeper.ProfilersIterator(ctx)
+	} else {
+		deleted, found = keeper.guardianKeeper.GetTrustee(ctx, gc.Address)
+		iterator = keeper.guardianKeeper.TrusteesIterator(ctx)
+	}
+	defer iterator.Close()
+
+	if gc.Op == GuardianOpAdd {
+		if found {
+			return ErrInvalidGuardianChange(keeper.codespace, fmt.Sprintf("%s %s already exists", gc.Role, gc.Address))
+		}
+		return nil
+	}
+
+	if !found {
+		return ErrInvalidGuardianChange(keeper.codespace, fmt.Sprintf("%s %s doesn't exist", gc.Role, gc.Address))
+	}
+	count, genesisCount := 0, 0
+	for ; iterator.Valid(); iterator.Next() {
+		var g guardian.Guardian
+		keeper.cdc.MustUnmarshalBinaryLengthPrefixed(iterator.Value(), &g)
+		count++
+		if g.AccountType == guardian.Genesis {
+			genesisCount++
+		}
+	}
+	if count < 2 {
+		return ErrInvalidGuardianChange(keeper.codespace, fmt.Sprintf("can't delete the last %s %s", gc.Role, gc.Address))
+	}
+	// the genesis guardians are the only ones adding and deleting guardians by msgs
+	if deleted.AccountType == guardian.Genesis && genesisCount < 2 {
+		return ErrInvalidGuardianChange(keeper.codespace, fmt.Sprintf("can't delete the last genesis %s %s", gc.Role, gc.Address))
+	}
+	return nil
+}
+
+func GuardianChangeProposalExecute(ctx sdk.Context, gk Keeper, gp *GuardianChangeProposal) error {
+	gc := gp.GuardianChange
+	ctx.Logger().Info("Execute GuardianChangeProposal begin", "ProposalID", gp.ProposalID, "GuardianChange", gc.String())
+
+	// the guardians may have changed since the proposal was submitted
+	if err := gk.validateGuardianChange(ctx, gc); err != nil {
+		ctx.Logger().Error("Execute GuardianChangeProposal Failure", "ProposalID", gp.ProposalID, "err", err.Error())
+		return err
+	}
+
+	var err sdk.Error
+	switch {
+	case gc.Op == GuardianOpAdd && gc.Role == GuardianRoleProfiler:
+		err = gk.guardianKeeper.AddProfiler(ctx, guardian.NewGuardian(gc.Description, guardian.Ordinary, gc.Address, GovModuleAccAddr))
+	case gc.Op == GuardianOpAdd && gc.Role == GuardianRoleTrustee:
+		err = gk.guardianKeeper.AddTrustee(ctx, guardian.NewGuardian(gc.Description, guardian.Ordinary, gc.Address, GovModuleAccAddr))
+	case gc.Op == GuardianOpDelete && gc.Role == GuardianRoleProfiler:
+		err = gk.guardianKeeper.DeleteProfiler(ctx, gc.Address)
+	case gc.Op == GuardianOpDelete && gc.Role == GuardianRoleTrustee:
+		err = gk.guardianKeeper.DeleteTrustee(ctx, gc.Address)
+	}
+	if err != nil {
+		ctx.Logger().Error("Execute GuardianChangeProposal Failure", "ProposalID", gp.ProposalID, "err", err.Error())
+		return err
+	}
+
+	ctx.Logger().Info("Execute GuardianChangeProposal Success", "ProposalID", gp.ProposalID)
+	return nil
+}
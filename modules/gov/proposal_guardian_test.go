@@ -0,0 +1,63 @@
+package gov
+
+import (
+	"testing"
+
+	"github.com/NPC-Chain/npcchub/modules/guardian"
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+)
+
+func TestValidateGuardianChange(t *testing.T) {
+	mapp, keeper, _, addrs, _, _ := getMockApp(t, 3)
+	mapp.BeginBlock(abci.RequestBeginBlock{})
+	ctx := mapp.BaseApp.NewContext(false, abci.Header{})
+
+	require.Nil(t, keeper.guardianKeeper.AddProfiler(ctx, guardian.NewGuardian("genesis", guardian.Genesis, addrs[0], addrs[0])))
+	require.Nil(t, keeper.guardianKeeper.AddProfiler(ctx, guardian.NewGuardian("ordinary", guardian.Ordinary, addrs[1], addrs[0])))
+	require.Nil(t, keeper.guardianKeeper.AddTrustee(ctx, guardian.NewGuardian("genesis", guardian.Genesis, addrs[0], addrs[0])))
+
+	deleteProfiler := func(i int) GuardianChange {
+		return GuardianChange{Op: GuardianOpDelete, Role: GuardianRoleProfiler, Address: addrs[i]}
+	}
+	require.NotNil(t, keeper.validateGuardianChange(ctx, GuardianChange{Op: GuardianOpAdd, Role: GuardianRoleProfiler, Address: addrs[1]}))
+	require.Nil(t, keeper.validateGuardianChange(ctx, GuardianChange{Op: GuardianOpAdd, Role: GuardianRoleProfiler, Address: addrs[2]}))
+	require.NotNil(t, keeper.validateGuardianChange(ctx, deleteProfiler(2)))
+	require.Nil(t, keeper.validateGuardianChange(ctx, deleteProfiler(1)))
+
+	// the last genesis profiler is kept, even though an ordinary one remains
+	require.NotNil(t, keeper.validateGuardianChange(ctx, deleteProfiler(0)))
+	require.Nil(t, keeper.guardianKeeper.AddProfiler(ctx, guardian.NewGuardian("genesis", guardian.Genesis, addrs[2], addrs[2])))
+	require.Nil(t, keeper.validateGuardianChange(ctx, deleteProfiler(0)))
+
+	// the last trustee is kept
+	require.NotNil(t, keeper.validateGuardianChange(ctx, GuardianChange{Op: GuardianOpDelete, Role: GuardianRoleTrustee, Address: addrs[0]}))
+}
+
+func TestGuardianChangeProposalExecute(t *testing.T) {
+	mapp, keeper, _, addrs, _, _ := getMockApp(t, 2)
+	mapp.BeginBlock(abci.RequestBeginBlock{})
+	ctx := mapp.BaseApp.NewContext(false, abci.Header{})
+	require.Nil(t, keeper.guardianKeeper.AddTrustee(ctx, guardian.NewGuardian("genesis", guardian.Genesis, addrs[0], addrs[0])))
+
+	proposal := &GuardianChangeProposal{
+		BasicProposal:  BasicProposal{ProposalID: 1, ProposalType: ProposalTypeGuardianChange},
+		GuardianChange: GuardianChange{Op: GuardianOpAdd, Role: GuardianRoleTrustee, Address: addrs[1], Description: "trustee"},
+	}
+	require.NoError(t, executeProposal(ctx, keeper, proposal))
+	trustee, found := keeper.guardianKeeper.GetTrustee(ctx, addrs[1])
+	require.True(t, found)
+	require.Equal(t, guardian.Ordinary, trustee.AccountType)
+	require.Equal(t, GovModuleAccAddr, trustee.AddedBy)
+
+	// the genesis trustee is the last one, the ordinary trustee doesn't replace it
+	proposal.GuardianChange = GuardianChange{Op: GuardianOpDelete, Role: GuardianRoleTrustee, Address: addrs[0]}
+	require.Error(t, executeProposal(ctx, keeper, proposal))
+	_, found = keeper.guardianKeeper.GetTrustee(ctx, addrs[0])
+	require.True(t, found)
+
+	proposal.GuardianChange = GuardianChange{Op: GuardianOpDelete, Role: GuardianRoleTrustee, Address: addrs[1]}
+	require.NoError(t, executeProposal(ctx, keeper, proposal))
+	_, found = keeper.guardianKeeper.GetTrustee(ctx, addrs[1])
+	require.False(t, found)
+}
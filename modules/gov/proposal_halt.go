@@ -1,7 +1,19 @@
 package gov
 
+import "fmt"
+
 var _ Proposal = (*SystemHaltProposal)(nil)
 
+// SystemHaltProposal halts the chain SystemHaltPeriod blocks after it passes, or at HaltHeight if it is set.
+// If ExportGenesis is set, every node exports the genesis for zero height at the halt height and then stops
 type SystemHaltProposal struct {
 	BasicProposal
+	HaltHeight    int64 `json:"halt_height"`
+	ExportGenesis bool  `json:"export_genesis"`
+}
+
+func (sp SystemHaltProposal) String() string {
+	return fmt.Sprintf(`%s
+  Halt Height:        %d
+  Export Genesis:     %v`, sp.BasicProposal.String(), sp.HaltHeight, sp.ExportGenesis)
 }
@@ -0,0 +1,81 @@
+package gov
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/NPC-Chain/npcchub/modules/guardian"
+	sdk "github.com/NPC-Chain/npcchub/types"
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	tmstate "github.com/tendermint/tendermint/state"
+)
+
+func TestSubmitSystemHaltProposal(t *testing.T) {
+	mapp, keeper, _, addrs, _, _ := getMockApp(t, 2)
+	mapp.BeginBlock(abci.RequestBeginBlock{})
+	ctx := mapp.BaseApp.NewContext(false, abci.Header{}).WithBlockHeight(10)
+	require.Nil(t, keeper.guardianKeeper.AddProfiler(ctx, guardian.NewGuardian("profiler", guardian.Genesis, addrs[0], addrs[0])))
+	handler := NewHandler(keeper)
+
+	// lower the deposit of the critical proposals to the balance of the accounts
+	deposit, _ := sdk.IrisCoinType.ConvertToMinDenomCoin(fmt.Sprintf("%d%s", 10, sdk.Iris))
+	govParams := keeper.GetParamSet(ctx)
+	govParams.CriticalMinDeposit = sdk.Coins{deposit}
+	keeper.SetParamSet(ctx, govParams)
+
+	// each proposal is submitted to a cache of the ctx, a single critical proposal is allowed at a time
+	submit := func(msg sdk.Msg) *SystemHaltProposal {
+		cacheCtx, _ := ctx.CacheContext()
+		res := handler(cacheCtx, msg)
+		require.True(t, res.IsOK(), res.Log)
+		proposalID, err := strconv.ParseUint(string(res.Data), 10, 64)
+		require.NoError(t, err)
+		return keeper.GetProposal(cacheCtx, proposalID).(*SystemHaltProposal)
+	}
+
+	// the proposals submitted without the halt height and the genesis export are handled the same way
+	msg := NewMsgSubmitProposal("halt", "halt the chain", ProposalTypeSystemHalt, addrs[0], sdk.Coins{deposit}, nil)
+	proposal := submit(msg)
+	require.Equal(t, int64(0), proposal.HaltHeight)
+	require.False(t, proposal.ExportGenesis)
+	proposal = submit(NewMsgSubmitSystemHaltProposal(msg, 0, false))
+	require.Equal(t, int64(0), proposal.HaltHeight)
+	require.False(t, proposal.ExportGenesis)
+
+	proposal = submit(NewMsgSubmitSystemHaltProposal(msg, 100, true))
+	require.Equal(t, int64(100), proposal.HaltHeight)
+	require.True(t, proposal.ExportGenesis)
+
+	// only the profilers submit SystemHalt proposals, by either msg
+	msg.Proposer = addrs[1]
+	require.False(t, handler(ctx, msg).IsOK())
+	require.False(t, handler(ctx, NewMsgSubmitSystemHaltProposal(msg, 100, true)).IsOK())
+
+	// the halt height must be in the future
+	msg.Proposer = addrs[0]
+	require.False(t, handler(ctx, NewMsgSubmitSystemHaltProposal(msg, 10, true)).IsOK())
+}
+
+func TestSystemHaltProposalExportGenesis(t *testing.T) {
+	mapp, keeper, _, _, _, _ := getMockApp(t, 1)
+	mapp.BeginBlock(abci.RequestBeginBlock{})
+	ctx := mapp.BaseApp.NewContext(false, abci.Header{}).WithBlockHeight(10)
+
+	proposal := &SystemHaltProposal{HaltHeight: 100, ExportGenesis: true}
+	require.NoError(t, SystemHaltProposalExecute(ctx, keeper, proposal))
+	require.Equal(t, int64(100), keeper.GetSystemHaltHeight(ctx))
+	require.True(t, keeper.GetSystemHaltExport(ctx))
+
+	// the export is tagged with the halt at the halt height only
+	resTags := EndBlocker(ctx.WithBlockHeight(99), keeper)
+	_, found := abci.GetTagByKey(resTags.ToKVPairs(), sdk.ExportGenesisTag)
+	require.False(t, found)
+
+	resTags = EndBlocker(ctx.WithBlockHeight(100), keeper)
+	_, found = abci.GetTagByKey(resTags.ToKVPairs(), tmstate.HaltTagKey)
+	require.True(t, found)
+	_, found = abci.GetTagByKey(resTags.ToKVPairs(), sdk.ExportGenesisTag)
+	require.True(t, found)
+}
@@ -1,5 +1,12 @@
 package gov
 
+import (
+	"fmt"
+
+	"github.com/NPC-Chain/npcchub/modules/params"
+	sdk "github.com/NPC-Chain/npcchub/types"
+)
+
 const (
 	Insert string = "insert"
 	Update string = "update"
@@ -20,3 +27,91 @@ type ParameterProposal struct {
 	BasicProposal
 	Params Params `json:"params"`
 }
+
+// ValidateParams applies all the changes to a copy of the current param sets and validates each
+// param on its own and every changed param set as a whole. It returns the changed param sets
+// in the order their subspaces first appear in ps.
+func (keeper Keeper) ValidateParams(ctx sdk.Context, ps Params) ([]params.ParamSet, sdk.Error) {
+	paramSets, _, err := keeper.validateParams(ctx, ps)
+	return paramSets, err
+}
+
+// validateParams returns the changed param sets and the validated value of each param
+func (keeper Keeper) validateParams(ctx sdk.Context, ps Params) ([]params.ParamSet, []interface{}, sdk.Error) {
+	if len(ps) == 0 {
+		return nil, nil, ErrEmptyParam(keeper.codespace)
+	}
+
+	var paramSets []params.ParamSet
+	loaded := make(map[string]params.ParamSet)
+	changed := make(map[string]bool)
+	values := make([]interface{}, len(ps))
+	for i, param := range ps {
+		subspace, found := keeper.paramsKeeper.GetSubspace(param.Subspace)
+		if !found {
+			return nil, nil, ErrInvalidParam(keeper.codespace, param.Subspace)
+		}
+
+		key := fmt.Sprintf("%s/%s", param.Subspace, param.Key)
+		if changed[key] {
+			return nil, nil, ErrDuplicateParam(keeper.codespace, param.Subspace, param.Key)
+		}
+		changed[key] = true
+
+		paramSet, found := loaded[param.Subspace]
+		if !found {
+			// the params keeper returns a new param set, which is changed here only
+			paramSet, found = keeper.paramsKeeper.GetParamSet(param.Subspace)
+			if !found {
+				return nil, nil, ErrInvalidParam(keeper.codespace, param.Subspace)
+			}
+			subspace.GetParamSet(ctx, paramSet)
+			paramSets = append(paramSets, paramSet)
+			loaded[param.Subspace] = paramSet
+		}
+
+		value, err := paramSet.Validate(param.Key, param.Value)
+		if err != nil {
+			return nil, nil, err
+		}
+		if !params.SetParamSetValue(paramSet, param.Key, value) {
+			return nil, nil, ErrInvalidParam(keeper.codespace, key)
+		}
+		values[i] = value
+	}
+
+	for _, paramSet := range paramSets {
+		if validator, ok := paramSet.(params.ParamSetValidator); ok {
+			if err := validator.ValidateParamSet(); err != nil {
+				return nil, nil, err
+			}
+		}
+	}
+	return paramSets, values, nil
+}
+
+// applyParams validates the params and sets them in a cached context, the changes are written only if every param is set
+func (keeper Keeper) applyParams(ctx sdk.Context, ps Params) (err sdk.Error) {
+	_, values, err := keeper.validateParams(ctx, ps)
+	if err != nil {
+		return err
+	}
+
+	cacheCtx, writeCache := ctx.CacheContext()
+	defer func() {
+		if r := recover(); r != nil {
+			err = sdk.ErrInternal(fmt.Sprintf("failed to set params: %v", r))
+		}
+	}()
+
+	for i, param := range ps {
+		subspace, _ := keeper.paramsKeeper.GetSubspace(param.Subspace)
+		subspace.Set(cacheCtx, []byte(param.Key), values[i])
+	}
+	writeCache()
+
+	for i, param := range ps {
+		SetParameterMetrics(keeper.metrics, param.Key, values[i])
+	}
+	return nil
+}
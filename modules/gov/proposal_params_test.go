@@ -0,0 +1,76 @@
+package gov
+
+import (
+	"testing"
+
+	"github.com/NPC-Chain/npcchub/modules/params"
+	"github.com/NPC-Chain/npcchub/modules/slashing"
+	sdk "github.com/NPC-Chain/npcchub/types"
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+)
+
+// setupSlashingParams registers the slashing params, which are validated as a whole, with their default values
+func setupSlashingParams(t *testing.T) (sdk.Context, Keeper, params.Subspace) {
+	mapp, keeper, _, _, _, _ := getMockApp(t, 1)
+	subspace := mapp.ParamsKeeper.Subspace(slashing.DefaultParamspace).WithTypeTable(slashing.ParamTypeTable())
+	mapp.ParamsKeeper.RegisterParamSet(&slashing.Params{})
+
+	mapp.BeginBlock(abci.RequestBeginBlock{})
+	ctx := mapp.BaseApp.NewContext(false, abci.Header{})
+	defaults := slashing.DefaultParams()
+	subspace.SetParamSet(ctx, &defaults)
+	return ctx, keeper, subspace
+}
+
+func TestValidateParams(t *testing.T) {
+	ctx, keeper, _ := setupSlashingParams(t)
+
+	_, err := keeper.ValidateParams(ctx, Params{})
+	require.Error(t, err)
+	_, err = keeper.ValidateParams(ctx, Params{{Subspace: "unknown", Key: "SignedBlocksWindow", Value: "100"}})
+	require.Error(t, err)
+	_, err = keeper.ValidateParams(ctx, Params{{Subspace: slashing.DefaultParamspace, Key: "SignedBlocksWindow", Value: "10"}})
+	require.Error(t, err)
+	_, err = keeper.ValidateParams(ctx, Params{
+		{Subspace: slashing.DefaultParamspace, Key: "SignedBlocksWindow", Value: "100"},
+		{Subspace: slashing.DefaultParamspace, Key: "SignedBlocksWindow", Value: "200"},
+	})
+	require.Error(t, err)
+
+	// each param is valid on its own, but the set allows less than a minute of missed blocks
+	window := Param{Subspace: slashing.DefaultParamspace, Key: "SignedBlocksWindow", Value: "100"}
+	minSigned := Param{Subspace: slashing.DefaultParamspace, Key: "MinSignedPerWindow", Value: "0.9"}
+	_, err = keeper.ValidateParams(ctx, Params{window})
+	require.NoError(t, err)
+	_, err = keeper.ValidateParams(ctx, Params{minSigned})
+	require.NoError(t, err)
+	_, err = keeper.ValidateParams(ctx, Params{window, minSigned})
+	require.Error(t, err)
+
+	// the changes are applied to a copy of the param set
+	paramSets, err := keeper.ValidateParams(ctx, Params{window})
+	require.NoError(t, err)
+	require.Len(t, paramSets, 1)
+	require.Equal(t, int64(100), paramSets[0].(*slashing.Params).SignedBlocksWindow)
+	paramSet, _ := keeper.paramsKeeper.GetParamSet(slashing.DefaultParamspace)
+	require.Equal(t, &slashing.Params{}, paramSet)
+}
+
+func TestApplyParams(t *testing.T) {
+	ctx, keeper, subspace := setupSlashingParams(t)
+	defaults := slashing.DefaultParams()
+
+	// no param is set if the set is invalid
+	window := Param{Subspace: slashing.DefaultParamspace, Key: "SignedBlocksWindow", Value: "100"}
+	minSigned := Param{Subspace: slashing.DefaultParamspace, Key: "MinSignedPerWindow", Value: "0.9"}
+	require.Error(t, keeper.applyParams(ctx, Params{window, minSigned}))
+	var current slashing.Params
+	subspace.GetParamSet(ctx, &current)
+	require.Equal(t, defaults, current)
+
+	require.NoError(t, keeper.applyParams(ctx, Params{window}))
+	subspace.GetParamSet(ctx, &current)
+	require.Equal(t, int64(100), current.SignedBlocksWindow)
+	require.Equal(t, defaults.MinSignedPerWindow, current.MinSignedPerWindow)
+}
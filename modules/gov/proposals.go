@@ -52,6 +52,11 @@ type Proposal interface {
 	GetTaxUsage() TaxUsage
 	SetTaxUsage(TaxUsage)
 
+	GetLevel() ProposalLevel
+
+	IsExpedited() bool
+	SetExpedited(bool)
+
 	String() string
 }
 
@@ -81,7 +86,7 @@ type BasicProposal struct {
 	Description  string       `json:"description"`   //  Description of the proposal
 	ProposalType ProposalKind `json:"proposal_type"` //  Type of proposal. Initial set {PlainTextProposal, SoftwareUpgradeProposal}
 
-	Status      ProposalStatus `json:"proposal_status"` //  Status of the Proposal {Pending, Active, Passed, Rejected}
+	Status      ProposalStatus `json:"proposal_status"` //  Status of the Proposal {Pending, Active, Passed, Rejected, Failed}
 	TallyResult TallyResult    `json:"tally_result"`    //  Result of Tallys
 
 	SubmitTime     time.Time `json:"submit_time"`      //  Time of the block where TxGovSubmitProposal was included
@@ -90,6 +95,9 @@ type BasicProposal struct {
 
 	VotingStartTime time.Time `json:"voting_start_time"` //  Time of the block where MinDeposit was reached. -1 if MinDeposit is not reached
 	VotingEndTime   time.Time `json:"voting_end_time"`   // Time that the VotingPeriod for this proposal will end and votes will be tallied
+
+	Level     ProposalLevel `json:"level"`     //  Level of the proposal {Critical, Important, Normal}
+	Expedited bool          `json:"expedited"` //  Whether the proposal is voted with the expedited voting period and threshold
 }
 
 func (bp BasicProposal) String() string {
@@ -102,10 +110,13 @@ func (bp BasicProposal) String() string {
   Total Deposit:      %s
   Voting Start Time:  %s
   Voting End Time:    %s
+  Level:              %s
+  Expedited:          %v
   Description:        %s`,
 		bp.ProposalID, bp.Title, bp.ProposalType,
 		bp.Status, bp.SubmitTime, bp.DepositEndTime,
-		bp.TotalDeposit.MainUnitString(), bp.VotingStartTime, bp.VotingEndTime, bp.GetDescription(),
+		bp.TotalDeposit.MainUnitString(), bp.VotingStartTime, bp.VotingEndTime,
+		bp.Level, bp.Expedited, bp.GetDescription(),
 	)
 }
 
@@ -117,11 +128,15 @@ func (p Proposals) String() string {
 	if len(p) == 0 {
 		return "[]"
 	}
-	out := "ID - (Status) [Type] [TotalDeposit] Title\n"
+	out := "ID - (Status) [Type] [Level] [TotalDeposit] Title\n"
 	for _, prop := range p {
-		out += fmt.Sprintf("%d - (%s) [%s] [%s] %s\n",
+		level := prop.GetLevel().String()
+		if prop.IsExpedited() {
+			level += "(Expedited)"
+		}
+		out += fmt.Sprintf("%d - (%s) [%s] [%s] [%s] %s\n",
 			prop.GetProposalID(), prop.GetStatus(),
-			prop.GetProposalType(), prop.GetTotalDeposit().MainUnitString(), prop.GetTitle())
+			prop.GetProposalType(), level, prop.GetTotalDeposit().MainUnitString(), prop.GetTitle())
 	}
 	return strings.TrimSpace(out)
 }
@@ -164,6 +179,9 @@ func (tp BasicProposal) GetProtocolDefinition() sdk.ProtocolDefinition {
 func (tp *BasicProposal) SetProtocolDefinition(sdk.ProtocolDefinition) {}
 func (tp BasicProposal) GetTaxUsage() TaxUsage                         { return TaxUsage{} }
 func (tp *BasicProposal) SetTaxUsage(taxUsage TaxUsage)                {}
+func (tp BasicProposal) GetLevel() ProposalLevel                       { return tp.Level }
+func (tp BasicProposal) IsExpedited() bool                             { return tp.Expedited }
+func (tp *BasicProposal) SetExpedited(expedited bool)                  { tp.Expedited = expedited }
 
 //-----------------------------------------------------------
 // ProposalQueue
@@ -182,6 +200,8 @@ const (
 	ProposalTypeSoftwareUpgrade ProposalKind = 0x02
 	ProposalTypeSystemHalt      ProposalKind = 0x03
 	ProposalTypeTxTaxUsage      ProposalKind = 0x04
+	ProposalTypeMsgExecution    ProposalKind = 0x05
+	ProposalTypeGuardianChange  ProposalKind = 0x06
 )
 
 // String to proposalType byte.  Returns ff if invalid.
@@ -195,6 +215,10 @@ func ProposalTypeFromString(str string) (ProposalKind, error) {
 		return ProposalTypeSystemHalt, nil
 	case "TxTaxUsage":
 		return ProposalTypeTxTaxUsage, nil
+	case "MsgExecution":
+		return ProposalTypeMsgExecution, nil
+	case "GuardianChange":
+		return ProposalTypeGuardianChange, nil
 	default:
 		return ProposalKind(0xff), errors.Errorf("'%s' is not a valid proposal type", str)
 	}
@@ -205,7 +229,9 @@ func ValidProposalType(pt ProposalKind) bool {
 	if pt == ProposalTypeParameterChange ||
 		pt == ProposalTypeSoftwareUpgrade ||
 		pt == ProposalTypeSystemHalt ||
-		pt == ProposalTypeTxTaxUsage {
+		pt == ProposalTypeTxTaxUsage ||
+		pt == ProposalTypeMsgExecution ||
+		pt == ProposalTypeGuardianChange {
 		return true
 	}
 	return false
@@ -254,6 +280,10 @@ func (pt ProposalKind) String() string {
 		return "SystemHalt"
 	case ProposalTypeTxTaxUsage:
 		return "TxTaxUsage"
+	case ProposalTypeMsgExecution:
+		return "MsgExecution"
+	case ProposalTypeGuardianChange:
+		return "GuardianChange"
 	default:
 		return ""
 	}
@@ -284,6 +314,7 @@ const (
 	StatusVotingPeriod  ProposalStatus = 0x02
 	StatusPassed        ProposalStatus = 0x03
 	StatusRejected      ProposalStatus = 0x04
+	StatusFailed        ProposalStatus = 0x05 // passed, but failed to execute
 )
 
 // ProposalStatusToString turns a string into a ProposalStatus
@@ -297,6 +328,8 @@ func ProposalStatusFromString(str string) (ProposalStatus, error) {
 		return StatusPassed, nil
 	case "Rejected":
 		return StatusRejected, nil
+	case "Failed":
+		return StatusFailed, nil
 	case "":
 		return StatusNil, nil
 	default:
@@ -309,7 +342,8 @@ func ValidProposalStatus(status ProposalStatus) bool {
 	if status == StatusDepositPeriod ||
 		status == StatusVotingPeriod ||
 		status == StatusPassed ||
-		status == StatusRejected {
+		status == StatusRejected ||
+		status == StatusFailed {
 		return true
 	}
 	return false
@@ -358,6 +392,8 @@ func (status ProposalStatus) String() string {
 		return "Passed"
 	case StatusRejected:
 		return "Rejected"
+	case StatusFailed:
+		return "Failed"
 	default:
 		return ""
 	}
@@ -15,6 +15,8 @@ const (
 	QueryVotes     = "votes"
 	QueryVote      = "vote"
 	QueryTally     = "tally"
+
+	QuerySimulateParams = "simulate_params"
 )
 
 func NewQuerier(keeper Keeper) sdk.Querier {
@@ -34,6 +36,8 @@ func NewQuerier(keeper Keeper) sdk.Querier {
 			return queryVote(ctx, path[1:], req, keeper)
 		case QueryTally:
 			return queryTally(ctx, path[1:], req, keeper)
+		case QuerySimulateParams:
+			return querySimulateParams(ctx, path[1:], req, keeper)
 		default:
 			return nil, sdk.ErrUnknownRequest("unknown gov query endpoint")
 		}
@@ -84,7 +88,7 @@ func queryDeposit(ctx sdk.Context, path []string, req abci.RequestQuery, keeper
 		return nil, ErrUnknownProposal(DefaultCodespace, params.ProposalID)
 	}
 
-	if proposal.GetStatus() == StatusPassed || proposal.GetStatus() == StatusRejected {
+	if proposal.GetStatus() == StatusPassed || proposal.GetStatus() == StatusRejected || proposal.GetStatus() == StatusFailed {
 		return nil, ErrCodeDepositDeleted(DefaultCodespace, params.ProposalID)
 	}
 
@@ -134,6 +138,8 @@ func queryVote(ctx sdk.Context, path []string, req abci.RequestQuery, keeper Kee
 // Params for query 'custom/gov/deposits'
 type QueryDepositsParams struct {
 	ProposalID uint64
+	Page       uint64 // page of the deposits, starting from 1
+	Size       uint16 // deposits per page, 0 returns all of them
 }
 
 // nolint: unparam
@@ -149,12 +155,12 @@ func queryDeposits(ctx sdk.Context, path []string, req abci.RequestQuery, keeper
 		return nil, ErrUnknownProposal(DefaultCodespace, params.ProposalID)
 	}
 
-	if proposal.GetStatus() == StatusPassed || proposal.GetStatus() == StatusRejected {
+	if proposal.GetStatus() == StatusPassed || proposal.GetStatus() == StatusRejected || proposal.GetStatus() == StatusFailed {
 		return nil, ErrCodeDepositDeleted(DefaultCodespace, params.ProposalID)
 	}
 
 	var deposits []Deposit
-	depositsIterator := keeper.GetDeposits(ctx, params.ProposalID)
+	depositsIterator := sdk.PaginatedIterator(keeper.GetDeposits(ctx, params.ProposalID), params.Page, params.Size)
 	defer depositsIterator.Close()
 	for ; depositsIterator.Valid(); depositsIterator.Next() {
 		deposit := Deposit{}
@@ -172,6 +178,8 @@ func queryDeposits(ctx sdk.Context, path []string, req abci.RequestQuery, keeper
 // Params for query 'custom/gov/votes'
 type QueryVotesParams struct {
 	ProposalID uint64
+	Page       uint64 // page of the votes, starting from 1
+	Size       uint16 // votes per page, 0 returns all of them
 }
 
 // nolint: unparam
@@ -189,7 +197,7 @@ func queryVotes(ctx sdk.Context, path []string, req abci.RequestQuery, keeper Ke
 	}
 
 	var votes []Vote
-	votesIterator := keeper.GetVotes(ctx, params.ProposalID)
+	votesIterator := sdk.PaginatedIterator(keeper.GetVotes(ctx, params.ProposalID), params.Page, params.Size)
 	defer votesIterator.Close()
 	for ; votesIterator.Valid(); votesIterator.Next() {
 		vote := Vote{}
@@ -255,7 +263,7 @@ func queryTally(ctx sdk.Context, path []string, req abci.RequestQuery, keeper Ke
 
 	if proposal.GetStatus() == StatusDepositPeriod {
 		tallyResult = EmptyTallyResult()
-	} else if proposal.GetStatus() == StatusPassed || proposal.GetStatus() == StatusRejected {
+	} else if proposal.GetStatus() == StatusPassed || proposal.GetStatus() == StatusRejected || proposal.GetStatus() == StatusFailed {
 		tallyResult = proposal.GetTallyResult()
 	} else {
 		_, tallyResult, _ = tally(ctx, keeper, proposal)
@@ -267,3 +275,28 @@ func queryTally(ctx sdk.Context, path []string, req abci.RequestQuery, keeper Ke
 	}
 	return bz, nil
 }
+
+// Params for query 'custom/gov/simulate_params'
+type QuerySimulateParamsParams struct {
+	Params Params
+}
+
+// nolint: unparam
+func querySimulateParams(ctx sdk.Context, path []string, req abci.RequestQuery, keeper Keeper) (res []byte, err sdk.Error) {
+	var params QuerySimulateParamsParams
+	err2 := keeper.cdc.UnmarshalJSON(req.Data, &params)
+	if err2 != nil {
+		return nil, sdk.ParseParamsErr(err2)
+	}
+
+	paramSets, err := keeper.ValidateParams(ctx, params.Params)
+	if err != nil {
+		return nil, err
+	}
+
+	bz, err2 := codec.MarshalJSONIndent(keeper.cdc, paramSets)
+	if err2 != nil {
+		return nil, sdk.MarshalResultErr(err2)
+	}
+	return bz, nil
+}
@@ -12,6 +12,9 @@ var (
 	ActionProposalDropped  = []byte("proposal-dropped")
 	ActionProposalPassed   = []byte("proposal-passed")
 	ActionProposalRejected = []byte("proposal-rejected")
+	ActionProposalFailed   = []byte("proposal-failed")
+
+	ActionProposalExpeditedFallback = []byte("proposal-expedited-fallback")
 
 	Action            = sdk.TagAction
 	Proposer          = "proposer"
@@ -23,4 +26,8 @@ var (
 	Usage             = "usage"
 	Percent           = "percent"
 	DestAddress       = "dest-address"
+	GuardianOp        = "guardian-op"
+	GuardianRole      = "guardian-role"
+	GuardianAddress   = "guardian-address"
+	HaltHeight        = "halt-height"
 )
@@ -16,7 +16,6 @@ const (
 type validatorGovInfo struct {
 	Address sdk.ValAddress // address of the validator operator
 	Power   sdk.Dec        // Power of a Validator
-	Vote    VoteOption     // Vote of the validator
 }
 
 func tally(ctx sdk.Context, keeper Keeper, proposal Proposal) (result ProposalResult, tallyResults TallyResult, votingVals map[string]bool) {
@@ -34,7 +33,6 @@ func tally(ctx sdk.Context, keeper Keeper, proposal Proposal) (result ProposalRe
 		currValidators[validator.GetOperator().String()] = validatorGovInfo{
 			Address: validator.GetOperator(),
 			Power:   validator.GetPower(),
-			Vote:    OptionEmpty,
 		}
 		systemVotingPower = systemVotingPower.Add(validator.GetPower())
 		return false
@@ -46,11 +44,12 @@ func tally(ctx sdk.Context, keeper Keeper, proposal Proposal) (result ProposalRe
 		vote := &Vote{}
 		keeper.cdc.MustUnmarshalBinaryLengthPrefixed(votesIterator.Value(), vote)
 
-		// if validator, just record it in the map
+		// if validator, distribute its power across the options of the vote
 		valAddrStr := sdk.ValAddress(vote.Voter).String()
 		if val, ok := currValidators[valAddrStr]; ok {
-			val.Vote = vote.Option
-			results[val.Vote] = results[val.Vote].Add(val.Power)
+			for _, wo := range vote.GetOptions() {
+				results[wo.Option] = results[wo.Option].Add(val.Power.Mul(wo.Weight))
+			}
 			totalVotingPower = totalVotingPower.Add(val.Power)
 			votingVals[valAddrStr] = true
 		}
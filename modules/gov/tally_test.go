@@ -0,0 +1,77 @@
+package gov
+
+import (
+	"testing"
+
+	"github.com/NPC-Chain/npcchub/modules/stake"
+	stakeTypes "github.com/NPC-Chain/npcchub/modules/stake/types"
+	sdk "github.com/NPC-Chain/npcchub/types"
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+)
+
+func TestWeightedVoteOptionsValidate(t *testing.T) {
+	options, err := WeightedVoteOptionsFromString("Yes=0.7,No=0.3")
+	require.NoError(t, err)
+	require.Nil(t, options.Validate(DefaultCodespace))
+
+	for _, str := range []string{"Yes=0.7,No=0.2", "Yes=0.7,No=0.4", "Yes=1,No=0", "Yes=1.5,No=-0.5", "Yes=0.5,Yes=0.5"} {
+		options, err := WeightedVoteOptionsFromString(str)
+		require.NoError(t, err)
+		require.NotNil(t, options.Validate(DefaultCodespace), str)
+		require.NotNil(t, NewMsgVoteWeighted(sdk.AccAddress([]byte("voter")), 1, options).ValidateBasic(), str)
+	}
+	require.NotNil(t, WeightedVoteOptions{}.Validate(DefaultCodespace))
+}
+
+func TestTallyWeightedVotes(t *testing.T) {
+	mapp, keeper, sk, addrs, pubKeys, _ := getMockApp(t, 3)
+	mapp.BeginBlock(abci.RequestBeginBlock{})
+	ctx := mapp.BaseApp.NewContext(false, abci.Header{})
+
+	// three validators with the same power
+	stakeHandler := stake.NewHandler(sk)
+	commission := stake.NewCommissionMsg(sdk.ZeroDec(), sdk.ZeroDec(), sdk.ZeroDec())
+	for i := range addrs {
+		msg := stake.NewMsgCreateValidator(sdk.ValAddress(addrs[i]), pubKeys[i],
+			sdk.NewCoin(stakeTypes.StakeDenom, sdk.NewIntWithDecimal(100, 18)), stake.Description{Moniker: "validator"}, commission)
+		require.True(t, stakeHandler(ctx, msg).IsOK())
+	}
+	stake.EndBlocker(ctx, sk)
+	power := sdk.NewDec(100)
+
+	tallyVotes := func(votes ...WeightedVoteOptions) (ProposalResult, TallyResult) {
+		proposal := keeper.NewSystemHaltProposal(ctx, "halt", "halt the chain", ProposalTypeSystemHalt, false, 0, false)
+		keeper.activateVotingPeriod(ctx, proposal)
+		for i, options := range votes {
+			require.Nil(t, keeper.AddWeightedVote(ctx, proposal.GetProposalID(), addrs[i], options))
+		}
+		result, tallyResults, _ := tally(ctx, keeper, proposal)
+		return result, tallyResults
+	}
+	weighted := func(str string) WeightedVoteOptions {
+		options, err := WeightedVoteOptionsFromString(str)
+		require.NoError(t, err)
+		return options
+	}
+
+	// the voting power of each validator is split across the options of its vote
+	result, tallyResults := tallyVotes(weighted("Yes=1"), weighted("Yes=0.9,No=0.1"), weighted("Yes=0.8,Abstain=0.2"))
+	require.Equal(t, PASS, result)
+	require.True(t, tallyResults.Yes.Equal(power.Mul(sdk.NewDecWithPrec(27, 1))))
+	require.True(t, tallyResults.No.Equal(power.Mul(sdk.NewDecWithPrec(1, 1))))
+	require.True(t, tallyResults.Abstain.Equal(power.Mul(sdk.NewDecWithPrec(2, 1))))
+	require.True(t, tallyResults.NoWithVeto.IsZero())
+
+	// half of the voting power vetoes the proposal
+	result, tallyResults = tallyVotes(weighted("NoWithVeto=1"), weighted("Yes=0.5,NoWithVeto=0.5"), weighted("Yes=1"))
+	require.Equal(t, REJECTVETO, result)
+	require.True(t, tallyResults.NoWithVeto.Equal(power.Mul(sdk.NewDecWithPrec(15, 1))))
+
+	// the weights must sum up to 1
+	proposal := keeper.NewSystemHaltProposal(ctx, "halt", "halt the chain", ProposalTypeSystemHalt, false, 0, false)
+	keeper.activateVotingPeriod(ctx, proposal)
+	require.NotNil(t, keeper.AddWeightedVote(ctx, proposal.GetProposalID(), addrs[0], weighted("Yes=0.6,No=0.6")))
+	_, found := keeper.GetVote(ctx, proposal.GetProposalID(), addrs[0])
+	require.False(t, found)
+}
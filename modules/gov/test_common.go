@@ -11,12 +11,11 @@ import (
 	"github.com/tendermint/tendermint/crypto"
 
 	"fmt"
+	"github.com/NPC-Chain/npcchub/app/protocol"
 	"github.com/NPC-Chain/npcchub/mock"
-	"github.com/NPC-Chain/npcchub/modules/auth"
 	"github.com/NPC-Chain/npcchub/modules/bank"
 	"github.com/NPC-Chain/npcchub/modules/distribution"
 	"github.com/NPC-Chain/npcchub/modules/guardian"
-	"github.com/NPC-Chain/npcchub/modules/params"
 	"github.com/NPC-Chain/npcchub/modules/stake"
 	sdk "github.com/NPC-Chain/npcchub/types"
 )
@@ -26,21 +25,14 @@ func getMockApp(t *testing.T, numGenAccs int) (*mock.App, Keeper, stake.Keeper,
 	mapp := mock.NewApp()
 
 	stake.RegisterCodec(mapp.Cdc)
+	guardian.RegisterCodec(mapp.Cdc)
 	RegisterCodec(mapp.Cdc)
 
 	keyGov := sdk.NewKVStoreKey("gov")
 	keyDistr := sdk.NewKVStoreKey("distr")
 
-	paramsKeeper := params.NewKeeper(
-		mapp.Cdc,
-		sdk.NewKVStoreKey("params"),
-		sdk.NewTransientStoreKey("transient_params"),
-	)
-	feeKeeper := auth.NewFeeKeeper(
-		mapp.Cdc,
-		sdk.NewKVStoreKey("fee"),
-		paramsKeeper.Subspace(auth.DefaultParamSpace),
-	)
+	paramsKeeper := mapp.ParamsKeeper
+	feeKeeper := mapp.FeeKeeper
 
 	ck := bank.NewBaseKeeper(mapp.AccountKeeper)
 	sk := stake.NewKeeper(
@@ -50,15 +42,17 @@ func getMockApp(t *testing.T, numGenAccs int) (*mock.App, Keeper, stake.Keeper,
 		stake.DefaultCodespace,
 		stake.NopMetrics())
 	dk := distribution.NewKeeper(mapp.Cdc, keyDistr, paramsKeeper.Subspace(distribution.DefaultParamspace), ck, sk, feeKeeper, DefaultCodespace, distribution.NopMetrics())
-	guardianKeeper := guardian.NewKeeper(mapp.Cdc, sdk.NewKVStoreKey("guardian"), guardian.DefaultCodespace)
-	gk := NewKeeper(keyGov, mapp.Cdc, paramsKeeper.Subspace(DefaultParamSpace), paramsKeeper, sdk.NewProtocolKeeper(sdk.NewKVStoreKey("main")), ck, dk, guardianKeeper, sk, DefaultCodespace, NopMetrics())
+	guardianKeeper := guardian.NewKeeper(mapp.Cdc, mapp.KeyGuardian, guardian.DefaultCodespace)
+	// the router of the msgs executed by the proposals
+	router := protocol.NewRouter().AddRoute(guardian.MsgType, guardian.NewHandler(guardianKeeper))
+	gk := NewKeeper(keyGov, mapp.Cdc, paramsKeeper.Subspace(DefaultParamSpace), paramsKeeper, sdk.NewProtocolKeeper(mapp.KeyMain), ck, dk, guardianKeeper, sk, router, DefaultCodespace, NopMetrics())
 
 	mapp.Router().AddRoute("gov", []*sdk.KVStoreKey{keyGov}, NewHandler(gk))
 
 	mapp.SetEndBlocker(getEndBlocker(gk))
 	mapp.SetInitChainer(getInitChainer(mapp, gk, sk))
 
-	require.NoError(t, mapp.CompleteSetup(keyGov))
+	require.NoError(t, mapp.CompleteSetup(keyGov, keyDistr))
 
 	coin, _ := sdk.IrisCoinType.ConvertToMinDenomCoin(fmt.Sprintf("%d%s", 1042, sdk.Iris))
 	genAccs, addrs, pubKeys, privKeys := mock.CreateGenAccounts(numGenAccs, sdk.Coins{coin})
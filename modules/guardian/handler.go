@@ -23,7 +23,7 @@ func NewHandler(k Keeper) sdk.Handler {
 }
 
 func handleMsgAddProfiler(ctx sdk.Context, k Keeper, msg MsgAddProfiler) sdk.Result {
-	if profiler, found := k.GetProfiler(ctx, msg.AddedBy); !found || profiler.AccountType != Genesis {
+	if !k.IsGenesisProfiler(ctx, msg.AddedBy) {
 		return ErrInvalidOperator(DefaultCodespace, msg.AddedBy).Result()
 	}
 	if _, found := k.GetProfiler(ctx, msg.Address); found {
@@ -38,7 +38,7 @@ func handleMsgAddProfiler(ctx sdk.Context, k Keeper, msg MsgAddProfiler) sdk.Res
 }
 
 func handleMsgAddTrustee(ctx sdk.Context, k Keeper, msg MsgAddTrustee) sdk.Result {
-	if trustee, found := k.GetTrustee(ctx, msg.AddedBy); !found || trustee.AccountType != Genesis {
+	if !k.IsGenesisTrustee(ctx, msg.AddedBy) {
 		return ErrInvalidOperator(DefaultCodespace, msg.AddedBy).Result()
 	}
 	if _, found := k.GetTrustee(ctx, msg.Address); found {
@@ -53,7 +53,7 @@ func handleMsgAddTrustee(ctx sdk.Context, k Keeper, msg MsgAddTrustee) sdk.Resul
 }
 
 func handleMsgDeleteProfiler(ctx sdk.Context, k Keeper, msg MsgDeleteProfiler) sdk.Result {
-	if profiler, found := k.GetProfiler(ctx, msg.DeletedBy); !found || profiler.AccountType != Genesis {
+	if !k.IsGenesisProfiler(ctx, msg.DeletedBy) {
 		return ErrInvalidOperator(DefaultCodespace, msg.DeletedBy).Result()
 	}
 	profiler, found := k.GetProfiler(ctx, msg.Address)
@@ -72,7 +72,7 @@ func handleMsgDeleteProfiler(ctx sdk.Context, k Keeper, msg MsgDeleteProfiler) s
 }
 
 func handleMsgDeleteTrustee(ctx sdk.Context, k Keeper, msg MsgDeleteTrustee) sdk.Result {
-	if trustee, found := k.GetTrustee(ctx, msg.DeletedBy); !found || trustee.AccountType != Genesis {
+	if !k.IsGenesisTrustee(ctx, msg.DeletedBy) {
 		return ErrInvalidOperator(DefaultCodespace, msg.DeletedBy).Result()
 	}
 	trustee, found := k.GetTrustee(ctx, msg.Address)
@@ -3,8 +3,13 @@ package guardian
 import (
 	"github.com/NPC-Chain/npcchub/codec"
 	sdk "github.com/NPC-Chain/npcchub/types"
+	"github.com/tendermint/tendermint/crypto"
 )
 
+// GovModuleAccAddr is the address of the gov module account, which executes the msgs of the
+// passed proposals with the authority of a genesis profiler and trustee
+var GovModuleAccAddr = sdk.AccAddress(crypto.AddressHash([]byte("govModule")))
+
 type Keeper struct {
 	storeKey sdk.StoreKey
 	cdc      *codec.Codec
@@ -46,6 +51,24 @@ func (k Keeper) GetProfiler(ctx sdk.Context, addr sdk.AccAddress) (guardian Guar
 	return guardian, false
 }
 
+// Checks the address is a profiler or the gov module account
+func (k Keeper) IsProfiler(ctx sdk.Context, addr sdk.AccAddress) bool {
+	if addr.Equals(GovModuleAccAddr) {
+		return true
+	}
+	_, found := k.GetProfiler(ctx, addr)
+	return found
+}
+
+// Checks the address is a genesis profiler or the gov module account
+func (k Keeper) IsGenesisProfiler(ctx sdk.Context, addr sdk.AccAddress) bool {
+	if addr.Equals(GovModuleAccAddr) {
+		return true
+	}
+	profiler, found := k.GetProfiler(ctx, addr)
+	return found && profiler.AccountType == Genesis
+}
+
 // Gets all profilers
 func (k Keeper) ProfilersIterator(ctx sdk.Context) sdk.Iterator {
 	store := ctx.KVStore(k.storeKey)
@@ -76,6 +99,24 @@ func (k Keeper) GetTrustee(ctx sdk.Context, addr sdk.AccAddress) (guardian Guard
 	return guardian, false
 }
 
+// Checks the address is a trustee or the gov module account
+func (k Keeper) IsTrustee(ctx sdk.Context, addr sdk.AccAddress) bool {
+	if addr.Equals(GovModuleAccAddr) {
+		return true
+	}
+	_, found := k.GetTrustee(ctx, addr)
+	return found
+}
+
+// Checks the address is a genesis trustee or the gov module account
+func (k Keeper) IsGenesisTrustee(ctx sdk.Context, addr sdk.AccAddress) bool {
+	if addr.Equals(GovModuleAccAddr) {
+		return true
+	}
+	trustee, found := k.GetTrustee(ctx, addr)
+	return found && trustee.AccountType == Genesis
+}
+
 // Gets all trustees
 func (k Keeper) TrusteesIterator(ctx sdk.Context) sdk.Iterator {
 	store := ctx.KVStore(k.storeKey)
@@ -51,6 +51,9 @@ const (
 	//auth
 	CodeInvalidGasPriceThreshold sdk.CodeType = 600
 	CodeInvalidTxSizeLimit       sdk.CodeType = 601
+	CodeInvalidTargetBlockGas    sdk.CodeType = 602
+	CodeInvalidBaseFeeChangeRate sdk.CodeType = 603
+	CodeInvalidSigVerifyCost     sdk.CodeType = 604
 
 	//distribution
 	CodeInvalidCommunityTax        sdk.CodeType = 700
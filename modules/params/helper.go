@@ -2,6 +2,7 @@ package params
 
 import (
 	"fmt"
+	"reflect"
 	"strings"
 )
 
@@ -16,6 +17,15 @@ func RegisterParamSet(paramSets map[string]ParamSet, ps ...ParamSet) {
 	}
 }
 
+// newParamSet returns a new zero valued param set of the same type as ps
+func newParamSet(ps ParamSet) ParamSet {
+	t := reflect.TypeOf(ps)
+	if t.Kind() != reflect.Ptr {
+		return ps
+	}
+	return reflect.New(t.Elem()).Interface().(ParamSet)
+}
+
 func GetParamKey(keystr string) string {
 	strs := strings.Split(keystr, "/")
 	if len(strs) != 2 {
@@ -31,3 +41,44 @@ func GetParamSpaceFromKey(keystr string) string {
 	}
 	return strs[0]
 }
+
+// SetParamSetValue sets the field of ps registered under key to value,
+// it returns false if the key is unknown or the value has a different type
+func SetParamSetValue(ps ParamSet, key string, value interface{}) bool {
+	for _, pair := range ps.KeyValuePairs() {
+		if string(pair.Key) != key {
+			continue
+		}
+		field := reflect.ValueOf(pair.Value).Elem()
+		v := reflect.ValueOf(value)
+		if v.Kind() == reflect.Ptr {
+			v = v.Elem()
+		}
+		if v.Type() != field.Type() {
+			return false
+		}
+		field.Set(v)
+		return true
+	}
+	return false
+}
+
+// SetUpgradeDefaults sets the parameters of an UpgradeParamSet left to their zero values, as in
+// the genesis files exported before the upgrade adding them, to their default values
+func SetUpgradeDefaults(ps ParamSet) {
+	ups, ok := ps.(UpgradeParamSet)
+	if !ok {
+		return
+	}
+	defaults := make(map[string]interface{})
+	for _, pair := range ups.UpgradeParams() {
+		defaults[string(pair.Key)] = pair.Value
+	}
+	for _, pair := range ps.KeyValuePairs() {
+		value, ok := defaults[string(pair.Key)]
+		field := reflect.ValueOf(pair.Value).Elem()
+		if ok && field.IsZero() {
+			field.Set(reflect.ValueOf(value).Elem())
+		}
+	}
+}
@@ -62,11 +62,12 @@ func (k Keeper) RegisterParamSet(ps ...ParamSet) {
 	RegisterParamSet(k.paramSets, ps...)
 }
 
-// Get existing substore from keeper
+// Get a new instance of the param set registered for the param space,
+// the registered one is shared by all the queries and must not be changed
 func (k Keeper) GetParamSet(paramSpace string) (ParamSet, bool) {
 	paramSet, ok := k.paramSets[paramSpace]
 	if !ok {
 		return nil, false
 	}
-	return paramSet, ok
+	return newParamSet(paramSet), ok
 }
@@ -6,11 +6,13 @@ import (
 
 // re-export types from subspace
 type (
-	Subspace         = subspace.Subspace
-	ReadOnlySubspace = subspace.ReadOnlySubspace
-	ParamSet         = subspace.ParamSet
-	KeyValuePairs    = subspace.KeyValuePairs
-	TypeTable        = subspace.TypeTable
+	Subspace          = subspace.Subspace
+	ReadOnlySubspace  = subspace.ReadOnlySubspace
+	ParamSet          = subspace.ParamSet
+	ParamSetValidator = subspace.ParamSetValidator
+	UpgradeParamSet   = subspace.UpgradeParamSet
+	KeyValuePairs     = subspace.KeyValuePairs
+	TypeTable         = subspace.TypeTable
 )
 
 // re-export functions from subspace
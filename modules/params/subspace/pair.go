@@ -22,3 +22,17 @@ type ParamSet interface {
 	StringFromBytes(*codec.Codec, string, []byte) (string, error)
 	String() string
 }
+
+// Interface for ParamSets whose parameters depend on each other.
+// ValidateParamSet is called after all the changes of a proposal are applied to the set
+type ParamSetValidator interface {
+	ValidateParamSet() sdk.Error
+}
+
+// Interface for ParamSets with parameters added by an upgrade of the chain, which are not in
+// the store of the chains started before the upgrade until set.
+// GetParamSet reads their default values meanwhile
+type UpgradeParamSet interface {
+	// UpgradeParams returns the pairs of the added parameters with their default values
+	UpgradeParams() KeyValuePairs
+}
@@ -142,9 +142,20 @@ func (s Subspace) Set(ctx sdk.Context, key []byte, param interface{}) {
 
 }
 
-// Get to ParamSet
+// Get to ParamSet, the parameters of an UpgradeParamSet missing from the store
+// are read with their default values
 func (s Subspace) GetParamSet(ctx sdk.Context, ps ParamSet) {
+	defaults := make(map[string]interface{})
+	if ups, ok := ps.(UpgradeParamSet); ok {
+		for _, pair := range ups.UpgradeParams() {
+			defaults[string(pair.Key)] = pair.Value
+		}
+	}
 	for _, pair := range ps.KeyValuePairs() {
+		if value, ok := defaults[string(pair.Key)]; ok && !s.Has(ctx, pair.Key) {
+			reflect.ValueOf(pair.Value).Elem().Set(reflect.ValueOf(value).Elem())
+			continue
+		}
 		s.Get(ctx, pair.Key, pair.Value)
 	}
 }
@@ -20,6 +20,8 @@ type SvcBinding struct {
 	Level       Level          `json:"level"`
 	Available   bool           `json:"available"`
 	DisableTime time.Time      `json:"disable_time"`
+	Stats       BindingStats   `json:"stats"`
+	DefVersion  string         `json:"def_version"`
 }
 
 type Level struct {
@@ -28,10 +30,11 @@ type Level struct {
 }
 
 // NewSvcBinding returns a new SvcBinding with the provided values.
-func NewSvcBinding(ctx sdk.Context, defChainID, defName, bindChainID string, provider sdk.AccAddress, bindingType BindingType, deposit sdk.Coins, prices []sdk.Coin, level Level, available bool) SvcBinding {
+func NewSvcBinding(ctx sdk.Context, defChainID, defName, defVersion, bindChainID string, provider sdk.AccAddress, bindingType BindingType, deposit sdk.Coins, prices []sdk.Coin, level Level, available bool) SvcBinding {
 	return SvcBinding{
 		DefChainID:  defChainID,
 		DefName:     defName,
+		DefVersion:  defVersion,
 		BindChainID: bindChainID,
 		Provider:    provider,
 		BindingType: bindingType,
@@ -46,6 +49,7 @@ func NewSvcBinding(ctx sdk.Context, defChainID, defName, bindChainID string, pro
 func SvcBindingEqual(bindingA, bindingB SvcBinding) bool {
 	if bindingA.DefChainID == bindingB.DefChainID &&
 		bindingA.DefName == bindingB.DefName &&
+		bindingA.DefVersion == bindingB.DefVersion &&
 		bindingA.BindChainID == bindingB.BindChainID &&
 		bindingA.Provider.String() == bindingB.Provider.String() &&
 		bindingA.BindingType == bindingB.BindingType &&
@@ -65,6 +69,32 @@ func SvcBindingEqual(bindingA, bindingB SvcBinding) bool {
 	return false
 }
 
+// BindingStats is the request history of a service binding, unlike the
+// self-declared Level it is maintained by the chain
+type BindingStats struct {
+	ServedRequests  int64 `json:"served_requests"`  // requests responded in time
+	TimeoutRequests int64 `json:"timeout_requests"` // requests expired without a response
+	TotalRspBlocks  int64 `json:"total_rsp_blocks"` // sum of the response latency in blocks of the served requests
+	Slashes         int64 `json:"slashes"`
+}
+
+// average response latency in blocks of the served requests
+func (stats BindingStats) AvgRspBlocks() sdk.Dec {
+	if stats.ServedRequests == 0 {
+		return sdk.ZeroDec()
+	}
+	return sdk.NewDec(stats.TotalRspBlocks).Quo(sdk.NewDec(stats.ServedRequests))
+}
+
+// ratio of the served requests to all the finished requests, a binding without history has a rate of 1
+func (stats BindingStats) SuccessRate() sdk.Dec {
+	total := stats.ServedRequests + stats.TimeoutRequests
+	if total == 0 {
+		return sdk.OneDec()
+	}
+	return sdk.NewDec(stats.ServedRequests).Quo(sdk.NewDec(total))
+}
+
 // is valid level?
 func validLevel(lv Level) bool {
 	if lv.AvgRspTime > 0 && lv.UsableTime > 0 && lv.UsableTime <= 10000 {
@@ -3,11 +3,20 @@ package service
 import (
 	"encoding/json"
 	"fmt"
+	"strconv"
+	"strings"
 
 	sdk "github.com/NPC-Chain/npcchub/types"
 	"github.com/pkg/errors"
 )
 
+// the version of a service definition created without an explicit version
+const DefaultSvcDefVersion = "1.0.0"
+
+// DefinitionVersionsProtocolVersion is the first protocol version of the versioned service definitions.
+// The definitions stored before are migrated to the DefaultSvcDefVersion in its first block.
+const DefinitionVersionsProtocolVersion = 3
+
 type SvcDef struct {
 	Name              string         `json:"name"`
 	ChainId           string         `json:"chain_id"`
@@ -16,6 +25,8 @@ type SvcDef struct {
 	Author            sdk.AccAddress `json:"author"`
 	AuthorDescription string         `json:"author_description"`
 	IDLContent        string         `json:"idl_content"`
+	Version           string         `json:"version"`
+	Deprecated        bool           `json:"deprecated"` // deprecated versions can't be bound any more
 }
 
 type MethodProperty struct {
@@ -26,10 +37,11 @@ type MethodProperty struct {
 	OutputCached  OutputCachedEnum  `json:"output_cached"`
 }
 
-func NewSvcDef(name, chainId, description string, tags []string, author sdk.AccAddress, authorDescription, idlContent string) SvcDef {
+func NewSvcDef(name, chainId, version, description string, tags []string, author sdk.AccAddress, authorDescription, idlContent string) SvcDef {
 	return SvcDef{
 		Name:              name,
 		ChainId:           chainId,
+		Version:           version,
 		Description:       description,
 		Tags:              tags,
 		Author:            author,
@@ -38,6 +50,54 @@ func NewSvcDef(name, chainId, description string, tags []string, author sdk.AccA
 	}
 }
 
+// parseDefVersion parses a semantic version of format MAJOR.MINOR.PATCH
+func parseDefVersion(version string) (v [3]uint64, err error) {
+	parts := strings.Split(version, ".")
+	if len(parts) != 3 {
+		return v, errors.Errorf("'%s' is not of format MAJOR.MINOR.PATCH", version)
+	}
+	for i, part := range parts {
+		// leading zeros are not allowed by semver
+		if len(part) > 1 && part[0] == '0' {
+			return v, errors.Errorf("'%s' has a leading zero", version)
+		}
+		v[i], err = strconv.ParseUint(part, 10, 64)
+		if err != nil {
+			return v, errors.Errorf("'%s' is not of format MAJOR.MINOR.PATCH", version)
+		}
+	}
+	return v, nil
+}
+
+// compareDefVersions returns -1, 0 or 1 if version a is lower than, equal to or greater than b,
+// both versions must be valid
+func compareDefVersions(a, b string) int {
+	va, err := parseDefVersion(a)
+	if err != nil {
+		panic(err)
+	}
+	vb, err := parseDefVersion(b)
+	if err != nil {
+		panic(err)
+	}
+	for i := range va {
+		if va[i] < vb[i] {
+			return -1
+		}
+		if va[i] > vb[i] {
+			return 1
+		}
+	}
+	return 0
+}
+
+func validDefVersion(version string) sdk.Error {
+	if _, err := parseDefVersion(version); err != nil {
+		return ErrInvalidDefVersion(DefaultCodespace, err.Error())
+	}
+	return nil
+}
+
 type OutputPrivacyEnum byte
 
 const (
@@ -43,6 +43,12 @@ const (
 
 	CodeIntOverflow  sdk.CodeType = 130
 	CodeInvalidInput sdk.CodeType = 131
+
+	CodeInvalidDefVersion      sdk.CodeType = 132
+	CodeSvcDefVersionNotExists sdk.CodeType = 133
+	CodeSvcDefDeprecated       sdk.CodeType = 134
+	CodeNotMatchingDefVersion  sdk.CodeType = 135
+	CodeNotMatchingAuthor      sdk.CodeType = 136
 )
 
 func codeToDefaultMsg(code sdk.CodeType) string {
@@ -74,6 +80,26 @@ func ErrSvcDefNotExists(codespace sdk.CodespaceType, defChainId, svcDefName stri
 	return sdk.NewError(codespace, CodeSvcDefNotExists, fmt.Sprintf("service definition name %s is not existed in %s", svcDefName, defChainId))
 }
 
+func ErrInvalidDefVersion(codespace sdk.CodespaceType, msg string) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidDefVersion, fmt.Sprintf("invalid service definition version, %s", msg))
+}
+
+func ErrSvcDefVersionNotExists(codespace sdk.CodespaceType, defChainId, svcDefName, version string) sdk.Error {
+	return sdk.NewError(codespace, CodeSvcDefVersionNotExists, fmt.Sprintf("version %s of service definition %s is not existed in %s", version, svcDefName, defChainId))
+}
+
+func ErrSvcDefDeprecated(codespace sdk.CodespaceType, svcDefName, version string) sdk.Error {
+	return sdk.NewError(codespace, CodeSvcDefDeprecated, fmt.Sprintf("version %s of service definition %s is deprecated", version, svcDefName))
+}
+
+func ErrNotMatchingDefVersion(codespace sdk.CodespaceType, version, bindingVersion string) sdk.Error {
+	return sdk.NewError(codespace, CodeNotMatchingDefVersion, fmt.Sprintf("version %s doesn't match the version %s of the service binding", version, bindingVersion))
+}
+
+func ErrNotMatchingAuthor(codespace sdk.CodespaceType, author sdk.AccAddress) sdk.Error {
+	return sdk.NewError(codespace, CodeNotMatchingAuthor, fmt.Sprintf("[%s] is not the author of the service definition", author))
+}
+
 func ErrInvalidIDL(codespace sdk.CodespaceType, msg string) sdk.Error {
 	return sdk.NewError(codespace, CodeInvalidIDL, fmt.Sprintf("The IDL content cannot be parsed, %s", msg))
 }
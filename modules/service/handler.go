@@ -1,6 +1,8 @@
 package service
 
 import (
+	"fmt"
+
 	"github.com/NPC-Chain/npcchub/modules/service/tags"
 	sdk "github.com/NPC-Chain/npcchub/types"
 )
@@ -11,6 +13,8 @@ func NewHandler(k Keeper) sdk.Handler {
 		switch msg := msg.(type) {
 		case MsgSvcDef:
 			return handleMsgSvcDef(ctx, k, msg)
+		case MsgSvcDefUpdate:
+			return handleMsgSvcDefUpdate(ctx, k, msg)
 		case MsgSvcBind:
 			return handleMsgSvcBind(ctx, k, msg)
 		case MsgSvcBindingUpdate:
@@ -37,33 +41,65 @@ func NewHandler(k Keeper) sdk.Handler {
 	}
 }
 func handleMsgSvcDef(ctx sdk.Context, k Keeper, msg MsgSvcDef) sdk.Result {
-	_, found := k.GetServiceDefinition(ctx, msg.ChainId, msg.Name)
+	_, found := k.GetLatestDefinitionVersion(ctx, msg.ChainId, msg.Name)
 	if found {
 		return ErrSvcDefExists(k.Codespace(), msg.ChainId, msg.Name).Result()
 	}
-	k.AddServiceDefinition(ctx, msg.SvcDef)
-	err := k.AddMethods(ctx, msg.SvcDef)
+	svcDef := msg.SvcDef
+	if len(svcDef.Version) == 0 {
+		svcDef.Version = DefaultSvcDefVersion
+	}
+	svcDef.Deprecated = false
+	k.AddServiceDefinition(ctx, svcDef)
+	err := k.AddMethods(ctx, svcDef)
 	if err != nil {
 		return err.Result()
 	}
-	ctx.Logger().Info("Create service definition", "name", msg.Name, "author", msg.Author.String())
+	ctx.Logger().Info("Create service definition", "name", msg.Name, "version", svcDef.Version, "author", msg.Author.String())
+	return sdk.Result{}
+}
+
+func handleMsgSvcDefUpdate(ctx sdk.Context, k Keeper, msg MsgSvcDefUpdate) sdk.Result {
+	latest, found := k.GetServiceDefinition(ctx, msg.ChainId, msg.Name, "")
+	if !found {
+		return ErrSvcDefNotExists(k.Codespace(), msg.ChainId, msg.Name).Result()
+	}
+	if !latest.Author.Equals(msg.Author) {
+		return ErrNotMatchingAuthor(k.Codespace(), msg.Author).Result()
+	}
+	if compareDefVersions(msg.Version, latest.Version) <= 0 {
+		return ErrInvalidDefVersion(k.Codespace(), fmt.Sprintf("%s must be greater than the latest version %s", msg.Version, latest.Version)).Result()
+	}
+
+	svcDef := msg.SvcDef
+	svcDef.Deprecated = false
+	k.AddServiceDefinition(ctx, svcDef)
+	err := k.AddMethods(ctx, svcDef)
+	if err != nil {
+		return err.Result()
+	}
+	if msg.DeprecatePrevious {
+		k.DeprecateServiceDefinitions(ctx, svcDef.ChainId, svcDef.Name, svcDef.Version)
+	}
+	ctx.Logger().Info("Update service definition", "name", msg.Name, "version", msg.Version,
+		"deprecate_previous", msg.DeprecatePrevious, "author", msg.Author.String())
 	return sdk.Result{}
 }
 
 func handleMsgSvcBind(ctx sdk.Context, k Keeper, msg MsgSvcBind) sdk.Result {
-	svcBinding := NewSvcBinding(ctx, msg.DefChainID, msg.DefName, msg.BindChainID, msg.Provider, msg.BindingType,
+	svcBinding := NewSvcBinding(ctx, msg.DefChainID, msg.DefName, msg.DefVersion, msg.BindChainID, msg.Provider, msg.BindingType,
 		msg.Deposit, msg.Prices, msg.Level, true)
 	err := k.AddServiceBinding(ctx, svcBinding)
 	if err != nil {
 		return err.Result()
 	}
-	ctx.Logger().Info("Add service binding", "def_name", msg.DefName, "def_chain_id", msg.DefChainID,
+	ctx.Logger().Info("Add service binding", "def_name", msg.DefName, "def_chain_id", msg.DefChainID, "def_version", msg.DefVersion,
 		"provider", msg.Provider.String(), "binding_type", msg.BindingType.String())
 	return sdk.Result{}
 }
 
 func handleMsgSvcBindUpdate(ctx sdk.Context, k Keeper, msg MsgSvcBindingUpdate) sdk.Result {
-	svcBinding := NewSvcBinding(ctx, msg.DefChainID, msg.DefName, msg.BindChainID, msg.Provider, msg.BindingType,
+	svcBinding := NewSvcBinding(ctx, msg.DefChainID, msg.DefName, msg.DefVersion, msg.BindChainID, msg.Provider, msg.BindingType,
 		msg.Deposit, msg.Prices, msg.Level, false)
 	err := k.UpdateServiceBinding(ctx, svcBinding)
 	if err != nil {
@@ -112,14 +148,17 @@ func handleMsgSvcRequest(ctx sdk.Context, k Keeper, msg MsgSvcRequest) sdk.Resul
 	if !bind.Available {
 		return ErrSvcBindingNotAvailable(k.Codespace()).Result()
 	}
+	if bind.DefVersion != msg.DefVersion {
+		return ErrNotMatchingDefVersion(k.Codespace(), msg.DefVersion, bind.DefVersion).Result()
+	}
 
-	_, methodFound := k.GetMethod(ctx, msg.DefChainID, msg.DefName, msg.MethodID)
+	_, methodFound := k.GetMethod(ctx, msg.DefChainID, msg.DefName, msg.DefVersion, msg.MethodID)
 	if !methodFound {
 		return ErrMethodNotExists(k.Codespace(), msg.MethodID).Result()
 	}
 
 	if msg.Profiling {
-		if _, found := k.gk.GetProfiler(ctx, msg.Consumer); !found {
+		if !k.gk.IsProfiler(ctx, msg.Consumer) {
 			return ErrNotProfiler(k.Codespace(), msg.Consumer).Result()
 		}
 	}
@@ -129,7 +168,7 @@ func handleMsgSvcRequest(ctx sdk.Context, k Keeper, msg MsgSvcRequest) sdk.Resul
 		return ErrLtServiceFee(k.Codespace(), sdk.Coins{bind.Prices[msg.MethodID-1]}).Result()
 	}
 
-	request := NewSvcRequest(msg.DefChainID, msg.DefName, msg.BindChainID, msg.ReqChainID, msg.Consumer, msg.Provider, msg.MethodID, msg.Input, msg.ServiceFee, msg.Profiling)
+	request := NewSvcRequest(msg.DefChainID, msg.DefName, msg.DefVersion, msg.BindChainID, msg.ReqChainID, msg.Consumer, msg.Provider, msg.MethodID, msg.Input, msg.ServiceFee, msg.Profiling)
 
 	// request service fee is equal to service binding service fee if not profiling
 	if len(bind.Prices) >= int(msg.MethodID) && !msg.Profiling {
@@ -182,6 +221,7 @@ func handleMsgSvcResponse(ctx sdk.Context, k Keeper, msg MsgSvcResponse) sdk.Res
 	// delete request from active request list and expiration list
 	k.DeleteActiveRequest(ctx, request)
 	k.DeleteRequestExpiration(ctx, request)
+	k.AddServedRequest(ctx, request)
 
 	err := k.AddIncomingFee(ctx, response.Provider, request.ServiceFee)
 	if err != nil {
@@ -217,8 +257,7 @@ func handleMsgSvcWithdrawFees(ctx sdk.Context, k Keeper, msg MsgSvcWithdrawFees)
 }
 
 func handleMsgSvcWithdrawTax(ctx sdk.Context, k Keeper, msg MsgSvcWithdrawTax) sdk.Result {
-	_, found := k.gk.GetTrustee(ctx, msg.Trustee)
-	if !found {
+	if !k.gk.IsTrustee(ctx, msg.Trustee) {
 		return ErrNotTrustee(k.Codespace(), msg.Trustee).Result()
 	}
 	_, err := k.ck.SendCoins(ctx, TaxCoinsAccAddr, msg.DestAddress, msg.Amount)
@@ -228,6 +267,16 @@ func handleMsgSvcWithdrawTax(ctx sdk.Context, k Keeper, msg MsgSvcWithdrawTax) s
 	return sdk.Result{}
 }
 
+// Called every block, migrate the service definitions stored before their versioning
+// in the first block of DefinitionVersionsProtocolVersion
+func BeginBlocker(ctx sdk.Context, keeper Keeper) {
+	if ctx.BlockHeader().Version.App < DefinitionVersionsProtocolVersion || keeper.DefinitionVersionsMigrated(ctx) {
+		return
+	}
+	ctx.Logger().Info("Migrating the service definitions to their versions", "module", "iris/service")
+	keeper.MigrateDefinitionVersions(ctx)
+}
+
 // Called every block, update request status
 func EndBlocker(ctx sdk.Context, keeper Keeper) (resTags sdk.Tags) {
 	ctx = ctx.WithLogger(ctx.Logger().With("handler", "endBlock").With("module", "iris/service"))
@@ -248,6 +297,7 @@ func EndBlocker(ctx sdk.Context, keeper Keeper) (resTags sdk.Tags) {
 		slashCoins := sdk.Coins{}
 		binding, found := keeper.GetServiceBinding(ctx, req.DefChainID, req.DefName, req.BindChainID, req.Provider)
 		if found {
+			binding.Stats.TimeoutRequests++
 			for _, coin := range binding.Deposit {
 				taxAmount := sdk.NewDecFromInt(coin.Amount).Mul(slashFraction).TruncateInt()
 				slashCoins = append(slashCoins, sdk.NewCoin(coin.Denom, taxAmount))
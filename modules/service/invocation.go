@@ -22,12 +22,14 @@ type SvcRequest struct {
 	RequestHeight         int64          `json:"request_height"`           // block height of service request
 	RequestIntraTxCounter int16          `json:"request_intra_tx_counter"` // block-local tx index of service request
 	ExpirationHeight      int64          `json:"expiration_height"`        // block height of the service request has expired
+	DefVersion            string         `json:"def_version"`
 }
 
-func NewSvcRequest(defChainID, defName, bindChainID, reqChainID string, consumer, provider sdk.AccAddress, methodID int16, input []byte, serviceFee sdk.Coins, profiling bool) SvcRequest {
+func NewSvcRequest(defChainID, defName, defVersion, bindChainID, reqChainID string, consumer, provider sdk.AccAddress, methodID int16, input []byte, serviceFee sdk.Coins, profiling bool) SvcRequest {
 	return SvcRequest{
 		DefChainID:  defChainID,
 		DefName:     defName,
+		DefVersion:  defVersion,
 		BindChainID: bindChainID,
 		ReqChainID:  reqChainID,
 		MethodID:    methodID,
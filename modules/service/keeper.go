@@ -1,6 +1,7 @@
 package service
 
 import (
+	"bytes"
 	"fmt"
 	"github.com/NPC-Chain/npcchub/codec"
 	"github.com/NPC-Chain/npcchub/modules/bank"
@@ -48,6 +49,8 @@ func (k Keeper) Codespace() sdk.CodespaceType {
 	return k.codespace
 }
 
+// AddServiceDefinition stores a version of a service definition,
+// the latest version is updated if the version is greater than it
 func (k Keeper) AddServiceDefinition(ctx sdk.Context, svcDef SvcDef) {
 	kvStore := ctx.KVStore(k.storeKey)
 
@@ -56,7 +59,12 @@ func (k Keeper) AddServiceDefinition(ctx sdk.Context, svcDef SvcDef) {
 		panic(err)
 	}
 
-	kvStore.Set(GetServiceDefinitionKey(svcDef.ChainId, svcDef.Name), svcDefBytes)
+	kvStore.Set(GetServiceDefinitionKey(svcDef.ChainId, svcDef.Name, svcDef.Version), svcDefBytes)
+
+	latest, found := k.GetLatestDefinitionVersion(ctx, svcDef.ChainId, svcDef.Name)
+	if !found || compareDefVersions(svcDef.Version, latest) > 0 {
+		kvStore.Set(GetLatestDefinitionVersionKey(svcDef.ChainId, svcDef.Name), k.cdc.MustMarshalBinaryLengthPrefixed(svcDef.Version))
+	}
 }
 
 func (k Keeper) AddMethods(ctx sdk.Context, svcDef SvcDef) sdk.Error {
@@ -71,15 +79,34 @@ func (k Keeper) AddMethods(ctx sdk.Context, svcDef SvcDef) sdk.Error {
 			return err
 		}
 		methodBytes := k.cdc.MustMarshalBinaryLengthPrefixed(methodProperty)
-		kvStore.Set(GetMethodPropertyKey(svcDef.ChainId, svcDef.Name, methodProperty.ID), methodBytes)
+		kvStore.Set(GetMethodPropertyKey(svcDef.ChainId, svcDef.Name, svcDef.Version, methodProperty.ID), methodBytes)
 	}
 	return nil
 }
 
-func (k Keeper) GetServiceDefinition(ctx sdk.Context, chainId, name string) (svcDef SvcDef, found bool) {
+// Gets the latest version of a service definition
+func (k Keeper) GetLatestDefinitionVersion(ctx sdk.Context, chainId, name string) (version string, found bool) {
+	kvStore := ctx.KVStore(k.storeKey)
+
+	versionBytes := kvStore.Get(GetLatestDefinitionVersionKey(chainId, name))
+	if versionBytes != nil {
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(versionBytes, &version)
+		return version, true
+	}
+	return version, false
+}
+
+// Gets a version of a service definition, the latest version is returned if the version is empty
+func (k Keeper) GetServiceDefinition(ctx sdk.Context, chainId, name, version string) (svcDef SvcDef, found bool) {
+	if len(version) == 0 {
+		if version, found = k.GetLatestDefinitionVersion(ctx, chainId, name); !found {
+			return svcDef, false
+		}
+	}
+
 	kvStore := ctx.KVStore(k.storeKey)
 
-	serviceDefBytes := kvStore.Get(GetServiceDefinitionKey(chainId, name))
+	serviceDefBytes := kvStore.Get(GetServiceDefinitionKey(chainId, name, version))
 	if serviceDefBytes != nil {
 		k.cdc.MustUnmarshalBinaryLengthPrefixed(serviceDefBytes, &svcDef)
 		return svcDef, true
@@ -87,10 +114,35 @@ func (k Keeper) GetServiceDefinition(ctx sdk.Context, chainId, name string) (svc
 	return svcDef, false
 }
 
-// Gets the method in a specific service and methodID
-func (k Keeper) GetMethod(ctx sdk.Context, chainId, name string, id int16) (method MethodProperty, found bool) {
+// Gets all the versions of a service definition
+func (k Keeper) ServiceDefinitionsIterator(ctx sdk.Context, chainId, name string) sdk.Iterator {
+	store := ctx.KVStore(k.storeKey)
+	return sdk.KVStorePrefixIterator(store, GetServiceDefinitionsSubspaceKey(chainId, name))
+}
+
+// DeprecateServiceDefinitions deprecates all the versions of a service definition lower than the version
+func (k Keeper) DeprecateServiceDefinitions(ctx sdk.Context, chainId, name, version string) {
+	iterator := k.ServiceDefinitionsIterator(ctx, chainId, name)
+	defer iterator.Close()
+	var svcDefs []SvcDef
+	for ; iterator.Valid(); iterator.Next() {
+		var svcDef SvcDef
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(iterator.Value(), &svcDef)
+		if !svcDef.Deprecated && compareDefVersions(svcDef.Version, version) < 0 {
+			svcDefs = append(svcDefs, svcDef)
+		}
+	}
+
+	for _, svcDef := range svcDefs {
+		svcDef.Deprecated = true
+		k.AddServiceDefinition(ctx, svcDef)
+	}
+}
+
+// Gets the method in a specific version of a service and methodID
+func (k Keeper) GetMethod(ctx sdk.Context, chainId, name, version string, id int16) (method MethodProperty, found bool) {
 	store := ctx.KVStore(k.storeKey)
-	methodBytes := store.Get(GetMethodPropertyKey(chainId, name, id))
+	methodBytes := store.Get(GetMethodPropertyKey(chainId, name, version, id))
 	if methodBytes != nil {
 		k.cdc.MustUnmarshalBinaryLengthPrefixed(methodBytes, &method)
 		return method, true
@@ -98,20 +150,92 @@ func (k Keeper) GetMethod(ctx sdk.Context, chainId, name string, id int16) (meth
 	return method, false
 }
 
-// Gets all the methods in a specific service
-func (k Keeper) GetMethods(ctx sdk.Context, chainId, name string) sdk.Iterator {
+// Gets all the methods in a specific version of a service
+func (k Keeper) GetMethods(ctx sdk.Context, chainId, name, version string) sdk.Iterator {
+	store := ctx.KVStore(k.storeKey)
+	return sdk.KVStorePrefixIterator(store, GetMethodsSubspaceKey(chainId, name, version))
+}
+
+// MigrateDefinitionVersions moves the service definitions and methods stored before the
+// versioning of the definitions to the DefaultSvcDefVersion, and binds the bindings to them
+// to the DefaultSvcDefVersion. The requests keep an empty DefVersion.
+func (k Keeper) MigrateDefinitionVersions(ctx sdk.Context) {
 	store := ctx.KVStore(k.storeKey)
-	return sdk.KVStorePrefixIterator(store, GetMethodsSubspaceKey(chainId, name))
+	store.Set(definitionsMigratedKey, []byte{1})
+
+	// the legacy keys have no version segment
+	for _, key := range legacyDefinitionKeys(store, serviceDefinitionKey, 2) {
+		var svcDef SvcDef
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(store.Get(key), &svcDef)
+		store.Delete(key)
+		svcDef.Version = DefaultSvcDefVersion
+		k.AddServiceDefinition(ctx, svcDef)
+	}
+	for _, key := range legacyDefinitionKeys(store, methodPropertyKey, 3) {
+		segments := bytes.Split(key[len(methodPropertyKey):], emptyByte)
+		methodBytes := store.Get(key)
+		store.Delete(key)
+		store.Set(append(methodPropertyKey, getStringsKey([]string{string(segments[0]), string(segments[1]),
+			DefaultSvcDefVersion, string(segments[2])})...), methodBytes)
+	}
+
+	iterator := sdk.KVStorePrefixIterator(store, bindingPropertyKey)
+	var bindings []SvcBinding
+	for ; iterator.Valid(); iterator.Next() {
+		var binding SvcBinding
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(iterator.Value(), &binding)
+		if len(binding.DefVersion) == 0 {
+			bindings = append(bindings, binding)
+		}
+	}
+	iterator.Close()
+	for _, binding := range bindings {
+		binding.DefVersion = DefaultSvcDefVersion
+		store.Set(GetServiceBindingKey(binding.DefChainID, binding.DefName, binding.BindChainID, binding.Provider),
+			k.cdc.MustMarshalBinaryLengthPrefixed(binding))
+	}
+}
+
+// DefinitionVersionsMigrated returns if the service definitions are migrated to their versions
+func (k Keeper) DefinitionVersionsMigrated(ctx sdk.Context) bool {
+	return ctx.KVStore(k.storeKey).Has(definitionsMigratedKey)
+}
+
+// legacyDefinitionKeys returns the keys of the prefix made of the number of segments
+func legacyDefinitionKeys(store sdk.KVStore, prefix []byte, segments int) (keys [][]byte) {
+	iterator := sdk.KVStorePrefixIterator(store, prefix)
+	defer iterator.Close()
+	for ; iterator.Valid(); iterator.Next() {
+		key := iterator.Key()
+		if len(bytes.Split(key[len(prefix):], emptyByte)) == segments {
+			keys = append(keys, append([]byte{}, key...))
+		}
+	}
+	return keys
+}
+
+// checks that a new binding can be made to the version of a service definition
+func (k Keeper) validateBindableVersion(ctx sdk.Context, defChainID, defName, defVersion string) sdk.Error {
+	if _, found := k.GetLatestDefinitionVersion(ctx, defChainID, defName); !found {
+		return ErrSvcDefNotExists(k.Codespace(), defChainID, defName)
+	}
+	svcDef, found := k.GetServiceDefinition(ctx, defChainID, defName, defVersion)
+	if !found {
+		return ErrSvcDefVersionNotExists(k.Codespace(), defChainID, defName, defVersion)
+	}
+	if svcDef.Deprecated {
+		return ErrSvcDefDeprecated(k.Codespace(), defName, defVersion)
+	}
+	return nil
 }
 
 func (k Keeper) AddServiceBinding(ctx sdk.Context, svcBinding SvcBinding) sdk.Error {
 	kvStore := ctx.KVStore(k.storeKey)
-	_, found := k.GetServiceDefinition(ctx, svcBinding.DefChainID, svcBinding.DefName)
-	if !found {
-		return ErrSvcDefNotExists(k.Codespace(), svcBinding.DefChainID, svcBinding.DefName)
+	if err := k.validateBindableVersion(ctx, svcBinding.DefChainID, svcBinding.DefName, svcBinding.DefVersion); err != nil {
+		return err
 	}
 
-	_, found = k.GetServiceBinding(ctx, svcBinding.DefChainID, svcBinding.DefName, svcBinding.BindChainID, svcBinding.Provider)
+	_, found := k.GetServiceBinding(ctx, svcBinding.DefChainID, svcBinding.DefName, svcBinding.BindChainID, svcBinding.Provider)
 	if found {
 		return ErrSvcBindingExists(k.Codespace())
 	}
@@ -137,6 +261,7 @@ func (k Keeper) AddServiceBinding(ctx sdk.Context, svcBinding SvcBinding) sdk.Er
 	}
 
 	svcBinding.DisableTime = time.Time{}
+	svcBinding.Stats = BindingStats{}
 	svcBindingBytes := k.cdc.MustMarshalBinaryLengthPrefixed(svcBinding)
 	kvStore.Set(GetServiceBindingKey(svcBinding.DefChainID, svcBinding.DefName, svcBinding.BindChainID, svcBinding.Provider), svcBindingBytes)
 	return nil
@@ -166,12 +291,24 @@ func (k Keeper) UpdateServiceBinding(ctx sdk.Context, svcBinding SvcBinding) sdk
 		return ErrSvcBindingNotExists(k.Codespace())
 	}
 
+	// rebind to another version of the service definition
+	if len(svcBinding.DefVersion) > 0 && svcBinding.DefVersion != oldBinding.DefVersion {
+		if err := k.validateBindableVersion(ctx, svcBinding.DefChainID, svcBinding.DefName, svcBinding.DefVersion); err != nil {
+			return err
+		}
+		oldBinding.DefVersion = svcBinding.DefVersion
+	}
+
 	if len(svcBinding.Prices) > 0 {
-		err := k.validateMethodPrices(ctx, svcBinding)
+		oldBinding.Prices = svcBinding.Prices
+	}
+
+	// the prices must match the methods of the bound version
+	if len(svcBinding.Prices) > 0 || oldBinding.DefVersion == svcBinding.DefVersion {
+		err := k.validateMethodPrices(ctx, oldBinding)
 		if err != nil {
 			return err
 		}
-		oldBinding.Prices = svcBinding.Prices
 	}
 
 	if svcBinding.BindingType != 0x00 {
@@ -304,7 +441,7 @@ func (k Keeper) RefundDeposit(ctx sdk.Context, defChainID, defName, bindChainID
 }
 
 func (k Keeper) validateMethodPrices(ctx sdk.Context, svcBinding SvcBinding) sdk.Error {
-	iterator := k.GetMethods(ctx, svcBinding.DefChainID, svcBinding.DefName)
+	iterator := k.GetMethods(ctx, svcBinding.DefChainID, svcBinding.DefName, svcBinding.DefVersion)
 	defer iterator.Close()
 	var methods []MethodProperty
 	for ; iterator.Valid(); iterator.Next() {
@@ -319,6 +456,20 @@ func (k Keeper) validateMethodPrices(ctx sdk.Context, svcBinding SvcBinding) sdk
 	return nil
 }
 
+// records a request responded by the provider of the binding
+func (k Keeper) AddServedRequest(ctx sdk.Context, req SvcRequest) {
+	binding, found := k.GetServiceBinding(ctx, req.DefChainID, req.DefName, req.BindChainID, req.Provider)
+	if !found {
+		return
+	}
+	binding.Stats.ServedRequests++
+	binding.Stats.TotalRspBlocks += ctx.BlockHeight() - req.RequestHeight
+
+	store := ctx.KVStore(k.storeKey)
+	svcBindingBytes := k.cdc.MustMarshalBinaryLengthPrefixed(binding)
+	store.Set(GetServiceBindingKey(binding.DefChainID, binding.DefName, binding.BindChainID, binding.Provider), svcBindingBytes)
+}
+
 //__________________________________________________________________________
 
 func (k Keeper) AddRequest(ctx sdk.Context, req SvcRequest) (SvcRequest, sdk.Error) {
@@ -534,6 +685,10 @@ func (k Keeper) Slash(ctx sdk.Context, binding SvcBinding, slashCoins sdk.Coins)
 		panic(errMsg)
 	}
 	binding.Deposit = deposit
+	// the slash fraction of a small deposit is truncated to zero, which is no slash
+	if !slashCoins.IsZero() {
+		binding.Stats.Slashes++
+	}
 	minDeposit, err := k.getMinDeposit(ctx, binding.Prices)
 	if err != nil {
 		return err
@@ -23,20 +23,32 @@ var (
 
 	serviceFeeTaxKey        = []byte{0x12}
 	serviceSlashFractionKey = []byte{0x13}
+
+	latestDefinitionVersionKey = []byte{0x14} // key for the latest version of a service definition
+	definitionsMigratedKey     = []byte{0x15} // key set once the definitions are migrated to their versions
 )
 
-func GetServiceDefinitionKey(chainId, name string) []byte {
-	return append(serviceDefinitionKey, getStringsKey([]string{chainId, name})...)
+func GetServiceDefinitionKey(chainId, name, version string) []byte {
+	return append(serviceDefinitionKey, getStringsKey([]string{chainId, name, version})...)
+}
+
+// Key for getting all versions of a service definition from the store
+func GetServiceDefinitionsSubspaceKey(chainId, name string) []byte {
+	return append(append(serviceDefinitionKey, getStringsKey([]string{chainId, name})...), emptyByte...)
+}
+
+func GetLatestDefinitionVersionKey(chainId, name string) []byte {
+	return append(latestDefinitionVersionKey, getStringsKey([]string{chainId, name})...)
 }
 
 // id can not be zero
-func GetMethodPropertyKey(chainId, serviceName string, id int16) []byte {
-	return append(methodPropertyKey, getStringsKey([]string{chainId, serviceName, string(id)})...)
+func GetMethodPropertyKey(chainId, serviceName, version string, id int16) []byte {
+	return append(methodPropertyKey, getStringsKey([]string{chainId, serviceName, version, string(id)})...)
 }
 
-// Key for getting all methods on a service from the store
-func GetMethodsSubspaceKey(chainId, serviceName string) []byte {
-	return append(append(methodPropertyKey, getStringsKey([]string{chainId, serviceName})...), emptyByte...)
+// Key for getting all methods on a version of a service from the store
+func GetMethodsSubspaceKey(chainId, serviceName, version string) []byte {
+	return append(append(methodPropertyKey, getStringsKey([]string{chainId, serviceName, version})...), emptyByte...)
 }
 
 func GetServiceBindingKey(defChainId, name, bindChainId string, provider sdk.AccAddress) []byte {
@@ -17,6 +17,7 @@ func TestKeeper_service_Definition(t *testing.T) {
 
 	serviceDef := NewSvcDef("myService",
 		"testnet",
+		"1.0.0",
 		"the service for unit test",
 		[]string{"test", "tutorial"},
 		addrs[0],
@@ -24,14 +25,15 @@ func TestKeeper_service_Definition(t *testing.T) {
 		idlContent)
 
 	keeper.AddServiceDefinition(ctx, serviceDef)
-	serviceDefB, _ := keeper.GetServiceDefinition(ctx, "testnet", "myService")
+	serviceDefB, _ := keeper.GetServiceDefinition(ctx, "testnet", "myService", "")
 
 	require.Equal(t, serviceDefB.IDLContent, idlContent)
 	require.Equal(t, serviceDefB.Name, "myService")
+	require.Equal(t, serviceDefB.Version, "1.0.0")
 
 	// test methods
 	keeper.AddMethods(ctx, serviceDef)
-	iterator := keeper.GetMethods(ctx, "testnet", "myService")
+	iterator := keeper.GetMethods(ctx, "testnet", "myService", "1.0.0")
 	defer iterator.Close()
 	require.True(t, iterator.Valid())
 	for ; ; iterator.Next() {
@@ -47,7 +49,7 @@ func TestKeeper_service_Definition(t *testing.T) {
 	}
 
 	// test binding
-	svcBinding := NewSvcBinding(ctx, "testnet", "myService", "testnet",
+	svcBinding := NewSvcBinding(ctx, "testnet", "myService", "1.0.0", "testnet",
 		addrs[1], Global, sdk.Coins{sdk.NewCoin("iris", sdk.NewInt(1000))}, []sdk.Coin{{"iris", sdk.NewInt(1)}},
 		Level{AvgRspTime: 10000, UsableTime: 9999}, true)
 	err := keeper.AddServiceBinding(ctx, svcBinding)
@@ -60,7 +62,7 @@ func TestKeeper_service_Definition(t *testing.T) {
 	require.True(t, SvcBindingEqual(svcBinding, gotSvcBinding))
 
 	// test binding update
-	svcBindingUpdate := NewSvcBinding(ctx, "testnet", "myService", "testnet",
+	svcBindingUpdate := NewSvcBinding(ctx, "testnet", "myService", "1.0.0", "testnet",
 		addrs[1], Global, sdk.Coins{sdk.NewCoin("iris", sdk.NewInt(100))}, []sdk.Coin{{"iris", sdk.NewInt(1)}},
 		Level{AvgRspTime: 10000, UsableTime: 9999}, true)
 	err = keeper.UpdateServiceBinding(ctx, svcBindingUpdate)
@@ -83,6 +85,7 @@ func TestKeeper_service_Call(t *testing.T) {
 
 	serviceDef := NewSvcDef("myService",
 		"testnet",
+		"1.0.0",
 		"the service for unit test",
 		[]string{"test", "tutorial"},
 		addrs[0],
@@ -91,13 +94,13 @@ func TestKeeper_service_Call(t *testing.T) {
 
 	keeper.AddServiceDefinition(ctx, serviceDef)
 
-	svcBinding := NewSvcBinding(ctx, "testnet", "myService", "testnet",
+	svcBinding := NewSvcBinding(ctx, "testnet", "myService", "1.0.0", "testnet",
 		addrs[1], Global, sdk.Coins{sdk.NewCoin("iris", sdk.NewInt(1000))}, []sdk.Coin{{"iris", sdk.NewInt(1)}},
 		Level{AvgRspTime: 10000, UsableTime: 9999}, true)
 	keeper.AddServiceBinding(ctx, svcBinding)
 
 	// service request
-	svcRequest := NewSvcRequest("testnet", "myService", "testnet", "testnet",
+	svcRequest := NewSvcRequest("testnet", "myService", "1.0.0", "testnet", "testnet",
 		addrs[2], addrs[1], 1, []byte("1234"), sdk.Coins{sdk.NewCoin("iris", sdk.NewInt(1))}, false)
 	svcRequest, err := keeper.AddRequest(ctx, svcRequest)
 	require.NoError(t, err)
@@ -119,6 +122,182 @@ func TestKeeper_service_Call(t *testing.T) {
 	}
 }
 
+func TestKeeper_service_DefinitionVersions(t *testing.T) {
+	mapp, keeper, _, addrs, _, _ := getMockApp(t, 3)
+	SortAddresses(addrs)
+	mapp.BeginBlock(abci.RequestBeginBlock{})
+	ctx := mapp.BaseApp.NewContext(false, abci.Header{})
+	keeper.ck.AddCoins(ctx, addrs[1], sdk.Coins{sdk.NewCoin("iris", sdk.NewInt(2100))})
+	handler := NewHandler(keeper)
+
+	msgDef := NewMsgSvcDef("myService", "testnet", "", "the service for unit test",
+		[]string{"test"}, addrs[0], "unit test author", idlContent)
+	require.True(t, handler(ctx, msgDef).IsOK())
+
+	latest, found := keeper.GetLatestDefinitionVersion(ctx, "testnet", "myService")
+	require.True(t, found)
+	require.Equal(t, DefaultSvcDefVersion, latest)
+
+	// only the author can add a version which must be greater than the latest one
+	msgUpdate := NewMsgSvcDefUpdate("myService", "testnet", "1.10.0", "the service for unit test",
+		[]string{"test"}, addrs[1], "unit test author", idlContent, true)
+	require.Equal(t, CodeNotMatchingAuthor, handler(ctx, msgUpdate).Code)
+
+	msgUpdate.Author = addrs[0]
+	msgUpdate.Version = "0.9.0"
+	require.Equal(t, CodeInvalidDefVersion, handler(ctx, msgUpdate).Code)
+
+	msgUpdate.Version = "1.10.0"
+	require.True(t, handler(ctx, msgUpdate).IsOK())
+
+	msgUpdate.Version = "1.9.0"
+	msgUpdate.DeprecatePrevious = false
+	require.Equal(t, CodeInvalidDefVersion, handler(ctx, msgUpdate).Code)
+
+	latestDef, found := keeper.GetServiceDefinition(ctx, "testnet", "myService", "")
+	require.True(t, found)
+	require.Equal(t, "1.10.0", latestDef.Version)
+	require.False(t, latestDef.Deprecated)
+
+	oldDef, found := keeper.GetServiceDefinition(ctx, "testnet", "myService", "1.0.0")
+	require.True(t, found)
+	require.True(t, oldDef.Deprecated)
+
+	// the versions are listed in semver order
+	bz, err := queryDefinitionVersions(ctx, abci.RequestQuery{
+		Data: keeper.cdc.MustMarshalJSON(QueryServiceParams{DefChainID: "testnet", ServiceName: "myService"}),
+	}, keeper)
+	require.Nil(t, err)
+	var svcDefs []SvcDef
+	keeper.cdc.MustUnmarshalJSON(bz, &svcDefs)
+	require.Equal(t, 2, len(svcDefs))
+	require.Equal(t, "1.0.0", svcDefs[0].Version)
+	require.Equal(t, "1.10.0", svcDefs[1].Version)
+
+	// the versions are paged in semver order too
+	bz, err = queryDefinitionVersions(ctx, abci.RequestQuery{
+		Data: keeper.cdc.MustMarshalJSON(QueryServiceParams{DefChainID: "testnet", ServiceName: "myService", Page: 2, Size: 1}),
+	}, keeper)
+	require.Nil(t, err)
+	keeper.cdc.MustUnmarshalJSON(bz, &svcDefs)
+	require.Equal(t, 1, len(svcDefs))
+	require.Equal(t, "1.10.0", svcDefs[0].Version)
+
+	// deprecated versions can't be bound
+	deposit := sdk.Coins{sdk.NewCoin("iris", sdk.NewInt(1000))}
+	prices := []sdk.Coin{{"iris", sdk.NewInt(1)}}
+	level := Level{AvgRspTime: 10000, UsableTime: 9999}
+	msgBind := NewMsgSvcBind("testnet", "myService", "1.0.0", "testnet", addrs[1], Global, deposit, prices, level)
+	require.Equal(t, CodeSvcDefDeprecated, handler(ctx, msgBind).Code)
+
+	msgBind.DefVersion = "2.0.0"
+	require.Equal(t, CodeSvcDefVersionNotExists, handler(ctx, msgBind).Code)
+
+	msgBind.DefVersion = "1.10.0"
+	require.True(t, handler(ctx, msgBind).IsOK())
+
+	// requests must reference the version of the binding
+	msgRequest := NewMsgSvcRequest("testnet", "myService", "1.0.0", "testnet", "testnet",
+		addrs[2], addrs[1], 1, []byte("1234"), sdk.Coins{sdk.NewCoin("iris", sdk.NewInt(1))}, false)
+	require.Equal(t, CodeNotMatchingDefVersion, handler(ctx, msgRequest).Code)
+}
+
+func TestKeeper_service_MigrateDefinitionVersions(t *testing.T) {
+	mapp, keeper, _, addrs, _, _ := getMockApp(t, 3)
+	mapp.BeginBlock(abci.RequestBeginBlock{})
+	ctx := mapp.BaseApp.NewContext(false, abci.Header{})
+	store := ctx.KVStore(keeper.storeKey)
+
+	// the state stored before the versioning of the definitions
+	svcDef := NewSvcDef("myService", "testnet", "", "the service for unit test",
+		[]string{"test"}, addrs[0], "unit test author", idlContent)
+	store.Set(append(serviceDefinitionKey, getStringsKey([]string{"testnet", "myService"})...),
+		keeper.cdc.MustMarshalBinaryLengthPrefixed(svcDef))
+	method := MethodProperty{ID: 1, Name: "SayHello", Description: "sayHello"}
+	store.Set(append(methodPropertyKey, getStringsKey([]string{"testnet", "myService", string(rune(method.ID))})...),
+		keeper.cdc.MustMarshalBinaryLengthPrefixed(method))
+	binding := NewSvcBinding(ctx, "testnet", "myService", "", "testnet",
+		addrs[1], Global, sdk.Coins{sdk.NewCoin("iris", sdk.NewInt(1000))}, []sdk.Coin{{"iris", sdk.NewInt(1)}},
+		Level{AvgRspTime: 10000, UsableTime: 9999}, true)
+	store.Set(GetServiceBindingKey("testnet", "myService", "testnet", addrs[1]),
+		keeper.cdc.MustMarshalBinaryLengthPrefixed(binding))
+
+	keeper.MigrateDefinitionVersions(ctx)
+	// migrating again leaves the state as is
+	keeper.MigrateDefinitionVersions(ctx)
+
+	migratedDef, found := keeper.GetServiceDefinition(ctx, "testnet", "myService", "")
+	require.True(t, found)
+	require.Equal(t, DefaultSvcDefVersion, migratedDef.Version)
+	require.Equal(t, svcDef.IDLContent, migratedDef.IDLContent)
+
+	migratedMethod, found := keeper.GetMethod(ctx, "testnet", "myService", DefaultSvcDefVersion, method.ID)
+	require.True(t, found)
+	require.Equal(t, method, migratedMethod)
+
+	migratedBinding, found := keeper.GetServiceBinding(ctx, "testnet", "myService", "testnet", addrs[1])
+	require.True(t, found)
+	require.Equal(t, DefaultSvcDefVersion, migratedBinding.DefVersion)
+
+	iterator := keeper.ServiceDefinitionsIterator(ctx, "testnet", "myService")
+	defer iterator.Close()
+	versions := 0
+	for ; iterator.Valid(); iterator.Next() {
+		versions++
+	}
+	require.Equal(t, 1, versions)
+}
+
+func TestKeeper_service_BindingStats(t *testing.T) {
+	mapp, keeper, _, addrs, _, _ := getMockApp(t, 3)
+	SortAddresses(addrs)
+	mapp.BeginBlock(abci.RequestBeginBlock{})
+	ctx := mapp.BaseApp.NewContext(false, abci.Header{}).WithBlockHeight(10)
+	keeper.ck.AddCoins(ctx, addrs[1], sdk.Coins{sdk.NewCoin("iris", sdk.NewInt(1100))})
+	keeper.ck.AddCoins(ctx, addrs[2], sdk.Coins{sdk.NewCoin("iris", sdk.NewInt(1100))})
+	mapp.AccountKeeper.IncreaseTotalLoosenToken(ctx, sdk.Coins{sdk.NewCoin("iris", sdk.NewInt(2200))})
+	handler := NewHandler(keeper)
+
+	serviceDef := NewSvcDef("myService", "testnet", "1.0.0", "the service for unit test",
+		[]string{"test", "tutorial"}, addrs[0], "unit test author", idlContent)
+	keeper.AddServiceDefinition(ctx, serviceDef)
+	keeper.AddMethods(ctx, serviceDef)
+
+	svcBinding := NewSvcBinding(ctx, "testnet", "myService", "1.0.0", "testnet",
+		addrs[1], Global, sdk.Coins{sdk.NewCoin("iris", sdk.NewInt(1000))}, []sdk.Coin{{"iris", sdk.NewInt(1)}},
+		Level{AvgRspTime: 10000, UsableTime: 9999}, true)
+	require.NoError(t, keeper.AddServiceBinding(ctx, svcBinding))
+
+	// a request served after 3 blocks
+	msgRequest := NewMsgSvcRequest("testnet", "myService", "1.0.0", "testnet", "testnet",
+		addrs[2], addrs[1], 1, []byte("1234"), sdk.Coins{sdk.NewCoin("iris", sdk.NewInt(1))}, false)
+	require.True(t, handler(ctx, msgRequest).IsOK())
+	req, found := keeper.GetActiveRequest(ctx, 10+keeper.GetParamSet(ctx).MaxRequestTimeout, 10, 0)
+	require.True(t, found)
+
+	msgResponse := NewMsgSvcResponse("testnet", req.RequestID(), addrs[1], []byte("1234"), nil)
+	require.True(t, handler(ctx.WithBlockHeight(13), msgResponse).IsOK())
+
+	binding, _ := keeper.GetServiceBinding(ctx, "testnet", "myService", "testnet", addrs[1])
+	require.Equal(t, BindingStats{ServedRequests: 1, TotalRspBlocks: 3}, binding.Stats)
+	require.True(t, binding.Stats.AvgRspBlocks().Equal(sdk.NewDec(3)))
+
+	// a request timed out
+	ctx = ctx.WithBlockHeight(20)
+	keeper.SetIntraTxCounter(ctx, 0)
+	require.True(t, handler(ctx, msgRequest).IsOK())
+	EndBlocker(ctx.WithBlockHeight(20+keeper.GetParamSet(ctx).MaxRequestTimeout), keeper)
+
+	binding, _ = keeper.GetServiceBinding(ctx, "testnet", "myService", "testnet", addrs[1])
+	require.Equal(t, BindingStats{ServedRequests: 1, TimeoutRequests: 1, TotalRspBlocks: 3, Slashes: 1}, binding.Stats)
+	require.True(t, binding.Stats.SuccessRate().Equal(sdk.NewDecWithPrec(5, 1)))
+
+	// nothing deducted is not counted as a slash
+	require.NoError(t, keeper.Slash(ctx, binding, sdk.Coins{sdk.NewCoin("iris", sdk.ZeroInt())}))
+	binding, _ = keeper.GetServiceBinding(ctx, "testnet", "myService", "testnet", addrs[1])
+	require.Equal(t, int64(1), binding.Stats.Slashes)
+}
+
 const idlContent = `
 	syntax = "proto3";
 
@@ -139,3 +318,39 @@ const idlContent = `
 	message HelloReply {
 		string message = 1;
 	}`
+
+func TestBeginBlocker_MigrateDefinitionVersions(t *testing.T) {
+	mapp, keeper, _, addrs, _, _ := getMockApp(t, 3)
+	mapp.BeginBlock(abci.RequestBeginBlock{})
+	ctx := mapp.BaseApp.NewContext(false, abci.Header{})
+	keeper.ck.AddCoins(ctx, addrs[1], sdk.Coins{sdk.NewCoin("iris", sdk.NewInt(1100))})
+	handler := NewHandler(keeper)
+
+	// a definition stored before the versioning of the definitions
+	store := ctx.KVStore(keeper.storeKey)
+	svcDef := NewSvcDef("myService", "testnet", "", "the service for unit test",
+		[]string{"test"}, addrs[0], "unit test author", idlContent)
+	store.Set(append(serviceDefinitionKey, getStringsKey([]string{"testnet", "myService"})...),
+		keeper.cdc.MustMarshalBinaryLengthPrefixed(svcDef))
+	method := MethodProperty{ID: 1, Name: "SayHello", Description: "sayHello"}
+	store.Set(append(methodPropertyKey, getStringsKey([]string{"testnet", "myService", string(rune(method.ID))})...),
+		keeper.cdc.MustMarshalBinaryLengthPrefixed(method))
+
+	msgBind := NewMsgSvcBind("testnet", "myService", DefaultSvcDefVersion, "testnet", addrs[1], Global,
+		sdk.Coins{sdk.NewCoin("iris", sdk.NewInt(1000))}, []sdk.Coin{{"iris", sdk.NewInt(1)}}, Level{AvgRspTime: 10000, UsableTime: 9999})
+	require.Equal(t, CodeSvcDefNotExists, handler(ctx, msgBind).Code)
+
+	// the blocks of the earlier protocol versions don't migrate the definitions
+	BeginBlocker(ctx.WithBlockHeader(abci.Header{Version: abci.Version{App: DefinitionVersionsProtocolVersion - 1}}), keeper)
+	require.False(t, keeper.DefinitionVersionsMigrated(ctx))
+	_, found := keeper.GetLatestDefinitionVersion(ctx, "testnet", "myService")
+	require.False(t, found)
+
+	BeginBlocker(ctx.WithBlockHeader(abci.Header{Version: abci.Version{App: DefinitionVersionsProtocolVersion}}), keeper)
+	require.True(t, keeper.DefinitionVersionsMigrated(ctx))
+	latest, found := keeper.GetLatestDefinitionVersion(ctx, "testnet", "myService")
+	require.True(t, found)
+	require.Equal(t, DefaultSvcDefVersion, latest)
+	res := handler(ctx, msgBind)
+	require.True(t, res.IsOK(), res.Log)
+}
@@ -15,7 +15,7 @@ const (
 	description   = "description"
 )
 
-var _, _, _, _, _, _, _, _, _, _, _ sdk.Msg = MsgSvcDef{}, MsgSvcBind{}, MsgSvcBindingUpdate{}, MsgSvcDisable{}, MsgSvcEnable{}, MsgSvcRefundDeposit{}, MsgSvcRequest{}, MsgSvcResponse{}, MsgSvcRefundFees{}, MsgSvcWithdrawFees{}, MsgSvcWithdrawTax{}
+var _, _, _, _, _, _, _, _, _, _, _, _ sdk.Msg = MsgSvcDef{}, MsgSvcDefUpdate{}, MsgSvcBind{}, MsgSvcBindingUpdate{}, MsgSvcDisable{}, MsgSvcEnable{}, MsgSvcRefundDeposit{}, MsgSvcRequest{}, MsgSvcResponse{}, MsgSvcRefundFees{}, MsgSvcWithdrawFees{}, MsgSvcWithdrawTax{}
 
 //______________________________________________________________________
 
@@ -24,11 +24,12 @@ type MsgSvcDef struct {
 	SvcDef
 }
 
-func NewMsgSvcDef(name, chainId, description string, tags []string, author sdk.AccAddress, authorDescription, idlContent string) MsgSvcDef {
+func NewMsgSvcDef(name, chainId, version, description string, tags []string, author sdk.AccAddress, authorDescription, idlContent string) MsgSvcDef {
 	return MsgSvcDef{
 		SvcDef{
 			Name:              name,
 			ChainId:           chainId,
+			Version:           version,
 			Description:       description,
 			Tags:              tags,
 			Author:            author,
@@ -59,6 +60,11 @@ func (msg MsgSvcDef) ValidateBasic() sdk.Error {
 	if !validServiceName(msg.Name) {
 		return ErrInvalidServiceName(DefaultCodespace, msg.Name)
 	}
+	if len(msg.Version) > 0 {
+		if err := validDefVersion(msg.Version); err != nil {
+			return err
+		}
+	}
 	if len(msg.Author) == 0 {
 		return ErrInvalidAuthor(DefaultCodespace)
 	}
@@ -82,6 +88,46 @@ func (msg MsgSvcDef) GetSigners() []sdk.AccAddress {
 	return []sdk.AccAddress{msg.Author}
 }
 
+//______________________________________________________________________
+
+// MsgSvcDefUpdate - struct for add a new version to a service definition
+type MsgSvcDefUpdate struct {
+	SvcDef
+	DeprecatePrevious bool `json:"deprecate_previous"` // deprecate all the previous versions
+}
+
+func NewMsgSvcDefUpdate(name, chainId, version, description string, tags []string, author sdk.AccAddress, authorDescription, idlContent string, deprecatePrevious bool) MsgSvcDefUpdate {
+	return MsgSvcDefUpdate{
+		SvcDef:            NewSvcDef(name, chainId, version, description, tags, author, authorDescription, idlContent),
+		DeprecatePrevious: deprecatePrevious,
+	}
+}
+
+func (msg MsgSvcDefUpdate) Route() string { return MsgRoute }
+func (msg MsgSvcDefUpdate) Type() string  { return "service_define_update" }
+
+func (msg MsgSvcDefUpdate) GetSignBytes() []byte {
+	if len(msg.Tags) == 0 {
+		msg.Tags = nil
+	}
+	b, err := msgCdc.MarshalJSON(msg)
+	if err != nil {
+		panic(err)
+	}
+	return sdk.MustSortJSON(b)
+}
+
+func (msg MsgSvcDefUpdate) ValidateBasic() sdk.Error {
+	if len(msg.Version) == 0 {
+		return ErrInvalidDefVersion(DefaultCodespace, "version is empty")
+	}
+	return MsgSvcDef{msg.SvcDef}.ValidateBasic()
+}
+
+func (msg MsgSvcDefUpdate) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Author}
+}
+
 func validateMethods(methods []protoidl.Method) (bool, sdk.Error) {
 	for _, method := range methods {
 		if len(method.Name) == 0 {
@@ -143,12 +189,14 @@ type MsgSvcBind struct {
 	Deposit     sdk.Coins      `json:"deposit"`
 	Prices      []sdk.Coin     `json:"price"`
 	Level       Level          `json:"level"`
+	DefVersion  string         `json:"def_version"`
 }
 
-func NewMsgSvcBind(defChainID, defName, bindChainID string, provider sdk.AccAddress, bindingType BindingType, deposit sdk.Coins, prices []sdk.Coin, level Level) MsgSvcBind {
+func NewMsgSvcBind(defChainID, defName, defVersion, bindChainID string, provider sdk.AccAddress, bindingType BindingType, deposit sdk.Coins, prices []sdk.Coin, level Level) MsgSvcBind {
 	return MsgSvcBind{
 		DefChainID:  defChainID,
 		DefName:     defName,
+		DefVersion:  defVersion,
 		BindChainID: bindChainID,
 		Provider:    provider,
 		BindingType: bindingType,
@@ -182,6 +230,9 @@ func (msg MsgSvcBind) ValidateBasic() sdk.Error {
 	if err := ensureNameLength(msg.DefName); err != nil {
 		return err
 	}
+	if err := validDefVersion(msg.DefVersion); err != nil {
+		return err
+	}
 	if !validBindingType(msg.BindingType) {
 		return ErrInvalidBindingType(DefaultCodespace, msg.BindingType)
 	}
@@ -218,12 +269,14 @@ type MsgSvcBindingUpdate struct {
 	Deposit     sdk.Coins      `json:"deposit"`
 	Prices      []sdk.Coin     `json:"price"`
 	Level       Level          `json:"level"`
+	DefVersion  string         `json:"def_version"`
 }
 
-func NewMsgSvcBindingUpdate(defChainID, defName, bindChainID string, provider sdk.AccAddress, bindingType BindingType, deposit sdk.Coins, prices []sdk.Coin, level Level) MsgSvcBindingUpdate {
+func NewMsgSvcBindingUpdate(defChainID, defName, defVersion, bindChainID string, provider sdk.AccAddress, bindingType BindingType, deposit sdk.Coins, prices []sdk.Coin, level Level) MsgSvcBindingUpdate {
 	return MsgSvcBindingUpdate{
 		DefChainID:  defChainID,
 		DefName:     defName,
+		DefVersion:  defVersion,
 		BindChainID: bindChainID,
 		Provider:    provider,
 		BindingType: bindingType,
@@ -256,6 +309,11 @@ func (msg MsgSvcBindingUpdate) ValidateBasic() sdk.Error {
 	if err := ensureNameLength(msg.DefName); err != nil {
 		return err
 	}
+	if len(msg.DefVersion) > 0 {
+		if err := validDefVersion(msg.DefVersion); err != nil {
+			return err
+		}
+	}
 	if len(msg.Provider) == 0 {
 		return sdk.ErrInvalidAddress(msg.Provider.String())
 	}
@@ -458,12 +516,14 @@ type MsgSvcRequest struct {
 	Input       []byte         `json:"input"`
 	ServiceFee  sdk.Coins      `json:"service_fee"`
 	Profiling   bool           `json:"profiling"`
+	DefVersion  string         `json:"def_version"`
 }
 
-func NewMsgSvcRequest(defChainID, defName, bindChainID, reqChainID string, consumer, provider sdk.AccAddress, methodID int16, input []byte, serviceFee sdk.Coins, profiling bool) MsgSvcRequest {
+func NewMsgSvcRequest(defChainID, defName, defVersion, bindChainID, reqChainID string, consumer, provider sdk.AccAddress, methodID int16, input []byte, serviceFee sdk.Coins, profiling bool) MsgSvcRequest {
 	return MsgSvcRequest{
 		DefChainID:  defChainID,
 		DefName:     defName,
+		DefVersion:  defVersion,
 		BindChainID: bindChainID,
 		ReqChainID:  reqChainID,
 		Consumer:    consumer,
@@ -505,6 +565,9 @@ func (msg MsgSvcRequest) ValidateBasic() sdk.Error {
 	if err := ensureNameLength(msg.DefName); err != nil {
 		return err
 	}
+	if err := validDefVersion(msg.DefVersion); err != nil {
+		return err
+	}
 	if len(msg.Provider) == 0 {
 		return sdk.ErrInvalidAddress(msg.Provider.String())
 	}
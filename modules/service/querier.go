@@ -1,18 +1,21 @@
 package service
 
 import (
+	"sort"
+
 	"github.com/NPC-Chain/npcchub/codec"
 	sdk "github.com/NPC-Chain/npcchub/types"
 	abci "github.com/tendermint/tendermint/abci/types"
 )
 
 const (
-	QueryDefinition = "definition"
-	QueryBinding    = "binding"
-	QueryBindings   = "bindings"
-	QueryRequests   = "requests"
-	QueryResponse   = "response"
-	QueryFees       = "fees"
+	QueryDefinition         = "definition"
+	QueryDefinitionVersions = "definition_versions"
+	QueryBinding            = "binding"
+	QueryBindings           = "bindings"
+	QueryRequests           = "requests"
+	QueryResponse           = "response"
+	QueryFees               = "fees"
 )
 
 func NewQuerier(k Keeper) sdk.Querier {
@@ -20,6 +23,8 @@ func NewQuerier(k Keeper) sdk.Querier {
 		switch path[0] {
 		case QueryDefinition:
 			return queryDefinition(ctx, req, k)
+		case QueryDefinitionVersions:
+			return queryDefinitionVersions(ctx, req, k)
 		case QueryBinding:
 			return queryBinding(ctx, req, k)
 		case QueryBindings:
@@ -39,6 +44,9 @@ func NewQuerier(k Keeper) sdk.Querier {
 type QueryServiceParams struct {
 	DefChainID  string
 	ServiceName string
+	DefVersion  string // the latest version is queried if empty
+	Page        uint64 // page of the versions or bindings, starting from 1
+	Size        uint16 // items per page, 0 returns all of them
 }
 
 type DefinitionOutput struct {
@@ -52,12 +60,15 @@ func queryDefinition(ctx sdk.Context, req abci.RequestQuery, k Keeper) ([]byte,
 	if err != nil {
 		return nil, sdk.ParseParamsErr(err)
 	}
-	svcDef, found := k.GetServiceDefinition(ctx, params.DefChainID, params.ServiceName)
+	svcDef, found := k.GetServiceDefinition(ctx, params.DefChainID, params.ServiceName, params.DefVersion)
 	if !found {
+		if len(params.DefVersion) > 0 {
+			return nil, ErrSvcDefVersionNotExists(DefaultCodespace, params.DefChainID, params.ServiceName, params.DefVersion)
+		}
 		return nil, ErrSvcDefNotExists(DefaultCodespace, params.DefChainID, params.ServiceName)
 	}
 
-	iterator := k.GetMethods(ctx, params.DefChainID, params.ServiceName)
+	iterator := k.GetMethods(ctx, params.DefChainID, params.ServiceName, svcDef.Version)
 	defer iterator.Close()
 	var methods []MethodProperty
 	for ; iterator.Valid(); iterator.Next() {
@@ -75,11 +86,45 @@ func queryDefinition(ctx sdk.Context, req abci.RequestQuery, k Keeper) ([]byte,
 	return bz, nil
 }
 
+func queryDefinitionVersions(ctx sdk.Context, req abci.RequestQuery, k Keeper) ([]byte, sdk.Error) {
+	var params QueryServiceParams
+	err := k.cdc.UnmarshalJSON(req.Data, &params)
+	if err != nil {
+		return nil, sdk.ParseParamsErr(err)
+	}
+
+	iterator := k.ServiceDefinitionsIterator(ctx, params.DefChainID, params.ServiceName)
+	defer iterator.Close()
+	var svcDefs []SvcDef
+	for ; iterator.Valid(); iterator.Next() {
+		var svcDef SvcDef
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(iterator.Value(), &svcDef)
+		svcDefs = append(svcDefs, svcDef)
+	}
+	if len(svcDefs) == 0 {
+		return nil, ErrSvcDefNotExists(DefaultCodespace, params.DefChainID, params.ServiceName)
+	}
+
+	// the store keeps the versions in lexical order, they are paged in version order
+	sort.Slice(svcDefs, func(i, j int) bool {
+		return compareDefVersions(svcDefs[i].Version, svcDefs[j].Version) < 0
+	})
+	start, end := sdk.PageBounds(len(svcDefs), params.Page, params.Size)
+
+	bz, err := codec.MarshalJSONIndent(k.cdc, svcDefs[start:end])
+	if err != nil {
+		return nil, sdk.MarshalResultErr(err)
+	}
+	return bz, nil
+}
+
 type QueryBindingParams struct {
 	DefChainID  string
 	ServiceName string
 	BindChainId string
 	Provider    sdk.AccAddress
+	Page        uint64 // page of the requests, starting from 1
+	Size        uint16 // requests per page, 0 returns all of them
 }
 
 func queryBinding(ctx sdk.Context, req abci.RequestQuery, k Keeper) ([]byte, sdk.Error) {
@@ -106,7 +151,7 @@ func queryBindings(ctx sdk.Context, req abci.RequestQuery, k Keeper) ([]byte, sd
 		return nil, sdk.ParseParamsErr(err)
 	}
 
-	iterator := k.ServiceBindingsIterator(ctx, params.DefChainID, params.ServiceName)
+	iterator := sdk.PaginatedIterator(k.ServiceBindingsIterator(ctx, params.DefChainID, params.ServiceName), params.Page, params.Size)
 	defer iterator.Close()
 	var bindings []SvcBinding
 	for ; iterator.Valid(); iterator.Next() {
@@ -129,7 +174,7 @@ func queryRequests(ctx sdk.Context, req abci.RequestQuery, k Keeper) ([]byte, sd
 		return nil, sdk.ParseParamsErr(err)
 	}
 
-	iterator := k.ActiveBindRequestsIterator(ctx, params.DefChainID, params.ServiceName, params.BindChainId, params.Provider)
+	iterator := sdk.PaginatedIterator(k.ActiveBindRequestsIterator(ctx, params.DefChainID, params.ServiceName, params.BindChainId, params.Provider), params.Page, params.Size)
 	defer iterator.Close()
 	var requests []SvcRequest
 	for ; iterator.Valid(); iterator.Next() {
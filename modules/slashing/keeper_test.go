@@ -484,3 +484,15 @@ func TestValidatorDippingInAndOut(t *testing.T) {
 	require.Equal(t, sdk.Unbonding, validator.Status)
 
 }
+
+// The genesis params allowing less missed blocks than a param change proposal are still imported
+func TestValidateParamsMissedBlocks(t *testing.T) {
+	params := DefaultParamsForTestnet()
+	params.SignedBlocksWindow = 100
+	params.MinSignedPerWindow = sdk.NewDecWithPrec(9, 1)
+	require.Nil(t, validateParams(params))
+	require.NotNil(t, params.ValidateParamSet())
+
+	params.MinSignedPerWindow = sdk.NewDecWithPrec(5, 1)
+	require.Nil(t, params.ValidateParamSet())
+}
@@ -11,6 +11,7 @@ import (
 )
 
 var _ params.ParamSet = (*Params)(nil)
+var _ params.ParamSetValidator = (*Params)(nil)
 
 // Default parameter namespace
 const (
@@ -170,6 +171,12 @@ func (p *Params) Validate(key string, value string) (interface{}, sdk.Error) {
 	}
 }
 
+// Implements params.ParamSetValidator. The bound on the missed blocks only applies to the param
+// change proposals, the genesis files of the existing chains may hold params out of it.
+func (p *Params) ValidateParamSet() sdk.Error {
+	return validateMissedBlocks(p.SignedBlocksWindow, p.MinSignedPerWindow)
+}
+
 func (p *Params) GetParamSpace() string {
 	return DefaultParamspace
 }
@@ -404,3 +411,12 @@ func (k Keeper) SlashFractionCensorship(ctx sdk.Context) (res sdk.Dec) {
 	k.paramspace.Get(ctx, KeySlashFractionCensorship, &res)
 	return
 }
+
+// a validator must be allowed to miss at least one minute of blocks in a window before being jailed
+func validateMissedBlocks(signedBlocksWindow int64, minSignedPerWindow sdk.Dec) sdk.Error {
+	minSigned := sdk.NewDec(signedBlocksWindow).Mul(minSignedPerWindow).RoundInt64()
+	if signedBlocksWindow-minSigned < BlocksPerMinute {
+		return sdk.NewError(params.DefaultCodespace, params.CodeInvalidSlashParams, fmt.Sprintf("Slash SignedBlocksWindow [%d] and MinSignedPerWindow [%s] should allow at least %d missed blocks", signedBlocksWindow, minSignedPerWindow.String(), BlocksPerMinute))
+	}
+	return nil
+}
@@ -5,6 +5,7 @@ import (
 	"container/list"
 	"fmt"
 	"strconv"
+	"sync"
 	"time"
 
 	"github.com/NPC-Chain/npcchub/modules/stake/types"
@@ -24,6 +25,9 @@ type cachedValidator struct {
 var validatorCache = make(map[string]cachedValidator, 500)
 var validatorCacheList = list.New()
 
+// validatorCacheMtx guards the cache, the concurrent queries read the validators outside of the ABCI lock
+var validatorCacheMtx sync.Mutex
+
 // get a single validator
 func (k Keeper) GetValidator(ctx sdk.Context, addr sdk.ValAddress) (validator types.Validator, found bool) {
 	store := ctx.KVStore(k.storeKey)
@@ -34,6 +38,8 @@ func (k Keeper) GetValidator(ctx sdk.Context, addr sdk.ValAddress) (validator ty
 
 	// If these amino encoded bytes are in the cache, return the cached validator
 	strValue := string(value)
+	validatorCacheMtx.Lock()
+	defer validatorCacheMtx.Unlock()
 	if val, ok := validatorCache[strValue]; ok {
 		valToReturn := val.val
 		// Doesn't mutate the cache's value
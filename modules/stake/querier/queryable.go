@@ -82,8 +82,12 @@ type QueryDelegatorParams struct {
 // - 'custom/stake/validatorDelegations'
 // - 'custom/stake/validatorUnbondingDelegations'
 // - 'custom/stake/validatorRedelegations'
+//
+// Page and Size select a page of the delegation lists, a Size of 0 returns the whole list
 type QueryValidatorParams struct {
 	ValidatorAddr sdk.ValAddress
+	Page          uint64
+	Size          uint16
 }
 
 // defines the params for the following queries:
@@ -165,6 +169,8 @@ func queryValidatorDelegations(ctx sdk.Context, cdc *codec.Codec, req abci.Reque
 		return []byte{}, sdk.ErrUnknownAddress("")
 	}
 	delegations := k.GetValidatorDelegations(ctx, params.ValidatorAddr)
+	start, end := sdk.PageBounds(len(delegations), params.Page, params.Size)
+	delegations = delegations[start:end]
 	res, errRes = codec.MarshalJSONIndent(cdc, delegations)
 	if errRes != nil {
 		return nil, sdk.MarshalResultErr(err)
@@ -181,6 +187,8 @@ func queryValidatorUnbondingDelegations(ctx sdk.Context, cdc *codec.Codec, req a
 	}
 
 	unbonds := k.GetUnbondingDelegationsFromValidator(ctx, params.ValidatorAddr)
+	start, end := sdk.PageBounds(len(unbonds), params.Page, params.Size)
+	unbonds = unbonds[start:end]
 
 	res, errRes = codec.MarshalJSONIndent(cdc, unbonds)
 	if errRes != nil {
@@ -198,6 +206,8 @@ func queryValidatorRedelegations(ctx sdk.Context, cdc *codec.Codec, req abci.Req
 	}
 
 	redelegations := k.GetRedelegationsFromValidator(ctx, params.ValidatorAddr)
+	start, end := sdk.PageBounds(len(redelegations), params.Page, params.Size)
+	redelegations = redelegations[start:end]
 
 	res, errRes = codec.MarshalJSONIndent(cdc, redelegations)
 	if errRes != nil {
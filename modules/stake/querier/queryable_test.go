@@ -300,6 +300,19 @@ func TestQueryDelegation(t *testing.T) {
 	require.Nil(t, errRes)
 	require.Equal(t, delegationsRes[0], delegation)
 
+	// Query a page of the validator delegations
+	pageParams := NewQueryValidatorParams(addrVal1)
+	pageParams.Page, pageParams.Size = 2, 1
+	bz, errRes = cdc.MarshalJSON(pageParams)
+	require.Nil(t, errRes)
+	query.Data = bz
+	res, err = queryValidatorDelegations(ctx, cdc, query, keeper)
+	require.Nil(t, err)
+	delegationsRes = nil
+	errRes = cdc.UnmarshalJSON(res, &delegationsRes)
+	require.Nil(t, errRes)
+	require.Empty(t, delegationsRes)
+
 	// Query unbonging delegation
 	keeper.BeginUnbonding(ctx, addrAcc2, val1.OperatorAddr, sdk.NewDec(10))
 
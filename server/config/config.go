@@ -2,12 +2,24 @@ package config
 
 import (
 	"fmt"
+	"time"
 
+	"github.com/NPC-Chain/npcchub/store"
 	sdk "github.com/NPC-Chain/npcchub/types"
 )
 
+// DefaultHaltExportFile is the file the genesis is exported to at the halt height, relative to the home directory
+const DefaultHaltExportFile = "config/halt_genesis.json"
+
+// DefaultStreamingRotateSize is the size in bytes from which a new stream file is started
+const DefaultStreamingRotateSize int64 = 100 * 1024 * 1024
+
 const (
-	defaultMinimumFees = ""
+	defaultMinimumFees   = ""
+	defaultStreamingPath = "data/streaming"
+
+	defaultQueryWorkers = 0
+	defaultQueryTimeout = 10 * time.Second
 )
 
 // BaseConfig defines the server's basic configuration
@@ -15,11 +27,53 @@ type BaseConfig struct {
 	// Tx minimum fee
 	MinFees string `mapstructure:"minimum_fees"`
 
+	// Number of txs of a sender the mempool accepts until the next block, 0 means no limit
+	MaxPendingTxs uint64 `mapstructure:"max_pending_txs"`
+
 	// Enable invariant check, ignore this flag on testnet
 	CheckInvariant bool `mapstructure:"check_invariant"`
 
 	// Enable track coin flow
 	TrackCoinFlow bool `mapstructure:"track_coin_flow"`
+
+	// File to export the genesis to at the halt height of a SystemHalt proposal with the genesis export
+	HaltExportFile string `mapstructure:"halt_export_file"`
+
+	// Pruning strategy: syncable, nothing, archive, everything or custom
+	Pruning string `mapstructure:"pruning"`
+
+	// Number of recent states kept by the custom pruning strategy
+	PruningKeepRecent int64 `mapstructure:"pruning_keep_recent"`
+
+	// Every how many states one is kept by the custom pruning strategy, 0 keeps none
+	PruningKeepEvery int64 `mapstructure:"pruning_keep_every"`
+
+	// Number of blocks between two prunings of the custom pruning strategy
+	PruningInterval int64 `mapstructure:"pruning_interval"`
+
+	// Backend of the application state db: goleveldb, boltdb, badgerdb or memdb
+	AppDBBackend string `mapstructure:"app_db_backend"`
+
+	// Sink of the per-block change feed: empty (disabled), file or unix
+	StreamingSink string `mapstructure:"streaming_sink"`
+
+	// Directory of the stream files or path of the Unix socket
+	StreamingPath string `mapstructure:"streaming_path"`
+
+	// Size in bytes from which a new stream file is started
+	StreamingRotateSize int64 `mapstructure:"streaming_rotate_size"`
+
+	// Stop the node when a block fails to be streamed
+	StreamingHaltOnError bool `mapstructure:"streaming_halt_on_error"`
+
+	// Number of workers running the custom queries against the last committed state, 0 serves them in turn with the ABCI calls
+	QueryWorkers int `mapstructure:"query_workers"`
+
+	// Gas limit of a custom or store query, 0 means no limit
+	QueryGasLimit uint64 `mapstructure:"query_gas_limit"`
+
+	// Timeout of a custom query, including the wait for a free worker
+	QueryTimeout time.Duration `mapstructure:"query_timeout"`
 }
 
 // Config defines the server's top level configuration
@@ -39,7 +93,29 @@ func (c *Config) MinimumFees() sdk.Coins {
 	return fees
 }
 
+// PruningOptions returns the options of the configured pruning strategy.
+func (c *Config) PruningOptions() (sdk.PruningOptions, error) {
+	return sdk.ParsePruningOptions(c.Pruning, c.PruningKeepRecent, c.PruningKeepEvery, c.PruningInterval)
+}
+
 // DefaultConfig returns server's default configuration.
 func DefaultConfig() *Config {
-	return &Config{BaseConfig{MinFees: defaultMinimumFees, CheckInvariant: false, TrackCoinFlow: false}}
+	return &Config{BaseConfig{
+		MinFees:             defaultMinimumFees,
+		MaxPendingTxs:       0,
+		CheckInvariant:      false,
+		TrackCoinFlow:       false,
+		HaltExportFile:      DefaultHaltExportFile,
+		Pruning:             sdk.PruningSyncable,
+		PruningKeepRecent:   sdk.PruneSyncable.Options().KeepRecent,
+		PruningKeepEvery:    sdk.PruneSyncable.Options().KeepEvery,
+		PruningInterval:     sdk.PruneSyncable.Options().Interval,
+		AppDBBackend:        store.DefaultDBBackend,
+		StreamingSink:       "",
+		StreamingPath:       defaultStreamingPath,
+		StreamingRotateSize: DefaultStreamingRotateSize,
+		QueryWorkers:        defaultQueryWorkers,
+		QueryGasLimit:       0,
+		QueryTimeout:        defaultQueryTimeout,
+	}}
 }
@@ -1,9 +1,14 @@
 package config
 
 import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	sdk "github.com/NPC-Chain/npcchub/types"
+	"github.com/spf13/viper"
 	"github.com/stretchr/testify/require"
 )
 
@@ -17,3 +22,44 @@ func TestSetMinimumFees(t *testing.T) {
 	cfg.SetMinimumFees(sdk.Coins{sdk.NewCoin("foo", sdk.NewInt(100))})
 	require.Equal(t, "100foo", cfg.MinFees)
 }
+
+func TestPruningOptions(t *testing.T) {
+	cfg := DefaultConfig()
+	pruning, err := cfg.PruningOptions()
+	require.Nil(t, err)
+	require.Equal(t, sdk.PruneSyncable.Options(), pruning)
+
+	cfg.Pruning = sdk.PruningCustom
+	cfg.PruningKeepRecent, cfg.PruningKeepEvery, cfg.PruningInterval = 1000, 0, 10
+	pruning, err = cfg.PruningOptions()
+	require.Nil(t, err)
+	require.Equal(t, sdk.NewPruningOptions(1000, 0, 10), pruning)
+
+	cfg.PruningInterval = 0
+	_, err = cfg.PruningOptions()
+	require.NotNil(t, err)
+
+	cfg.Pruning = "unknown"
+	_, err = cfg.PruningOptions()
+	require.NotNil(t, err)
+}
+
+func TestWriteConfigFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "config")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	cfg := DefaultConfig()
+	cfg.QueryTimeout = 3 * time.Second
+	cfg.QueryGasLimit = 1000000
+	file := filepath.Join(dir, "iris.toml")
+	WriteConfigFile(file, cfg)
+
+	viper.Reset()
+	defer viper.Reset()
+	viper.SetConfigFile(file)
+	require.Nil(t, viper.ReadInConfig())
+	parsed, err := ParseConfig()
+	require.Nil(t, err)
+	require.Equal(t, cfg, parsed)
+}
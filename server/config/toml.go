@@ -16,12 +16,67 @@ const defaultConfigTemplate = `# This is a TOML config file.
 # Validators reject any tx from the mempool with less than the minimum fee per gas.
 minimum_fees = "{{ .BaseConfig.MinFees }}"
 
+# Number of txs of a sender, the first signer paying the fee, that CheckTx accepts until they are
+# included in a block, so that a single sender can't fill the mempool. 0 means no limit.
+# CheckTx also returns the priority of a tx, its gas price in iris-atto, in the "priority" tag.
+max_pending_txs = {{ .BaseConfig.MaxPendingTxs }}
+
 # Enable invariant check on mainnet, ignore this config on testnet
 check_invariant = {{ .BaseConfig.CheckInvariant }}
 
 # Enable track coin flow
 track_coin_flow = {{ .BaseConfig.TrackCoinFlow }}
 
+# File to export the genesis for zero height to when a SystemHalt proposal asks for the export,
+# relative to the home directory if not absolute. The node stops after the export.
+halt_export_file = "{{ .BaseConfig.HaltExportFile }}"
+
+# Pruning strategy of the application state: syncable (keep the last 100 states and every 10000th),
+# nothing or archive (keep all states, so that the node serves queries with proofs at any height),
+# everything (keep the current state only) or custom (keep the states set below).
+# Queries at pruned heights fail with the code 24 (height pruned) of the sdk codespace.
+pruning = "{{ .BaseConfig.Pruning }}"
+
+# Number of recent states kept by the custom pruning strategy
+pruning_keep_recent = {{ .BaseConfig.PruningKeepRecent }}
+
+# Every how many states one is kept by the custom pruning strategy, 0 keeps none of them
+pruning_keep_every = {{ .BaseConfig.PruningKeepEvery }}
+
+# Number of blocks between two prunings of the custom pruning strategy
+pruning_interval = {{ .BaseConfig.PruningInterval }}
+
+# Backend of the application state db: goleveldb, boltdb (built with -tags boltdb),
+# badgerdb (built with -tags badgerdb) or memdb (for tests only, the state is lost on exit).
+# Use "iris migrate-db" to move an existing application db to another backend.
+app_db_backend = "{{ .BaseConfig.AppDBBackend }}"
+
+# Sink of the change feed streaming, for every committed block, the ABCI requests and responses
+# and the writes made to each store: empty (disabled), file (JSON lines files in streaming_path,
+# rotated by size) or unix (JSON lines written to the Unix socket at streaming_path).
+streaming_sink = "{{ .BaseConfig.StreamingSink }}"
+
+# Directory of the stream files or path of the Unix socket, relative to the home directory if not absolute
+streaming_path = "{{ .BaseConfig.StreamingPath }}"
+
+# Size in bytes from which a new stream file is started
+streaming_rotate_size = {{ .BaseConfig.StreamingRotateSize }}
+
+# Stop the node when a block fails to be streamed, otherwise the block is skipped
+streaming_halt_on_error = {{ .BaseConfig.StreamingHaltOnError }}
+
+# Number of workers running the custom queries (e.g. from the LCD) against the last committed state,
+# concurrently with consensus. 0 serves the queries in turn with the other ABCI calls.
+# The queriers of the modules must not share mutable state with the state machine to be served concurrently.
+query_workers = {{ .BaseConfig.QueryWorkers }}
+
+# Gas limit of a custom or store query, 0 means no limit. Reads are charged as in transactions,
+# and the gas used by a query is returned in the info of its response, e.g. "gas_used:1234".
+query_gas_limit = {{ .BaseConfig.QueryGasLimit }}
+
+# Timeout of a custom query run by the query workers, including the wait for a free worker
+query_timeout = "{{ .BaseConfig.QueryTimeout }}"
+
 `
 
 var configTemplate *template.Template
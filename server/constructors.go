@@ -6,6 +6,8 @@ import (
 	"os"
 	"path/filepath"
 
+	"github.com/NPC-Chain/npcchub/store"
+	"github.com/spf13/viper"
 	abci "github.com/tendermint/tendermint/abci/types"
 	cfg "github.com/tendermint/tendermint/config"
 	"github.com/tendermint/tendermint/libs/log"
@@ -24,12 +26,16 @@ type (
 
 	// AppReset is a function that reset all app state to particular height
 	AppReset func(*Context, log.Logger, dbm.DB, io.Writer, int64) error
+
+	// AppReplay is a function that loads the app state of a particular height
+	// from the db to re-execute the following blocks
+	AppReplay func(*Context, log.Logger, dbm.DB, io.Writer, int64) (abci.Application, error)
 )
 
+// openDB opens the application db with the app_db_backend of iris.toml
 func openDB(rootDir string) (dbm.DB, error) {
 	dataDir := filepath.Join(rootDir, "data")
-	db, err := dbm.NewGoLevelDB("application", dataDir)
-	return db, err
+	return store.OpenDB("application", viper.GetString(flagAppDBBackend), dataDir)
 }
 
 func openTraceWriter(traceWriterFile string) (w io.Writer, err error) {
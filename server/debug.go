@@ -0,0 +1,283 @@
+package server
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/NPC-Chain/npcchub/store"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	abci "github.com/tendermint/tendermint/abci/types"
+	bc "github.com/tendermint/tendermint/blockchain"
+	tmcli "github.com/tendermint/tendermint/libs/cli"
+	sm "github.com/tendermint/tendermint/state"
+	tmtypes "github.com/tendermint/tendermint/types"
+	dbm "github.com/tendermint/tm-db"
+)
+
+const (
+	flagFromHeight = "from"
+	flagToHeight   = "to"
+	flagTraceDiff  = "trace-diff"
+)
+
+// DebugCmd groups the commands to investigate the state of a stopped node
+func DebugCmd(ctx *Context, appReplay AppReplay) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "debug",
+		Short: "Tools to investigate the state of a stopped node",
+	}
+	cmd.AddCommand(ReplayCmd(ctx, appReplay))
+	return cmd
+}
+
+// ReplayCmd re-executes the blocks of the block store on the app state of a height
+// and stops at the first block whose results or app hash differ from the recorded ones
+func ReplayCmd(ctx *Context, appReplay AppReplay) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "replay",
+		Short: "Re-execute blocks from the block store and stop at the first divergence",
+		Long: `Load the application state at the --from height from the application db and re-execute
+the blocks up to the --to height from the Tendermint block store, without networking.
+The results hash and app hash of every block are checked against the ones recorded by the
+next block header, and the replay stops at the first divergence. The hash of every store
+is printed, along with the recorded one when it differs, and --trace-diff also prints the
+writes of each block to the stores.
+
+The application db is only read: the replayed states are kept in memory. The node must be stopped.
+
+Example:
+$ iris debug replay --from=1000 --to=1200 --trace-diff
+`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			home := viper.GetString(tmcli.HomeFlag)
+			emptyState, err := isEmptyState(home)
+			if err != nil {
+				return err
+			}
+			if emptyState {
+				fmt.Println("WARNING: State is not initialized.")
+				return nil
+			}
+
+			from := viper.GetInt64(flagFromHeight)
+			to := viper.GetInt64(flagToHeight)
+			if from <= 0 {
+				return errors.Errorf("--from must be greater than zero")
+			}
+
+			dataDir := filepath.Join(home, "data")
+			blockDB := loadDb(ctx, "blockstore", dataDir)
+			defer blockDB.Close()
+			stateDB := loadDb(ctx, "state", dataDir)
+			defer stateDB.Close()
+			blockStore := bc.NewBlockStore(blockDB)
+			if to <= 0 || to > blockStore.Height() {
+				to = blockStore.Height()
+			}
+			if from >= to {
+				return errors.Errorf("no block to replay between %d and %d, the block store height is %d", from, to, blockStore.Height())
+			}
+
+			db, err := openDB(home)
+			if err != nil {
+				return err
+			}
+			defer db.Close()
+
+			var traceBuf bytes.Buffer
+			var traceWriter io.Writer
+			if viper.GetBool(flagTraceDiff) {
+				traceWriter = &traceBuf
+			}
+
+			overlay := store.NewOverlayDB(db)
+			app, err := appReplay(ctx, ctx.Logger, overlay, traceWriter, from)
+			if err != nil {
+				return err
+			}
+
+			r := replayer{
+				app:        app,
+				db:         db,
+				overlay:    overlay,
+				blockStore: blockStore,
+				stateDB:    stateDB,
+				out:        os.Stdout,
+			}
+			for height := from + 1; height <= to; height++ {
+				traceBuf.Reset()
+				diverged, err := r.replayBlock(height)
+				if err != nil {
+					return err
+				}
+				if traceWriter != nil {
+					if err := store.FilterTraceWrites(&traceBuf, r.out); err != nil {
+						return err
+					}
+				}
+				if diverged {
+					return errors.Errorf("the replay diverged at height %d", height)
+				}
+			}
+
+			fmt.Fprintf(r.out, "Replayed blocks %d to %d without divergence\n", from+1, to)
+			return nil
+		},
+	}
+	cmd.Flags().Int64(flagFromHeight, 0, "Height of the application state to replay the next blocks on")
+	cmd.Flags().Int64(flagToHeight, 0, "Height of the last block to replay (0 means the block store height)")
+	cmd.Flags().Bool(flagTraceDiff, false, "Print the store writes of each replayed block")
+	cmd.MarkFlagRequired(flagFromHeight)
+	return cmd
+}
+
+type replayer struct {
+	app        abci.Application
+	db         dbm.DB
+	overlay    dbm.DB
+	blockStore *bc.BlockStore
+	stateDB    dbm.DB
+	out        io.Writer
+}
+
+// replayBlock executes and commits the block of the height, prints its hashes and
+// returns whether they differ from the recorded ones
+func (r replayer) replayBlock(height int64) (diverged bool, err error) {
+	block := r.blockStore.LoadBlock(height)
+	if block == nil {
+		return false, errors.Errorf("block %d not found in the block store", height)
+	}
+	commitInfo, byzVals, err := r.beginBlockValidatorInfo(block)
+	if err != nil {
+		return false, err
+	}
+
+	r.app.BeginBlock(abci.RequestBeginBlock{
+		Hash:                block.Hash(),
+		Header:              tmtypes.TM2PB.Header(&block.Header),
+		LastCommitInfo:      commitInfo,
+		ByzantineValidators: byzVals,
+	})
+	deliverTxs := make([]*abci.ResponseDeliverTx, len(block.Txs))
+	for i, tx := range block.Txs {
+		res := r.app.DeliverTx(tx)
+		deliverTxs[i] = &res
+	}
+	r.app.EndBlock(abci.RequestEndBlock{Height: height})
+	appHash := r.app.Commit().Data
+	resultsHash := tmtypes.NewResults(deliverTxs).Hash()
+
+	// the hashes of a block are recorded by the next one, or by the state for the last one
+	var expectedAppHash, expectedResultsHash []byte
+	if next := r.blockStore.LoadBlock(height + 1); next != nil {
+		expectedAppHash, expectedResultsHash = next.AppHash, next.LastResultsHash
+	} else if state := sm.LoadState(r.stateDB); state.LastBlockHeight == height {
+		expectedAppHash, expectedResultsHash = state.AppHash, state.LastResultsHash
+	} else {
+		fmt.Fprintf(r.out, "height=%d txs=%d app_hash=%X results_hash=%X unverified\n", height, len(block.Txs), appHash, resultsHash)
+		return false, r.printStoreHashes(height)
+	}
+
+	appHashOK := bytes.Equal(appHash, expectedAppHash)
+	resultsHashOK := bytes.Equal(resultsHash, expectedResultsHash)
+	status := "ok"
+	if !appHashOK || !resultsHashOK {
+		status = "DIVERGED"
+	}
+	fmt.Fprintf(r.out, "height=%d txs=%d app_hash=%X results_hash=%X %s\n", height, len(block.Txs), appHash, resultsHash, status)
+	if !appHashOK {
+		fmt.Fprintf(r.out, "  app hash %X was recorded\n", expectedAppHash)
+	}
+	if !resultsHashOK {
+		fmt.Fprintf(r.out, "  results hash %X was recorded\n", expectedResultsHash)
+		r.printTxDivergence(height, deliverTxs)
+	}
+	return status != "ok", r.printStoreHashes(height)
+}
+
+// beginBlockValidatorInfo rebuilds the votes and the evidences the block was begun with
+func (r replayer) beginBlockValidatorInfo(block *tmtypes.Block) (abci.LastCommitInfo, []abci.Evidence, error) {
+	var votes []abci.VoteInfo
+	if block.Height > 1 {
+		lastValSet, err := sm.LoadValidators(r.stateDB, block.Height-1)
+		if err != nil {
+			return abci.LastCommitInfo{}, nil, err
+		}
+		if len(block.LastCommit.Precommits) != len(lastValSet.Validators) {
+			return abci.LastCommitInfo{}, nil, errors.Errorf("precommit length (%d) doesn't match valset length (%d) at height %d",
+				len(block.LastCommit.Precommits), len(lastValSet.Validators), block.Height)
+		}
+		votes = make([]abci.VoteInfo, len(lastValSet.Validators))
+		for i, val := range lastValSet.Validators {
+			votes[i] = abci.VoteInfo{
+				Validator:       tmtypes.TM2PB.Validator(val),
+				SignedLastBlock: block.LastCommit.Precommits[i] != nil,
+			}
+		}
+	}
+
+	byzVals := make([]abci.Evidence, len(block.Evidence.Evidence))
+	for i, ev := range block.Evidence.Evidence {
+		valSet, err := sm.LoadValidators(r.stateDB, ev.Height())
+		if err != nil {
+			return abci.LastCommitInfo{}, nil, err
+		}
+		byzVals[i] = tmtypes.TM2PB.Evidence(ev, valSet, block.Time)
+	}
+
+	return abci.LastCommitInfo{Round: int32(block.LastCommit.Round()), Votes: votes}, byzVals, nil
+}
+
+// printTxDivergence prints the first tx whose result differs from the recorded one
+func (r replayer) printTxDivergence(height int64, deliverTxs []*abci.ResponseDeliverTx) {
+	recorded, err := sm.LoadABCIResponses(r.stateDB, height)
+	if err != nil {
+		fmt.Fprintf(r.out, "  the tx results of the block weren't recorded: %v\n", err)
+		return
+	}
+	if len(recorded.DeliverTx) != len(deliverTxs) {
+		fmt.Fprintf(r.out, "  %d tx results were recorded for %d txs\n", len(recorded.DeliverTx), len(deliverTxs))
+		return
+	}
+	for i, res := range deliverTxs {
+		if res.Code != recorded.DeliverTx[i].Code || !bytes.Equal(res.Data, recorded.DeliverTx[i].Data) {
+			fmt.Fprintf(r.out, "  tx %d: code=%d data=%X log=%s\n", i, res.Code, res.Data, res.Log)
+			fmt.Fprintf(r.out, "  tx %d was recorded with code=%d data=%X log=%s\n", i, recorded.DeliverTx[i].Code, recorded.DeliverTx[i].Data, recorded.DeliverTx[i].Log)
+			return
+		}
+	}
+}
+
+// printStoreHashes prints the hash of every store after the replay of the height,
+// and the one recorded in the application db when it differs
+func (r replayer) printStoreHashes(height int64) error {
+	hashes, err := store.GetStoreHashes(r.overlay, height)
+	if err != nil {
+		return err
+	}
+	// the recorded hashes may have been pruned
+	recorded, err := store.GetStoreHashes(r.db, height)
+	if err != nil {
+		recorded = nil
+	}
+
+	names := make([]string, 0, len(hashes))
+	for name := range hashes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(r.out, "  %s %X", name, hashes[name])
+		if recordedHash, ok := recorded[name]; ok && !bytes.Equal(recordedHash, hashes[name]) {
+			fmt.Fprintf(r.out, " (recorded %X)", recordedHash)
+		}
+		fmt.Fprintln(r.out)
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+package server
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/NPC-Chain/npcchub/store"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	tmcli "github.com/tendermint/tendermint/libs/cli"
+)
+
+const (
+	flagFromBackend = "from"
+	flagToBackend   = "to"
+	flagBatchSize   = "batch-size"
+)
+
+// MigrateDBCmd copies the application db of a stopped node to another db backend
+func MigrateDBCmd(ctx *Context) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "migrate-db",
+		Short: "Copy the application db to another db backend",
+		Long: `Copy the application db to another db backend. The node must be stopped.
+The previous db is kept as a backup next to the migrated one, and app_db_backend
+must be set to the new backend in iris.toml before starting the node again.
+
+Example:
+$ iris migrate-db --to=badgerdb
+`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			home := viper.GetString(tmcli.HomeFlag)
+			from := viper.GetString(flagFromBackend)
+			if len(from) == 0 {
+				from = viper.GetString(flagAppDBBackend)
+			}
+			if len(from) == 0 {
+				from = store.DefaultDBBackend
+			}
+			to := viper.GetString(flagToBackend)
+			if from == to {
+				return errors.Errorf("the application db already uses %s", to)
+			}
+			if from == store.MemDBBackend || to == store.MemDBBackend {
+				return errors.Errorf("%s is not persistent and can't be migrated", store.MemDBBackend)
+			}
+
+			dataDir := filepath.Join(home, "data")
+			dbPath := filepath.Join(dataDir, "application.db")
+			if exists, _ := pathExists(dbPath); !exists {
+				return errors.Errorf("application db not found in %s", dataDir)
+			}
+			tmpDir := filepath.Join(dataDir, "migrate-"+to)
+			if exists, _ := pathExists(tmpDir); exists {
+				return errors.Errorf("%s already exists, remove it to migrate again", tmpDir)
+			}
+			backupPath := fmt.Sprintf("%s.%s.bak", dbPath, from)
+			if exists, _ := pathExists(backupPath); exists {
+				return errors.Errorf("%s already exists", backupPath)
+			}
+
+			src, err := store.OpenDB("application", from, dataDir)
+			if err != nil {
+				return err
+			}
+			dst, err := store.OpenDB("application", to, tmpDir)
+			if err != nil {
+				src.Close()
+				return err
+			}
+
+			ctx.Logger.Info("migrating application db", "from", from, "to", to)
+			copied, err := store.MigrateDB(src, dst, viper.GetInt(flagBatchSize))
+			src.Close()
+			dst.Close()
+			if err != nil {
+				_ = os.RemoveAll(tmpDir)
+				return err
+			}
+
+			if err := os.Rename(dbPath, backupPath); err != nil {
+				return err
+			}
+			if err := os.Rename(filepath.Join(tmpDir, "application.db"), dbPath); err != nil {
+				return err
+			}
+			if err := os.RemoveAll(tmpDir); err != nil {
+				return err
+			}
+
+			fmt.Printf("Migrated %d keys from %s to %s, the previous db is kept in %s\n", copied, from, to, backupPath)
+			fmt.Printf("Set app_db_backend = \"%s\" in iris.toml before starting the node\n", to)
+			return nil
+		},
+	}
+	cmd.Flags().String(flagFromBackend, "", "Backend of the current application db, defaults to app_db_backend of iris.toml")
+	cmd.Flags().String(flagToBackend, "", fmt.Sprintf("Backend to migrate the application db to: %s", store.BoltDBBackend+", "+store.BadgerDBBackend+", "+store.GoLevelDBBackend))
+	cmd.Flags().Int(flagBatchSize, 10000, "Number of keys written per batch")
+	cmd.MarkFlagRequired(flagToBackend)
+	return cmd
+}
@@ -0,0 +1,74 @@
+package server
+
+import (
+	"fmt"
+
+	"github.com/NPC-Chain/npcchub/server/config"
+	"github.com/NPC-Chain/npcchub/store"
+	sdk "github.com/NPC-Chain/npcchub/types"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	tmcli "github.com/tendermint/tendermint/libs/cli"
+)
+
+// PruneCmd prunes the application state of a stopped node to the configured pruning strategy
+func PruneCmd(ctx *Context) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "prune",
+		Short: "Prune the application states which are not kept by the pruning strategy",
+		Long: `Prune the application states which are not kept by the pruning strategy, so that an existing node
+can switch to a new strategy without resyncing. The node must be stopped.
+
+Example:
+$ iris prune --pruning=custom --pruning_keep_recent=1000 --pruning_keep_every=0
+`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			home := viper.GetString(tmcli.HomeFlag)
+			emptyState, err := isEmptyState(home)
+			if err != nil {
+				return err
+			}
+			if emptyState {
+				fmt.Println("WARNING: State is not initialized.")
+				return nil
+			}
+
+			pruning, err := PruningOptions()
+			if err != nil {
+				return err
+			}
+
+			db, err := openDB(home)
+			if err != nil {
+				return err
+			}
+			defer db.Close()
+
+			ctx.Logger.Info("pruning application state", "strategy", viper.GetString(flagPruning), "pruning", pruning.String())
+			pruned, err := store.PruneStores(db, pruning)
+			if err != nil {
+				return err
+			}
+			fmt.Printf("Pruned %d store versions\n", pruned)
+			return nil
+		},
+	}
+	addPruningFlags(cmd)
+	return cmd
+}
+
+// PruningOptions returns the options of the pruning strategy set by iris.toml or the pruning flags
+func PruningOptions() (sdk.PruningOptions, error) {
+	conf, err := config.ParseConfig()
+	if err != nil {
+		return sdk.PruningOptions{}, err
+	}
+	return conf.PruningOptions()
+}
+
+func addPruningFlags(cmd *cobra.Command) {
+	cmd.Flags().String(flagPruning, sdk.PruningSyncable, "Pruning strategy: syncable, nothing, archive, everything, custom")
+	cmd.Flags().Int64(flagKeepRecent, sdk.PruneSyncable.Options().KeepRecent, "Number of recent states kept by the custom pruning strategy")
+	cmd.Flags().Int64(flagKeepEvery, sdk.PruneSyncable.Options().KeepEvery, "Every how many states one is kept by the custom pruning strategy, 0 keeps none")
+	cmd.Flags().Int64(flagPruneInterval, sdk.PruneSyncable.Options().Interval, "Number of blocks between two prunings of the custom pruning strategy")
+}
@@ -0,0 +1,57 @@
+package server
+
+import (
+	"sync"
+
+	abcicli "github.com/tendermint/tendermint/abci/client"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/proxy"
+)
+
+// ConcurrentQuerier is implemented by applications able to serve some queries concurrently
+// with the other ABCI calls, e.g. against an immutable view of the last committed state
+type ConcurrentQuerier interface {
+	IsConcurrentQuery(req abci.RequestQuery) bool
+}
+
+// localClientCreator creates local clients sharing one mutex, as tendermint's local client
+// creator does, except that the queries the app serves concurrently don't take the mutex,
+// so that heavy queries don't stall the consensus and mempool connections
+type localClientCreator struct {
+	mtx *sync.Mutex
+	app abci.Application
+}
+
+var _ proxy.ClientCreator = (*localClientCreator)(nil)
+
+func newLocalClientCreator(app abci.Application) proxy.ClientCreator {
+	return &localClientCreator{
+		mtx: new(sync.Mutex),
+		app: app,
+	}
+}
+
+func (l *localClientCreator) NewABCIClient() (abcicli.Client, error) {
+	client := abcicli.NewLocalClient(l.mtx, l.app)
+	querier, ok := l.app.(ConcurrentQuerier)
+	if !ok {
+		return client, nil
+	}
+	return &concurrentQueryClient{Client: client, app: l.app, querier: querier}, nil
+}
+
+// concurrentQueryClient serves the concurrent queries without the mutex of the local client.
+// Tendermint only sends queries through QuerySync.
+type concurrentQueryClient struct {
+	abcicli.Client
+	app     abci.Application
+	querier ConcurrentQuerier
+}
+
+func (c *concurrentQueryClient) QuerySync(req abci.RequestQuery) (*abci.ResponseQuery, error) {
+	if !c.querier.IsConcurrentQuery(req) {
+		return c.Client.QuerySync(req)
+	}
+	res := c.app.Query(req)
+	return &res, nil
+}
@@ -44,7 +44,7 @@ func ResetCmd(ctx *Context, cdc *codec.Codec, appReset AppReset) *cobra.Command
 				return errors.Errorf("Height must greater than zero")
 			}
 
-			if err := checkHeight(home, height); err != nil {
+			if err := checkHeight(ctx, home, height); err != nil {
 				return err
 			}
 
@@ -62,9 +62,9 @@ func ResetCmd(ctx *Context, cdc *codec.Codec, appReset AppReset) *cobra.Command
 	return cmd
 }
 
-func checkHeight(home string, target int64) error {
+func checkHeight(ctx *Context, home string, target int64) error {
 	home = filepath.Join(home, "data")
-	blockDb := loadDb("blockstore", home)
+	blockDb := loadDb(ctx, "blockstore", home)
 	defer func() {
 		blockDb.Close()
 		if r := recover(); r != nil {
@@ -21,7 +21,8 @@ import (
 const flagTmpDir = "tmp-dir"
 const pathSeparator = string(os.PathSeparator)
 
-// SnapshotCmd delete historical block data and index data
+// SnapshotCmd delete historical block data and index data,
+// its subcommands export and restore state snapshots to bootstrap new nodes
 func SnapshotCmd(ctx *Context, cdc *codec.Codec, appReset AppReset) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "snapshot",
@@ -57,22 +58,23 @@ func SnapshotCmd(ctx *Context, cdc *codec.Codec, appReset AppReset) *cobra.Comma
 		},
 	}
 	cmd.Flags().String(flagTmpDir, "", "Snapshot file storage directory")
+	cmd.AddCommand(
+		SnapshotExportCmd(ctx, cdc),
+		SnapshotRestoreCmd(ctx, cdc),
+	)
 	return cmd
 }
 
-func loadDb(name, path string) *dbm.GoLevelDB {
-	db, err := dbm.NewGoLevelDB(name, path)
-	if err != nil {
-		panic(err)
-	}
-	return db
+// loadDb opens a tendermint db with the db_backend of the tendermint config
+func loadDb(ctx *Context, name, path string) dbm.DB {
+	return dbm.NewDB(name, dbm.DBBackendType(ctx.Config.DBBackend), path)
 }
 
 func snapshot(ctx *Context, cdc *codec.Codec, dataDir, targetDir string, appReset AppReset) error {
-	blockDB := loadDb("blockstore", dataDir)
+	blockDB := loadDb(ctx, "blockstore", dataDir)
 	blockStore := bc.NewBlockStore(blockDB)
 
-	stateDB := loadDb("state", dataDir)
+	stateDB := loadDb(ctx, "state", dataDir)
 	state := tmsm.LoadState(stateDB)
 
 	defer func() {
@@ -86,9 +88,9 @@ func snapshot(ctx *Context, cdc *codec.Codec, dataDir, targetDir string, appRese
 	}
 
 	//save local current block and flush disk
-	snapshotBlock(blockStore, targetDir, state.LastBlockHeight)
+	snapshotBlock(ctx, blockStore, targetDir, state.LastBlockHeight)
 	//save local current block height state
-	snapshotState(cdc, stateDB, targetDir)
+	snapshotState(ctx, cdc, stateDB, targetDir)
 	//save local current block height consensus data
 	snapshotCsWAL(ctx, dataDir, targetDir, state.LastBlockHeight)
 
@@ -105,8 +107,8 @@ func snapshot(ctx *Context, cdc *codec.Codec, dataDir, targetDir string, appRese
 	return copyDir(evidenceDir, evidenceTargetDir)
 }
 
-func snapshotState(cdc *codec.Codec, tmDB *dbm.GoLevelDB, targetDir string) {
-	targetDb := loadDb("state", targetDir)
+func snapshotState(ctx *Context, cdc *codec.Codec, tmDB dbm.DB, targetDir string) {
+	targetDb := loadDb(ctx, "state", targetDir)
 	defer targetDb.Close()
 
 	state := tmsm.LoadState(tmDB)
@@ -116,8 +118,8 @@ func snapshotState(cdc *codec.Codec, tmDB *dbm.GoLevelDB, targetDir string) {
 	tmsm.SaveState(targetDb, state)
 }
 
-func snapshotBlock(originStore *bc.BlockStore, targetDir string, height int64) int64 {
-	targetDb := loadDb("blockstore", targetDir)
+func snapshotBlock(ctx *Context, originStore *bc.BlockStore, targetDir string, height int64) int64 {
+	targetDb := loadDb(ctx, "blockstore", targetDir)
 	defer targetDb.Close()
 
 	bsj := bc.BlockStoreStateJSON{Height: height - 1}
@@ -1,6 +1,11 @@
 package server
 
 import (
+	"fmt"
+	"strings"
+
+	"github.com/NPC-Chain/npcchub/server/config"
+	"github.com/NPC-Chain/npcchub/store"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -12,7 +17,6 @@ import (
 	"github.com/tendermint/tendermint/node"
 	"github.com/tendermint/tendermint/p2p"
 	pvm "github.com/tendermint/tendermint/privval"
-	"github.com/tendermint/tendermint/proxy"
 )
 
 const (
@@ -20,8 +24,15 @@ const (
 	flagAddress        = "address"
 	flagTraceStore     = "trace-store"
 	flagPruning        = "pruning"
+	flagKeepRecent     = "pruning_keep_recent"
+	flagKeepEvery      = "pruning_keep_every"
+	flagPruneInterval  = "pruning_interval"
 	flagMinimumFees    = "minimum_fees"
 	flagCheckInvariant = "check_invariant"
+	flagHaltExportFile = "halt_export_file"
+	flagAppDBBackend   = "app_db_backend"
+	flagStreamingSink  = "streaming_sink"
+	flagStreamingPath  = "streaming_path"
 )
 
 // StartCmd runs the service passed in, either stand-alone or in-process with
@@ -47,9 +58,13 @@ func StartCmd(ctx *Context, appCreator AppCreator) *cobra.Command {
 	cmd.Flags().Bool(flagWithTendermint, true, "Run abci app embedded in-process with tendermint")
 	cmd.Flags().String(flagAddress, "tcp://0.0.0.0:26658", "Listen address")
 	cmd.Flags().String(flagTraceStore, "", "Enable KVStore tracing to an output file")
-	cmd.Flags().String(flagPruning, "syncable", "Pruning strategy: syncable, nothing, everything")
+	addPruningFlags(cmd)
+	cmd.Flags().String(flagAppDBBackend, store.DefaultDBBackend, fmt.Sprintf("Backend of the application state db: %s", strings.Join(store.DBBackends, ", ")))
 	cmd.Flags().String(flagMinimumFees, "", "Minimum fees validator will accept for transactions")
 	cmd.Flags().Bool(flagCheckInvariant, false, "Enable invariant check on mainnet, ignore this flag on testnet")
+	cmd.Flags().String(flagHaltExportFile, config.DefaultHaltExportFile, "File to export the genesis for zero height to when a SystemHalt proposal asks for the export, relative to the home directory if not absolute")
+	cmd.Flags().String(flagStreamingSink, "", "Sink of the per-block change feed: file or unix, empty disables the streaming")
+	cmd.Flags().String(flagStreamingPath, "", "Directory of the stream files or path of the Unix socket, relative to the home directory if not absolute")
 
 	// add support for all Tendermint-specific command line options
 	tcmd.AddNodeFlags(cmd)
@@ -121,7 +136,7 @@ func startInProcess(ctx *Context, appCreator AppCreator) (*node.Node, error) {
 		cfg,
 		pvm.LoadOrGenFilePV(cfg.PrivValidatorFile()),
 		nodeKey,
-		proxy.NewLocalClientCreator(app),
+		newLocalClientCreator(app),
 		node.DefaultGenesisDocProviderFunc(cfg),
 		node.DefaultDBProvider,
 		node.DefaultMetricsProvider(cfg.Instrumentation),
@@ -0,0 +1,294 @@
+package server
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/NPC-Chain/npcchub/codec"
+	"github.com/NPC-Chain/npcchub/store"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	bc "github.com/tendermint/tendermint/blockchain"
+	tmcli "github.com/tendermint/tendermint/libs/cli"
+	tmsm "github.com/tendermint/tendermint/state"
+	"github.com/tendermint/tendermint/types"
+)
+
+const (
+	flagOutputDir = "output-dir"
+	flagChunkSize = "chunk-size"
+	flagAppHash   = "app-hash"
+
+	snapshotTendermintFile = "tendermint.json"
+)
+
+// snapshotTendermint holds the tendermint state a node needs to start at the snapshot height
+type snapshotTendermint struct {
+	State      tmsm.State    `json:"state"`
+	Block      *types.Block  `json:"block"`
+	SeenCommit *types.Commit `json:"seen_commit"`
+}
+
+// SnapshotExportCmd exports the application stores at a committed height into a state snapshot
+func SnapshotExportCmd(ctx *Context, cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export the application state at a committed height into chunked snapshot files",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			home := viper.GetString(tmcli.HomeFlag)
+			emptyState, err := isEmptyState(home)
+			if err != nil {
+				return err
+			}
+			if emptyState {
+				fmt.Println("WARNING: State is not initialized.")
+				return nil
+			}
+
+			dataDir := filepath.Join(home, "data")
+			tmSnapshot, err := loadSnapshotTendermint(ctx, dataDir, viper.GetInt64(flagHeight))
+			if err != nil {
+				return err
+			}
+			height := tmSnapshot.State.LastBlockHeight
+
+			outputDir := viper.GetString(flagOutputDir)
+			if len(outputDir) == 0 {
+				outputDir = filepath.Join(home, "snapshots", fmt.Sprintf("%d", height))
+			}
+			if exists, _ := pathExists(outputDir); exists {
+				return errors.Errorf("snapshot directory %s already exists", outputDir)
+			}
+
+			db, err := openDB(home)
+			if err != nil {
+				return err
+			}
+			defer db.Close()
+
+			manifest, err := store.ExportSnapshot(db, height, outputDir, viper.GetInt64(flagChunkSize))
+			if err != nil {
+				_ = os.RemoveAll(outputDir)
+				return err
+			}
+			if !bytes.Equal(manifest.AppHash, tmSnapshot.State.AppHash) {
+				_ = os.RemoveAll(outputDir)
+				return errors.Errorf("app hash %X at height %d does not match the block header app hash %X",
+					manifest.AppHash, height, tmSnapshot.State.AppHash)
+			}
+
+			bz, err := cdc.MarshalJSONIndent(tmSnapshot, "", "  ")
+			if err != nil {
+				return err
+			}
+			if err := ioutil.WriteFile(filepath.Join(outputDir, snapshotTendermintFile), bz, 0644); err != nil {
+				return err
+			}
+
+			fmt.Printf("Snapshot of height %d exported to %s, app hash %X\n", height, outputDir, manifest.AppHash)
+			return nil
+		},
+	}
+	cmd.Flags().Int64(flagHeight, 0, "Export the state at a particular committed height (0 means latest height)")
+	cmd.Flags().String(flagOutputDir, "", "Snapshot directory, defaults to $home/snapshots/<height>")
+	cmd.Flags().Int64(flagChunkSize, store.DefaultSnapshotChunkSize, "Maximum size in bytes of a chunk file")
+	return cmd
+}
+
+// SnapshotRestoreCmd rebuilds the data directory of a new node from a state snapshot
+func SnapshotRestoreCmd(ctx *Context, cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "restore [snapshot-dir]",
+		Short: "Restore the application state from a snapshot and verify its app hash",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			home := viper.GetString(tmcli.HomeFlag)
+			snapshotDir := args[0]
+
+			bz, err := ioutil.ReadFile(filepath.Join(snapshotDir, snapshotTendermintFile))
+			if err != nil {
+				return err
+			}
+			var tmSnapshot snapshotTendermint
+			if err := cdc.UnmarshalJSON(bz, &tmSnapshot); err != nil {
+				return errors.Errorf("invalid %s: %v", snapshotTendermintFile, err)
+			}
+
+			manifest, err := store.LoadSnapshotManifest(snapshotDir)
+			if err != nil {
+				return err
+			}
+			if err := verifySnapshotTendermint(tmSnapshot, manifest); err != nil {
+				return err
+			}
+			// the snapshot files only vouch for themselves, the app hash must come from a trusted header
+			appHash := viper.GetString(flagAppHash)
+			trusted, err := hex.DecodeString(appHash)
+			if err != nil || len(trusted) == 0 {
+				return errors.Errorf("invalid app hash %s, the app hash of the snapshot height is required", appHash)
+			}
+			if !bytes.Equal(trusted, manifest.AppHash) {
+				return errors.Errorf("snapshot app hash %X does not match the trusted app hash %X", manifest.AppHash, trusted)
+			}
+
+			dataDir := filepath.Join(home, "data")
+			for _, name := range []string{"application.db", "state.db", "blockstore.db"} {
+				if exists, _ := pathExists(filepath.Join(dataDir, name)); exists {
+					return errors.Errorf("%s already exists, restore requires an empty data directory", filepath.Join(dataDir, name))
+				}
+			}
+
+			db, err := openDB(home)
+			if err != nil {
+				return err
+			}
+			_, err = store.RestoreSnapshot(db, snapshotDir)
+			db.Close()
+			if err == nil {
+				err = restoreSnapshotTendermint(ctx, dataDir, tmSnapshot)
+			}
+			if err != nil {
+				// leave the data directory empty so that the restore can be retried
+				for _, name := range []string{"application.db", "state.db", "blockstore.db"} {
+					_ = os.RemoveAll(filepath.Join(dataDir, name))
+				}
+				return err
+			}
+
+			ctx.Logger.Info("snapshot is restored successful", "height", manifest.Height, "app_hash", manifest.AppHash)
+			return nil
+		},
+	}
+	cmd.Flags().String(flagAppHash, "", "Trusted app hash (hex) of the snapshot height, e.g. from the header of the next block")
+	cmd.MarkFlagRequired(flagAppHash)
+	return cmd
+}
+
+// loadSnapshotTendermint rebuilds the tendermint state as it was right after committing the
+// given height. The app hash and results hash of a block are only known from the next header,
+// so any height below the latest one needs the next block to be available.
+func loadSnapshotTendermint(ctx *Context, dataDir string, height int64) (snapshotTendermint, error) {
+	blockDB := loadDb(ctx, "blockstore", dataDir)
+	defer blockDB.Close()
+	stateDB := loadDb(ctx, "state", dataDir)
+	defer stateDB.Close()
+
+	blockStore := bc.NewBlockStore(blockDB)
+	state := tmsm.LoadState(stateDB)
+	if height == 0 {
+		height = state.LastBlockHeight
+	}
+	if height <= 0 || height > state.LastBlockHeight {
+		return snapshotTendermint{}, errors.Errorf("height must be in [1, %d]", state.LastBlockHeight)
+	}
+
+	if height < state.LastBlockHeight {
+		meta := blockStore.LoadBlockMeta(height)
+		next := blockStore.LoadBlockMeta(height + 1)
+		if meta == nil || next == nil {
+			return snapshotTendermint{}, errors.Errorf("blocks %d and %d are required to export height %d", height, height+1, height)
+		}
+		lastVals, err := tmsm.LoadValidators(stateDB, height)
+		if err != nil {
+			return snapshotTendermint{}, err
+		}
+		vals, err := tmsm.LoadValidators(stateDB, height+1)
+		if err != nil {
+			return snapshotTendermint{}, err
+		}
+		nextVals, err := tmsm.LoadValidators(stateDB, height+2)
+		if err != nil {
+			return snapshotTendermint{}, err
+		}
+		params, err := tmsm.LoadConsensusParams(stateDB, height+1)
+		if err != nil {
+			return snapshotTendermint{}, err
+		}
+		state = tmsm.State{
+			Version:          tmsm.Version{Consensus: next.Header.Version, Software: state.Version.Software},
+			ChainID:          state.ChainID,
+			LastBlockHeight:  height,
+			LastBlockTotalTx: meta.Header.TotalTxs,
+			LastBlockID:      meta.BlockID,
+			LastBlockTime:    meta.Header.Time,
+			NextValidators:   nextVals,
+			Validators:       vals,
+			LastValidators:   lastVals,
+			ConsensusParams:  params,
+			LastResultsHash:  next.Header.LastResultsHash,
+			AppHash:          next.Header.AppHash,
+		}
+	}
+	// the restored state db only holds the validator sets and params from here on
+	state.LastHeightValidatorsChanged = height + 2
+	state.LastHeightConsensusParamsChanged = height + 1
+
+	block := blockStore.LoadBlock(height)
+	if block == nil {
+		return snapshotTendermint{}, errors.Errorf("block %d has been pruned", height)
+	}
+	seenCommit := blockStore.LoadSeenCommit(height)
+	if seenCommit == nil {
+		seenCommit = blockStore.LoadBlockCommit(height)
+	}
+	if seenCommit == nil {
+		return snapshotTendermint{}, errors.Errorf("commit of block %d not found", height)
+	}
+	return snapshotTendermint{State: state, Block: block, SeenCommit: seenCommit}, nil
+}
+
+// verifySnapshotTendermint checks the tendermint state is the one of the snapshot height
+func verifySnapshotTendermint(tmSnapshot snapshotTendermint, manifest store.SnapshotManifest) error {
+	state, block := tmSnapshot.State, tmSnapshot.Block
+	if state.LastBlockHeight != manifest.Height || block == nil || block.Height != manifest.Height {
+		return errors.Errorf("tendermint state does not match the snapshot height %d", manifest.Height)
+	}
+	if !bytes.Equal(state.AppHash, manifest.AppHash) {
+		return errors.Errorf("tendermint app hash %X does not match the snapshot app hash %X", state.AppHash, manifest.AppHash)
+	}
+	if !bytes.Equal(block.Hash(), state.LastBlockID.Hash) {
+		return errors.Errorf("block %d does not match the last block id of the state", block.Height)
+	}
+	if !bytes.Equal(state.LastValidators.Hash(), block.ValidatorsHash) ||
+		!bytes.Equal(state.Validators.Hash(), block.NextValidatorsHash) {
+		return errors.Errorf("validator sets do not match block %d", block.Height)
+	}
+	if tmSnapshot.SeenCommit == nil || !tmSnapshot.SeenCommit.BlockID.Equals(state.LastBlockID) {
+		return errors.Errorf("commit does not match block %d", block.Height)
+	}
+	return nil
+}
+
+// restoreSnapshotTendermint writes the state and the last block of the snapshot so that
+// the node resumes consensus at the next height without replaying any block
+func restoreSnapshotTendermint(ctx *Context, dataDir string, tmSnapshot snapshotTendermint) (err error) {
+	// the writes of the dbs panic on failure
+	defer func() {
+		if r := recover(); r != nil {
+			err = errors.Errorf("failed to write the tendermint state of height %d: %v", tmSnapshot.State.LastBlockHeight, r)
+		}
+	}()
+
+	state := tmSnapshot.State
+	height := state.LastBlockHeight
+
+	stateDB := loadDb(ctx, "state", dataDir)
+	defer stateDB.Close()
+	stateDB.Set(calcValidatorsKey(height), (&tmsm.ValidatorsInfo{ValidatorSet: state.LastValidators, LastHeightChanged: height}).Bytes())
+	stateDB.Set(calcValidatorsKey(height+1), (&tmsm.ValidatorsInfo{ValidatorSet: state.Validators, LastHeightChanged: height + 1}).Bytes())
+	tmsm.SaveState(stateDB, state)
+
+	blockDB := loadDb(ctx, "blockstore", dataDir)
+	defer blockDB.Close()
+	bsj := bc.BlockStoreStateJSON{Height: height - 1}
+	bsj.Save(blockDB)
+	blockStore := bc.NewBlockStore(blockDB)
+	block := tmSnapshot.Block
+	blockStore.SaveBlock(block, block.MakePartSet(types.BlockPartSizeBytes), tmSnapshot.SeenCommit)
+	return nil
+}
@@ -0,0 +1,276 @@
+// +build badgerdb
+
+package store
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/dgraph-io/badger/v2"
+	dbm "github.com/tendermint/tm-db"
+)
+
+var _ dbm.DB = (*badgerDB)(nil)
+
+// badgerDB implements dbm.DB on top of BadgerDB, a pure Go key-value store
+// which doesn't suffer the compaction stalls of LevelDB on large states
+type badgerDB struct {
+	db *badger.DB
+}
+
+func newBadgerDB(name, dir string) (dbm.DB, error) {
+	path := filepath.Join(dir, name+".db")
+	if err := os.MkdirAll(path, 0755); err != nil {
+		return nil, err
+	}
+	db, err := badger.Open(badger.DefaultOptions(path).WithLogger(nil))
+	if err != nil {
+		return nil, err
+	}
+	return &badgerDB{db: db}, nil
+}
+
+// Implements DB.
+func (bdb *badgerDB) Get(key []byte) (value []byte) {
+	err := bdb.db.View(func(txn *badger.Txn) error {
+		item, err := txn.Get(key)
+		if err == badger.ErrKeyNotFound {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		value, err = item.ValueCopy(nil)
+		if err == nil && value == nil {
+			// an empty value is distinguished from a missing key
+			value = []byte{}
+		}
+		return err
+	})
+	if err != nil {
+		panic(err)
+	}
+	return value
+}
+
+// Implements DB.
+func (bdb *badgerDB) Has(key []byte) bool {
+	return bdb.Get(key) != nil
+}
+
+// Implements DB.
+func (bdb *badgerDB) Set(key, value []byte) {
+	if value == nil {
+		value = []byte{}
+	}
+	err := bdb.db.Update(func(txn *badger.Txn) error {
+		return txn.Set(key, value)
+	})
+	if err != nil {
+		panic(err)
+	}
+}
+
+// Implements DB.
+func (bdb *badgerDB) SetSync(key, value []byte) {
+	bdb.Set(key, value)
+	bdb.sync()
+}
+
+// Implements DB.
+func (bdb *badgerDB) Delete(key []byte) {
+	err := bdb.db.Update(func(txn *badger.Txn) error {
+		return txn.Delete(key)
+	})
+	if err != nil {
+		panic(err)
+	}
+}
+
+// Implements DB.
+func (bdb *badgerDB) DeleteSync(key []byte) {
+	bdb.Delete(key)
+	bdb.sync()
+}
+
+func (bdb *badgerDB) sync() {
+	if err := bdb.db.Sync(); err != nil {
+		panic(err)
+	}
+}
+
+// Implements DB.
+func (bdb *badgerDB) Close() {
+	if err := bdb.db.Close(); err != nil {
+		panic(err)
+	}
+}
+
+// Implements DB.
+func (bdb *badgerDB) Print() {
+	iter := bdb.Iterator(nil, nil)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		fmt.Printf("[%X]:\t[%X]\n", iter.Key(), iter.Value())
+	}
+}
+
+// Implements DB.
+func (bdb *badgerDB) Stats() map[string]string {
+	lsm, vlog := bdb.db.Size()
+	return map[string]string{
+		"database.type": "badgerDB",
+		"lsm.size":      fmt.Sprintf("%d", lsm),
+		"vlog.size":     fmt.Sprintf("%d", vlog),
+	}
+}
+
+// Implements DB.
+func (bdb *badgerDB) NewBatch() dbm.Batch {
+	return &badgerBatch{db: bdb, batch: bdb.db.NewWriteBatch()}
+}
+
+// Implements DB.
+func (bdb *badgerDB) Iterator(start, end []byte) dbm.Iterator {
+	return newBadgerIterator(bdb.db, start, end, false)
+}
+
+// Implements DB.
+func (bdb *badgerDB) ReverseIterator(start, end []byte) dbm.Iterator {
+	return newBadgerIterator(bdb.db, start, end, true)
+}
+
+//----------------------------------------
+// Batch
+
+type badgerBatch struct {
+	db    *badgerDB
+	batch *badger.WriteBatch
+}
+
+// Implements Batch.
+func (b *badgerBatch) Set(key, value []byte) {
+	if value == nil {
+		value = []byte{}
+	}
+	if err := b.batch.Set(key, value); err != nil {
+		panic(err)
+	}
+}
+
+// Implements Batch.
+func (b *badgerBatch) Delete(key []byte) {
+	if err := b.batch.Delete(key); err != nil {
+		panic(err)
+	}
+}
+
+// Implements Batch.
+func (b *badgerBatch) Write() {
+	if err := b.batch.Flush(); err != nil {
+		panic(err)
+	}
+}
+
+// Implements Batch.
+func (b *badgerBatch) WriteSync() {
+	b.Write()
+	b.db.sync()
+}
+
+// Implements Batch.
+func (b *badgerBatch) Close() {
+	b.batch.Cancel()
+}
+
+//----------------------------------------
+// Iterator
+
+// badgerIterator iterates over [start, end), backwards if reverse is set
+type badgerIterator struct {
+	txn     *badger.Txn
+	iter    *badger.Iterator
+	start   []byte
+	end     []byte
+	reverse bool
+}
+
+func newBadgerIterator(db *badger.DB, start, end []byte, reverse bool) *badgerIterator {
+	txn := db.NewTransaction(false)
+	opts := badger.DefaultIteratorOptions
+	opts.Reverse = reverse
+	iter := txn.NewIterator(opts)
+
+	switch {
+	case !reverse && start != nil:
+		iter.Seek(start)
+	case reverse && end != nil:
+		// a reverse seek stops at the last key not greater than end, which is excluded
+		iter.Seek(end)
+		if iter.Valid() && bytes.Equal(iter.Item().Key(), end) {
+			iter.Next()
+		}
+	default:
+		iter.Rewind()
+	}
+
+	return &badgerIterator{
+		txn:     txn,
+		iter:    iter,
+		start:   start,
+		end:     end,
+		reverse: reverse,
+	}
+}
+
+// Implements Iterator.
+func (bi *badgerIterator) Domain() ([]byte, []byte) {
+	return bi.start, bi.end
+}
+
+// Implements Iterator.
+func (bi *badgerIterator) Valid() bool {
+	if !bi.iter.Valid() {
+		return false
+	}
+	key := bi.iter.Item().Key()
+	if bi.reverse {
+		return bi.start == nil || bytes.Compare(key, bi.start) >= 0
+	}
+	return bi.end == nil || bytes.Compare(key, bi.end) < 0
+}
+
+// Implements Iterator.
+func (bi *badgerIterator) Next() {
+	bi.assertIsValid()
+	bi.iter.Next()
+}
+
+// Implements Iterator.
+func (bi *badgerIterator) Key() []byte {
+	bi.assertIsValid()
+	return bi.iter.Item().KeyCopy(nil)
+}
+
+// Implements Iterator.
+func (bi *badgerIterator) Value() []byte {
+	bi.assertIsValid()
+	value, err := bi.iter.Item().ValueCopy(nil)
+	if err != nil {
+		panic(err)
+	}
+	return value
+}
+
+// Implements Iterator.
+func (bi *badgerIterator) Close() {
+	bi.iter.Close()
+	bi.txn.Discard()
+}
+
+func (bi *badgerIterator) assertIsValid() {
+	if !bi.Valid() {
+		panic("badgerIterator is invalid")
+	}
+}
@@ -0,0 +1,13 @@
+// +build !badgerdb
+
+package store
+
+import (
+	"fmt"
+
+	dbm "github.com/tendermint/tm-db"
+)
+
+func newBadgerDB(name, dir string) (dbm.DB, error) {
+	return nil, fmt.Errorf("failed to open %s db %s: build with the badgerdb tag to enable it", BadgerDBBackend, name)
+}
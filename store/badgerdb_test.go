@@ -0,0 +1,77 @@
+// +build badgerdb
+
+package store
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBadgerDB(t *testing.T) {
+	dir, err := ioutil.TempDir("", "badgerdb")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	db, err := OpenDB("application", BadgerDBBackend, dir)
+	require.Nil(t, err)
+	defer db.Close()
+
+	db.Set([]byte("key1"), []byte("value1"))
+	db.SetSync([]byte("key2"), nil)
+	require.Equal(t, []byte("value1"), db.Get([]byte("key1")))
+	require.True(t, db.Has([]byte("key2")))
+	require.Nil(t, db.Get([]byte("key3")))
+	db.Delete([]byte("key1"))
+	require.False(t, db.Has([]byte("key1")))
+
+	batch := db.NewBatch()
+	for i := 0; i < 5; i++ {
+		batch.Set([]byte(fmt.Sprintf("key%d", i)), []byte(fmt.Sprintf("value%d", i)))
+	}
+	batch.Delete([]byte("key2"))
+	batch.WriteSync()
+	batch.Close()
+
+	var keys []string
+	iter := db.Iterator([]byte("key1"), []byte("key4"))
+	for ; iter.Valid(); iter.Next() {
+		keys = append(keys, string(iter.Key()))
+	}
+	iter.Close()
+	require.Equal(t, []string{"key1", "key3"}, keys)
+
+	keys = nil
+	iter = db.ReverseIterator([]byte("key1"), []byte("key4"))
+	for ; iter.Valid(); iter.Next() {
+		keys = append(keys, string(iter.Key()))
+	}
+	iter.Close()
+	require.Equal(t, []string{"key3", "key1"}, keys)
+}
+
+func TestMigrateDBToBadgerDB(t *testing.T) {
+	dir, err := ioutil.TempDir("", "badgerdb")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	src, err := OpenDB("application", GoLevelDBBackend, dir)
+	require.Nil(t, err)
+	defer src.Close()
+	for i := 0; i < 25; i++ {
+		src.Set([]byte(fmt.Sprintf("key%02d", i)), []byte(fmt.Sprintf("value%d", i)))
+	}
+
+	dst, err := OpenDB("application", BadgerDBBackend, dir+"/badger")
+	require.Nil(t, err)
+	defer dst.Close()
+	copied, err := MigrateDB(src, dst, 10)
+	require.Nil(t, err)
+	require.Equal(t, int64(25), copied)
+	for i := 0; i < 25; i++ {
+		require.Equal(t, []byte(fmt.Sprintf("value%d", i)), dst.Get([]byte(fmt.Sprintf("key%02d", i))))
+	}
+}
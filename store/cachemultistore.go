@@ -4,6 +4,7 @@ import (
 	"io"
 
 	sdk "github.com/NPC-Chain/npcchub/types"
+	dbm "github.com/tendermint/tm-db"
 )
 
 //----------------------------------------
@@ -23,15 +24,28 @@ type cacheMultiStore struct {
 var _ CacheMultiStore = cacheMultiStore{}
 
 func newCacheMultiStoreFromRMS(rms *rootMultiStore) cacheMultiStore {
+	stores := make(map[StoreKey]CacheWrapper, len(rms.stores))
+	for key, store := range rms.stores {
+		if rms.ListeningEnabled() {
+			stores[key] = rms.listenStore(key, store.(KVStore))
+		} else {
+			stores[key] = store
+		}
+	}
+	return newCacheMultiStore(rms.db, stores, rms.keysByName, rms.traceWriter, rms.traceContext)
+}
+
+func newCacheMultiStore(db dbm.DB, stores map[StoreKey]CacheWrapper, keysByName map[string]StoreKey,
+	traceWriter io.Writer, traceContext TraceContext) cacheMultiStore {
 	cms := cacheMultiStore{
-		db:           NewCacheKVStore(dbStoreAdapter{rms.db}),
-		stores:       make(map[StoreKey]CacheWrap, len(rms.stores)),
-		keysByName:   rms.keysByName,
-		traceWriter:  rms.traceWriter,
-		traceContext: rms.traceContext,
+		db:           NewCacheKVStore(dbStoreAdapter{db}),
+		stores:       make(map[StoreKey]CacheWrap, len(stores)),
+		keysByName:   keysByName,
+		traceWriter:  traceWriter,
+		traceContext: traceContext,
 	}
 
-	for key, store := range rms.stores {
+	for key, store := range stores {
 		if cms.TracingEnabled() {
 			cms.stores[key] = store.CacheWrapWithTrace(cms.traceWriter, cms.traceContext)
 		} else {
@@ -8,6 +8,7 @@ import (
 // nolint
 type (
 	PruningStrategy  = types.PruningStrategy
+	PruningOptions   = types.PruningOptions
 	Store            = types.Store
 	Committer        = types.Committer
 	CommitStore      = types.CommitStore
@@ -25,7 +26,9 @@ type (
 	StoreKey         = types.StoreKey
 	StoreType        = types.StoreType
 	Queryable        = types.Queryable
+	GasQueryable     = types.GasQueryable
 	TraceContext     = types.TraceContext
+	WriteListener    = types.WriteListener
 	Gas              = types.Gas
 	GasMeter         = types.GasMeter
 	GasConfig        = types.GasConfig
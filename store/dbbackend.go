@@ -0,0 +1,81 @@
+package store
+
+import (
+	"fmt"
+
+	dbm "github.com/tendermint/tm-db"
+)
+
+// nolint - backends of the application state db
+const (
+	GoLevelDBBackend = "goleveldb"
+	BoltDBBackend    = "boltdb"
+	BadgerDBBackend  = "badgerdb"
+	MemDBBackend     = "memdb"
+
+	// DefaultDBBackend is the backend used when none is configured
+	DefaultDBBackend = GoLevelDBBackend
+)
+
+// DBBackends lists the supported backends of the application state db
+var DBBackends = []string{GoLevelDBBackend, BoltDBBackend, BadgerDBBackend, MemDBBackend}
+
+// OpenDB opens or creates the db of the given name in dir with the given backend.
+// boltdb requires building with the boltdb tag and badgerdb with the badgerdb tag.
+func OpenDB(name, backend, dir string) (db dbm.DB, err error) {
+	switch backend {
+	case "", GoLevelDBBackend:
+		return dbm.NewGoLevelDB(name, dir)
+	case MemDBBackend:
+		return dbm.NewMemDB(), nil
+	case BadgerDBBackend:
+		return newBadgerDB(name, dir)
+	case BoltDBBackend:
+		// tm-db panics on the backends which are not compiled in
+		defer func() {
+			if r := recover(); r != nil {
+				err = fmt.Errorf("failed to open %s db %s: %v", backend, name, r)
+			}
+		}()
+		return dbm.NewDB(name, dbm.BoltDBBackend, dir), nil
+	default:
+		return nil, fmt.Errorf("invalid db backend %s, expected one of %v", backend, DBBackends)
+	}
+}
+
+// MigrateDB copies every key of src into dst in batches of batchSize keys
+// and returns the number of copied keys. The dbs report their read and write
+// errors by panicking, which are returned as errors.
+func MigrateDB(src, dst dbm.DB, batchSize int) (copied int64, err error) {
+	if batchSize <= 0 {
+		return 0, fmt.Errorf("batch size must be positive, got %d", batchSize)
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("failed to migrate the db after %d keys: %v", copied, r)
+		}
+	}()
+
+	iter := src.Iterator(nil, nil)
+	defer iter.Close()
+
+	batch := dst.NewBatch()
+	defer func() {
+		batch.Close()
+	}()
+	var pending int64
+	for ; iter.Valid(); iter.Next() {
+		batch.Set(iter.Key(), iter.Value())
+		pending++
+		if pending == int64(batchSize) {
+			batch.Write()
+			copied += pending
+			pending = 0
+			batch.Close()
+			batch = dst.NewBatch()
+		}
+	}
+	batch.WriteSync()
+	copied += pending
+	return copied, nil
+}
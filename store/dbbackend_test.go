@@ -0,0 +1,90 @@
+package store
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tm-db"
+)
+
+func TestOpenDB(t *testing.T) {
+	dir, err := ioutil.TempDir("", "dbbackend")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	for _, backend := range []string{"", GoLevelDBBackend, MemDBBackend} {
+		db, err := OpenDB("application", backend, dir)
+		require.Nil(t, err, backend)
+		db.Set([]byte("key"), []byte("value"))
+		require.Equal(t, []byte("value"), db.Get([]byte("key")))
+		db.Close()
+	}
+
+	_, err = OpenDB("application", "unknown", dir)
+	require.NotNil(t, err)
+}
+
+func TestMigrateDB(t *testing.T) {
+	dir, err := ioutil.TempDir("", "dbbackend")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	src, err := OpenDB("application", GoLevelDBBackend, dir)
+	require.Nil(t, err)
+	defer src.Close()
+	for i := 0; i < 25; i++ {
+		src.Set([]byte(fmt.Sprintf("key%02d", i)), []byte(fmt.Sprintf("value%d", i)))
+	}
+
+	dst, err := OpenDB("application", MemDBBackend, dir)
+	require.Nil(t, err)
+	copied, err := MigrateDB(src, dst, 10)
+	require.Nil(t, err)
+	require.Equal(t, int64(25), copied)
+	for i := 0; i < 25; i++ {
+		require.Equal(t, []byte(fmt.Sprintf("value%d", i)), dst.Get([]byte(fmt.Sprintf("key%02d", i))))
+	}
+
+	_, err = MigrateDB(src, dst, 0)
+	require.NotNil(t, err)
+}
+
+// failingDB is a db whose batches fail to be written
+type failingDB struct {
+	dbm.DB
+}
+
+func (db failingDB) NewBatch() dbm.Batch {
+	return failingBatch{db.DB.NewBatch()}
+}
+
+type failingBatch struct {
+	dbm.Batch
+}
+
+func (b failingBatch) Write() {
+	panic("disk full")
+}
+
+func (b failingBatch) WriteSync() {
+	panic("disk full")
+}
+
+func TestMigrateDBWriteError(t *testing.T) {
+	src := dbm.NewMemDB()
+	for i := 0; i < 25; i++ {
+		src.Set([]byte(fmt.Sprintf("key%02d", i)), []byte(fmt.Sprintf("value%d", i)))
+	}
+
+	copied, err := MigrateDB(src, failingDB{dbm.NewMemDB()}, 10)
+	require.NotNil(t, err)
+	require.Equal(t, int64(0), copied)
+
+	// the error of the last batch is returned as well
+	copied, err = MigrateDB(src, failingDB{dbm.NewMemDB()}, 100)
+	require.NotNil(t, err)
+	require.Equal(t, int64(0), copied)
+}
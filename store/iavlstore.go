@@ -1,6 +1,7 @@
 package store
 
 import (
+	"bytes"
 	"fmt"
 	"io"
 	"sync"
@@ -18,10 +19,15 @@ const (
 	defaultIAVLCacheSize = 10000
 	NumStoreEvery        = 10000
 	NumRecent            = 100
+
+	// DefaultSubspacePageLimit is the number of pairs of a subspace page if the query sets no limit
+	DefaultSubspacePageLimit = 100
+	// MaxSubspacePageLimit is the maximum number of pairs of a subspace page
+	MaxSubspacePageLimit = 1000
 )
 
 // load the iavl store
-func LoadIAVLStore(db dbm.DB, id CommitID, pruning sdk.PruningStrategy, overwrite bool) (CommitStore, error) {
+func LoadIAVLStore(db dbm.DB, id CommitID, pruning sdk.PruningOptions, overwrite bool) (CommitStore, error) {
 	tree := iavl.NewMutableTree(db, defaultIAVLCacheSize)
 	var err error
 	if overwrite {
@@ -42,6 +48,7 @@ func LoadIAVLStore(db dbm.DB, id CommitID, pruning sdk.PruningStrategy, overwrit
 var _ KVStore = (*iavlStore)(nil)
 var _ CommitStore = (*iavlStore)(nil)
 var _ Queryable = (*iavlStore)(nil)
+var _ GasQueryable = (*iavlStore)(nil)
 
 // iavlStore Implements KVStore and CommitStore.
 type iavlStore struct {
@@ -59,6 +66,22 @@ type iavlStore struct {
 	// By default this value should be set the same across all nodes,
 	// so that nodes can know the waypoints their peers store.
 	storeEvery int64
+
+	// How many versions are committed between two prunings.
+	// A value of 1 means prune at every commit.
+	pruneInterval int64
+
+	// The readers of the rootstore versions, nil for a store outside of a rootMultiStore.
+	readers *versionReaders
+
+	// The versions which were being read when they were pruned, deleted at a next commit.
+	pendingPrunes []pendingPrune
+}
+
+// pendingPrune is a tree version to delete along with its rootstore version
+type pendingPrune struct {
+	release int64
+	version int64
 }
 
 // CONTRACT: tree should be fully loaded.
@@ -81,24 +104,7 @@ func (st *iavlStore) Commit([]*sdk.KVStoreKey) CommitID {
 		panic(err)
 	}
 
-	// Release an old version of history, if not a sync waypoint.
-	previous := version - 1
-	if st.numRecent < previous {
-		toRelease := previous - st.numRecent
-		if st.storeEvery == 0 || toRelease%st.storeEvery != 0 {
-			// Keep version 1
-			if toRelease == 1 {
-				return CommitID{
-					Version: version,
-					Hash:    hash,
-				}
-			}
-			err := st.tree.DeleteVersion(toRelease)
-			if err != nil && err.(cmn.Error).Data() != iavl.ErrVersionDoesNotExist {
-				panic(err)
-			}
-		}
-	}
+	st.prune(version, version)
 
 	return CommitID{
 		Version: version,
@@ -115,26 +121,7 @@ func (st *iavlStore) CommitWithVersion(KVStoreList []*sdk.KVStoreKey, release in
 		panic(err)
 	}
 
-	// Release an old version of history, if not a sync waypoint.
-	previous := version - 1
-	preRelease := release - 1
-	if st.numRecent < previous {
-		toVersion := previous - st.numRecent
-		toRelease := preRelease - st.numRecent
-		if st.storeEvery == 0 || toRelease%st.storeEvery != 0 {
-			// Keep version 1
-			if toVersion == 1 {
-				return CommitID{
-					Version: version,
-					Hash:    hash,
-				}
-			}
-			err := st.tree.DeleteVersion(toVersion)
-			if err != nil && err.(cmn.Error).Data() != iavl.ErrVersionDoesNotExist {
-				panic(err)
-			}
-		}
-	}
+	st.prune(version, release)
 
 	return CommitID{
 		Version: version,
@@ -142,6 +129,53 @@ func (st *iavlStore) CommitWithVersion(KVStoreList []*sdk.KVStoreKey, release in
 	}
 }
 
+// Release the old versions of history which are not sync waypoints, every pruneInterval
+// rootstore versions. The tree version may lag behind the rootstore version for the
+// stores mounted after genesis, the waypoints are decided on the rootstore version.
+func (st *iavlStore) prune(version, release int64) {
+	pending := st.pendingPrunes
+	st.pendingPrunes = nil
+	for _, p := range pending {
+		st.deleteVersion(p.release, p.version)
+	}
+
+	interval := st.pruneInterval
+	if interval <= 0 {
+		interval = 1
+	}
+	if release%interval != 0 {
+		return
+	}
+
+	offset := version - release
+	last := release - 1 - st.numRecent
+	for toRelease := last; toRelease > last-interval; toRelease-- {
+		toVersion := toRelease + offset
+		// Keep version 1
+		if toVersion <= 1 {
+			return
+		}
+		if st.storeEvery != 0 && toRelease%st.storeEvery == 0 {
+			continue
+		}
+		st.deleteVersion(toRelease, toVersion)
+	}
+}
+
+// deleteVersion deletes the tree version, unless its rootstore version is read
+// by a query, in which case it is deleted at a next commit
+func (st *iavlStore) deleteVersion(release, version int64) {
+	deleted := st.readers.prune(release, func() {
+		err := st.tree.DeleteVersion(version)
+		if err != nil && err.(cmn.Error).Data() != iavl.ErrVersionDoesNotExist {
+			panic(err)
+		}
+	})
+	if !deleted {
+		st.pendingPrunes = append(st.pendingPrunes, pendingPrune{release: release, version: version})
+	}
+}
+
 // Implements Committer.
 func (st *iavlStore) LastCommitID() CommitID {
 	return CommitID{
@@ -151,17 +185,10 @@ func (st *iavlStore) LastCommitID() CommitID {
 }
 
 // Implements Committer.
-func (st *iavlStore) SetPruning(pruning sdk.PruningStrategy) {
-	switch pruning {
-	case sdk.PruneEverything:
-		st.numRecent = 0
-		st.storeEvery = 0
-	case sdk.PruneNothing:
-		st.storeEvery = 1
-	case sdk.PruneSyncable:
-		st.numRecent = NumRecent
-		st.storeEvery = NumStoreEvery
-	}
+func (st *iavlStore) SetPruning(pruning sdk.PruningOptions) {
+	st.numRecent = pruning.KeepRecent
+	st.storeEvery = pruning.KeepEvery
+	st.pruneInterval = pruning.Interval
 }
 
 // VersionExists returns whether or not a given version is stored.
@@ -239,6 +266,19 @@ func getHeight(tree *iavl.MutableTree, req abci.RequestQuery) int64 {
 	return height
 }
 
+// QuerySubspacePageParams are the params of the /subspace_page query
+type QuerySubspacePageParams struct {
+	Subspace []byte `json:"subspace"`
+	StartKey []byte `json:"start_key"` // first key of the page, the start of the subspace if empty
+	Limit    int    `json:"limit"`     // DefaultSubspacePageLimit if 0
+}
+
+// SubspacePage is the result of the /subspace_page query
+type SubspacePage struct {
+	KVs     []KVPair `json:"kvs"`
+	NextKey []byte   `json:"next_key"` // start key of the next page, empty on the last page
+}
+
 // Query implements ABCI interface, allows queries
 //
 // by default we will return from (latest height -1),
@@ -247,6 +287,12 @@ func getHeight(tree *iavl.MutableTree, req abci.RequestQuery) int64 {
 // if you care to have the latest data to see a tx results, you must
 // explicitly set the height you want to see
 func (st *iavlStore) Query(req abci.RequestQuery) (res abci.ResponseQuery) {
+	return st.QueryWithGas(req, sdk.NewInfiniteGasMeter())
+}
+
+// QueryWithGas implements the GasQueryable interface. Reads are charged as in
+// transactions: by key for /key and by iterated pair for the subspace queries.
+func (st *iavlStore) QueryWithGas(req abci.RequestQuery, meter GasMeter) (res abci.ResponseQuery) {
 	if len(req.Data) == 0 {
 		msg := "Query cannot be zero length"
 		return sdk.ErrTxDecode(msg).QueryResult()
@@ -264,8 +310,11 @@ func (st *iavlStore) Query(req abci.RequestQuery) (res abci.ResponseQuery) {
 
 		res.Key = key
 		if !st.VersionExists(res.Height) {
-			res.Log = cmn.ErrorWrap(iavl.ErrVersionDoesNotExist, "").Error()
-			break
+			if res.Height == 0 {
+				res.Log = cmn.ErrorWrap(iavl.ErrVersionDoesNotExist, "").Error()
+				break
+			}
+			return heightNotAvailable(res.Height, tree.Version())
 		}
 
 		if req.Prove {
@@ -292,6 +341,9 @@ func (st *iavlStore) Query(req abci.RequestQuery) (res abci.ResponseQuery) {
 		} else {
 			_, res.Value = tree.GetVersioned(key, res.Height)
 		}
+		gasConfig := sdk.KVGasConfig()
+		meter.ConsumeGas(gasConfig.ReadCostFlat, sdk.GasReadCostFlatDesc)
+		meter.ConsumeGas(gasConfig.ReadCostPerByte*sdk.Gas(len(res.Value)), sdk.GasReadPerByteDesc)
 
 	case "/subspace":
 		var KVs []KVPair
@@ -299,7 +351,7 @@ func (st *iavlStore) Query(req abci.RequestQuery) (res abci.ResponseQuery) {
 		subspace := req.Data
 		res.Key = subspace
 
-		iterator := sdk.KVStorePrefixIterator(st, subspace)
+		iterator := sdk.KVStorePrefixIterator(st.Gas(meter, sdk.KVGasConfig()), subspace)
 		defer iterator.Close()
 		for ; iterator.Valid(); iterator.Next() {
 			KVs = append(KVs, KVPair{Key: iterator.Key(), Value: iterator.Value()})
@@ -307,6 +359,18 @@ func (st *iavlStore) Query(req abci.RequestQuery) (res abci.ResponseQuery) {
 
 		res.Value = cdc.MustMarshalBinaryLengthPrefixed(KVs)
 
+	case "/subspace_page":
+		var params QuerySubspacePageParams
+		if err := cdc.UnmarshalJSON(req.Data, &params); err != nil {
+			return sdk.ParseParamsErr(err).QueryResult()
+		}
+		page, err := st.subspacePage(params, meter)
+		if err != nil {
+			return err.QueryResult()
+		}
+		res.Key = params.Subspace
+		res.Value = cdc.MustMarshalBinaryLengthPrefixed(page)
+
 	default:
 		msg := fmt.Sprintf("Unexpected Query path: %v", req.Path)
 		return sdk.ErrUnknownRequest(msg).QueryResult()
@@ -315,6 +379,48 @@ func (st *iavlStore) Query(req abci.RequestQuery) (res abci.ResponseQuery) {
 	return
 }
 
+// subspacePage returns at most limit pairs of the subspace from the start key on
+func (st *iavlStore) subspacePage(params QuerySubspacePageParams, meter GasMeter) (page SubspacePage, err sdk.Error) {
+	limit := params.Limit
+	if limit == 0 {
+		limit = DefaultSubspacePageLimit
+	}
+	if limit < 0 || limit > MaxSubspacePageLimit {
+		return page, sdk.ErrUnknownRequest(fmt.Sprintf("page limit must be in [1, %d]", MaxSubspacePageLimit))
+	}
+	start := params.Subspace
+	if len(params.StartKey) > 0 {
+		if !bytes.HasPrefix(params.StartKey, params.Subspace) {
+			return page, sdk.ErrUnknownRequest("start key is not in the subspace")
+		}
+		start = params.StartKey
+	}
+
+	iterator := st.Gas(meter, sdk.KVGasConfig()).Iterator(start, sdk.PrefixEndBytes(params.Subspace))
+	defer iterator.Close()
+	for ; iterator.Valid(); iterator.Next() {
+		if len(page.KVs) == limit {
+			page.NextKey = iterator.Key()
+			break
+		}
+		page.KVs = append(page.KVs, KVPair{Key: iterator.Key(), Value: iterator.Value()})
+	}
+	return page, nil
+}
+
+// heightNotAvailable returns the query error for a version which is not in the tree,
+// either because it's not committed yet or because it has been pruned. The pruned
+// heights fail with CodeHeightPruned, so that clients can retry on an archive node.
+func heightNotAvailable(height, latest int64) (res abci.ResponseQuery) {
+	if height > latest {
+		res = sdk.ErrUnknownRequest(fmt.Sprintf("height %d is not committed yet, the latest height is %d", height, latest)).QueryResult()
+	} else {
+		res = sdk.ErrHeightPruned(fmt.Sprintf("height %d has been pruned, query an archive node", height)).QueryResult()
+	}
+	res.Height = height
+	return res
+}
+
 //----------------------------------------
 
 // Implements Iterator.
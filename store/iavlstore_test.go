@@ -385,6 +385,27 @@ func TestIAVLPruneEverything(t *testing.T) {
 	}
 }
 
+func TestIAVLPruningInterval(t *testing.T) {
+	db := dbm.NewMemDB()
+	tree := iavl.NewMutableTree(db, cacheSize)
+	iavlStore := newIAVLStore(tree, int64(0), int64(0))
+	iavlStore.SetPruning(sdk.NewPruningOptions(2, 5, 4))
+	for i := 1; i <= 12; i++ {
+		nextVersion(iavlStore)
+	}
+	// pruned at versions 4, 8 and 12, keeping the last 2 and every 5th
+	for _, ver := range []int64{1, 5, 10, 11, 12} {
+		require.True(t, iavlStore.VersionExists(ver), "Missing version %d", ver)
+	}
+	for _, ver := range []int64{2, 3, 4, 6, 7, 8, 9} {
+		require.False(t, iavlStore.VersionExists(ver), "Unpruned version %d", ver)
+	}
+
+	// versions committed since the last pruning are kept until the next one
+	nextVersion(iavlStore)
+	require.True(t, iavlStore.VersionExists(int64(10)))
+}
+
 func TestIAVLStoreQuery(t *testing.T) {
 	db := dbm.NewMemDB()
 	tree := iavl.NewMutableTree(db, cacheSize)
@@ -475,6 +496,76 @@ func TestIAVLStoreQuery(t *testing.T) {
 	require.Equal(t, v1, qres.Value)
 }
 
+func TestIAVLStoreQuerySubspacePage(t *testing.T) {
+	db := dbm.NewMemDB()
+	tree := iavl.NewMutableTree(db, cacheSize)
+	iavlStore := newIAVLStore(tree, numRecent, storeEvery)
+	for i := 0; i < 5; i++ {
+		iavlStore.Set([]byte(fmt.Sprintf("key%d", i)), []byte(fmt.Sprintf("val%d", i)))
+	}
+	iavlStore.Set([]byte("other"), []byte("val"))
+	iavlStore.Commit(nil)
+
+	queryPage := func(startKey []byte, limit int) (SubspacePage, abci.ResponseQuery) {
+		params := QuerySubspacePageParams{Subspace: []byte("key"), StartKey: startKey, Limit: limit}
+		qres := iavlStore.Query(abci.RequestQuery{Path: "/subspace_page", Data: cdc.MustMarshalJSON(params)})
+		var page SubspacePage
+		if qres.IsOK() {
+			cdc.MustUnmarshalBinaryLengthPrefixed(qres.Value, &page)
+		}
+		return page, qres
+	}
+
+	page, qres := queryPage(nil, 2)
+	require.Equal(t, uint32(sdk.CodeOK), qres.Code)
+	require.Equal(t, []KVPair{{Key: []byte("key0"), Value: []byte("val0")}, {Key: []byte("key1"), Value: []byte("val1")}}, page.KVs)
+	require.Equal(t, []byte("key2"), page.NextKey)
+
+	page, _ = queryPage(page.NextKey, 2)
+	require.Equal(t, []byte("key2"), page.KVs[0].Key)
+	require.Equal(t, []byte("key4"), page.NextKey)
+
+	// the last page has no next key
+	page, _ = queryPage(page.NextKey, 2)
+	require.Len(t, page.KVs, 1)
+	require.Nil(t, page.NextKey)
+
+	// the default limit returns the whole subspace
+	page, _ = queryPage(nil, 0)
+	require.Len(t, page.KVs, 5)
+
+	_, qres = queryPage([]byte("other"), 2)
+	require.False(t, qres.IsOK())
+	_, qres = queryPage(nil, MaxSubspacePageLimit+1)
+	require.False(t, qres.IsOK())
+}
+
+func TestIAVLStoreQueryGas(t *testing.T) {
+	db := dbm.NewMemDB()
+	tree := iavl.NewMutableTree(db, cacheSize)
+	iavlStore := newIAVLStore(tree, numRecent, storeEvery)
+	for i := 0; i < 100; i++ {
+		iavlStore.Set([]byte(fmt.Sprintf("key%03d", i)), []byte("value"))
+	}
+	iavlStore.Commit(nil)
+
+	meter := sdk.NewInfiniteGasMeter()
+	qres := iavlStore.QueryWithGas(abci.RequestQuery{Path: "/key", Data: []byte("key000")}, meter)
+	require.Equal(t, uint32(sdk.CodeOK), qres.Code)
+	keyGas := meter.GasConsumed()
+	require.True(t, keyGas > 0)
+
+	meter = sdk.NewInfiniteGasMeter()
+	iavlStore.QueryWithGas(abci.RequestQuery{Path: "/subspace", Data: []byte("key")}, meter)
+	require.True(t, meter.GasConsumed() > 100*keyGas/2)
+
+	// the subspace query runs out of gas before iterating over the whole subspace
+	meter = sdk.NewGasMeter(10 * keyGas)
+	require.Panics(t, func() {
+		iavlStore.QueryWithGas(abci.RequestQuery{Path: "/subspace", Data: []byte("key")}, meter)
+	})
+}
+
 func BenchmarkIAVLIteratorNext(b *testing.B) {
 	db := dbm.NewMemDB()
 	treeSize := 1000
@@ -0,0 +1,82 @@
+package store
+
+import (
+	"io"
+
+	sdk "github.com/NPC-Chain/npcchub/types"
+	"github.com/tendermint/iavl"
+)
+
+var _ KVStore = (*immutableIAVLStore)(nil)
+
+// immutableIAVLStore is a read-only KVStore of a committed version of an iavl
+// tree. It only reads nodes from the node db, so that it is safe to use
+// concurrently with the commits of the mutable tree.
+type immutableIAVLStore struct {
+	tree *iavl.ImmutableTree
+}
+
+// GetImmutable returns a read-only store of the committed version.
+func (st *iavlStore) GetImmutable(version int64) (*immutableIAVLStore, error) {
+	tree, err := st.tree.GetImmutable(version)
+	if err != nil {
+		return nil, err
+	}
+	return &immutableIAVLStore{tree: tree}, nil
+}
+
+// Implements Store.
+func (st *immutableIAVLStore) GetStoreType() StoreType {
+	return sdk.StoreTypeIAVL
+}
+
+// Implements Store.
+func (st *immutableIAVLStore) CacheWrap() CacheWrap {
+	return NewCacheKVStore(st)
+}
+
+// CacheWrapWithTrace implements the Store interface.
+func (st *immutableIAVLStore) CacheWrapWithTrace(w io.Writer, tc TraceContext) CacheWrap {
+	return NewCacheKVStore(NewTraceKVStore(st, w, tc))
+}
+
+// Implements KVStore.
+func (st *immutableIAVLStore) Get(key []byte) (value []byte) {
+	_, v := st.tree.Get(key)
+	return v
+}
+
+// Implements KVStore.
+func (st *immutableIAVLStore) Has(key []byte) (exists bool) {
+	return st.tree.Has(key)
+}
+
+// Implements KVStore. It panics as the store is read-only.
+func (st *immutableIAVLStore) Set(key, value []byte) {
+	panic("cannot Set on an immutable iavl store")
+}
+
+// Implements KVStore. It panics as the store is read-only.
+func (st *immutableIAVLStore) Delete(key []byte) {
+	panic("cannot Delete on an immutable iavl store")
+}
+
+// Implements KVStore
+func (st *immutableIAVLStore) Prefix(prefix []byte) KVStore {
+	return prefixStore{st, prefix}
+}
+
+// Implements KVStore
+func (st *immutableIAVLStore) Gas(meter GasMeter, config GasConfig) KVStore {
+	return NewGasKVStore(meter, config, st)
+}
+
+// Implements KVStore.
+func (st *immutableIAVLStore) Iterator(start, end []byte) Iterator {
+	return newIAVLIterator(st.tree, start, end, true)
+}
+
+// Implements KVStore.
+func (st *immutableIAVLStore) ReverseIterator(start, end []byte) Iterator {
+	return newIAVLIterator(st.tree, start, end, false)
+}
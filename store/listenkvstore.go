@@ -0,0 +1,94 @@
+package store
+
+import (
+	"io"
+
+	sdk "github.com/NPC-Chain/npcchub/types"
+)
+
+// ListenKVStore implements the KVStore interface and notifies its listeners of
+// every Set and Delete before delegating them to the parent KVStore. Reads and
+// iterations are passed through.
+type ListenKVStore struct {
+	parent    sdk.KVStore
+	storeKey  StoreKey
+	listeners []WriteListener
+}
+
+// NewListenKVStore returns a reference to a new ListenKVStore given a parent
+// KVStore implementation, the key of the store and the listeners to notify.
+func NewListenKVStore(parent sdk.KVStore, storeKey StoreKey, listeners []WriteListener) *ListenKVStore {
+	return &ListenKVStore{parent: parent, storeKey: storeKey, listeners: listeners}
+}
+
+// Get implements the KVStore interface. It delegates the Get call to the
+// parent KVStore.
+func (lkv *ListenKVStore) Get(key []byte) []byte {
+	return lkv.parent.Get(key)
+}
+
+// Set implements the KVStore interface. It notifies the listeners of the write
+// and delegates the Set call to the parent KVStore.
+func (lkv *ListenKVStore) Set(key []byte, value []byte) {
+	lkv.parent.Set(key, value)
+	lkv.onWrite(key, value, false)
+}
+
+// Delete implements the KVStore interface. It notifies the listeners of the
+// deletion and delegates the Delete call to the parent KVStore.
+func (lkv *ListenKVStore) Delete(key []byte) {
+	lkv.parent.Delete(key)
+	lkv.onWrite(key, nil, true)
+}
+
+// Has implements the KVStore interface. It delegates the Has call to the
+// parent KVStore.
+func (lkv *ListenKVStore) Has(key []byte) bool {
+	return lkv.parent.Has(key)
+}
+
+// Prefix implements the KVStore interface.
+func (lkv *ListenKVStore) Prefix(prefix []byte) KVStore {
+	return prefixStore{lkv, prefix}
+}
+
+// Gas implements the KVStore interface. The gas store writes through the
+// ListenKVStore so that its writes are notified as well.
+func (lkv *ListenKVStore) Gas(meter GasMeter, config GasConfig) KVStore {
+	return NewGasKVStore(meter, config, lkv)
+}
+
+// Iterator implements the KVStore interface. It delegates the Iterator call
+// to the parent KVStore.
+func (lkv *ListenKVStore) Iterator(start, end []byte) sdk.Iterator {
+	return lkv.parent.Iterator(start, end)
+}
+
+// ReverseIterator implements the KVStore interface. It delegates the
+// ReverseIterator call to the parent KVStore.
+func (lkv *ListenKVStore) ReverseIterator(start, end []byte) sdk.Iterator {
+	return lkv.parent.ReverseIterator(start, end)
+}
+
+// GetStoreType implements the KVStore interface. It returns the underlying
+// KVStore type.
+func (lkv *ListenKVStore) GetStoreType() sdk.StoreType {
+	return lkv.parent.GetStoreType()
+}
+
+// CacheWrap implements the KVStore interface. The writes of the cache are
+// notified when it is written to the ListenKVStore.
+func (lkv *ListenKVStore) CacheWrap() sdk.CacheWrap {
+	return NewCacheKVStore(lkv)
+}
+
+// CacheWrapWithTrace implements the KVStore interface.
+func (lkv *ListenKVStore) CacheWrapWithTrace(w io.Writer, tc TraceContext) CacheWrap {
+	return NewCacheKVStore(NewTraceKVStore(lkv, w, tc))
+}
+
+func (lkv *ListenKVStore) onWrite(key []byte, value []byte, delete bool) {
+	for _, listener := range lkv.listeners {
+		listener.OnWrite(lkv.storeKey, key, value, delete)
+	}
+}
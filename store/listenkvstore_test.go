@@ -0,0 +1,78 @@
+package store
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/NPC-Chain/npcchub/types"
+	dbm "github.com/tendermint/tm-db"
+)
+
+type writeRecord struct {
+	store  string
+	key    string
+	value  string
+	delete bool
+}
+
+type writeRecorder struct {
+	writes []writeRecord
+}
+
+func (wr *writeRecorder) OnWrite(storeKey StoreKey, key []byte, value []byte, delete bool) {
+	wr.writes = append(wr.writes, writeRecord{storeKey.Name(), string(key), string(value), delete})
+}
+
+func TestListenKVStore(t *testing.T) {
+	recorder := &writeRecorder{}
+	key := sdk.NewKVStoreKey("store")
+	store := NewListenKVStore(dbStoreAdapter{dbm.NewMemDB()}, key, []WriteListener{recorder})
+
+	store.Set([]byte("key1"), []byte("value1"))
+	store.Prefix([]byte("p/")).Set([]byte("key2"), []byte("value2"))
+	store.Gas(sdk.NewInfiniteGasMeter(), sdk.KVGasConfig()).Delete([]byte("key1"))
+	require.Equal(t, []byte("value2"), store.Get([]byte("p/key2")))
+
+	// writes of a cache are notified once it is written
+	cache := store.CacheWrap().(CacheKVStore)
+	cache.Set([]byte("key3"), []byte("value3"))
+	require.Len(t, recorder.writes, 3)
+	cache.Write()
+
+	require.Equal(t, []writeRecord{
+		{"store", "key1", "value1", false},
+		{"store", "p/key2", "value2", false},
+		{"store", "key1", "", true},
+		{"store", "key3", "value3", false},
+	}, recorder.writes)
+}
+
+func TestMultiStoreListeners(t *testing.T) {
+	recorder := &writeRecorder{}
+	db := dbm.NewMemDB()
+	store := newMultiStoreWithMounts(db)
+	transientKey := sdk.NewTransientStoreKey("transient")
+	store.MountStoreWithDB(transientKey, sdk.StoreTypeTransient, nil)
+	require.Nil(t, store.LoadLatestVersion())
+	require.False(t, store.ListeningEnabled())
+
+	store.AddListeners(recorder)
+	require.True(t, store.ListeningEnabled())
+
+	key1 := store.keysByName["store1"]
+	key2 := store.keysByName["store2"]
+	store.GetKVStore(key1).Set([]byte("key1"), []byte("value1"))
+
+	// cached writes are notified when flushed to the root stores only
+	cache := store.CacheMultiStore()
+	cache.GetKVStore(key2).Set([]byte("key2"), []byte("value2"))
+	cache.GetKVStore(transientKey).Set([]byte("key3"), []byte("value3"))
+	require.Len(t, recorder.writes, 1)
+	cache.Write()
+
+	require.Equal(t, []writeRecord{
+		{"store1", "key1", "value1", false},
+		{"store2", "key2", "value2", false},
+	}, recorder.writes)
+}
@@ -12,7 +12,7 @@ import (
 func TestVerifyIAVLStoreQueryProof(t *testing.T) {
 	// Create main tree for testing.
 	db := dbm.NewMemDB()
-	iStore, err := LoadIAVLStore(db, CommitID{}, sdk.PruneNothing, false)
+	iStore, err := LoadIAVLStore(db, CommitID{}, sdk.PruneNothing.Options(), false)
 	store := iStore.(*iavlStore)
 	require.Nil(t, err)
 	store.Set([]byte("MYKEY"), []byte("MYVALUE"))
@@ -0,0 +1,200 @@
+package store
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"sync"
+
+	cmn "github.com/tendermint/tendermint/libs/common"
+	dbm "github.com/tendermint/tm-db"
+)
+
+// overlayDB keeps the writes in memory on top of a parent db which is only read,
+// so that blocks can be re-executed against a node's state without changing it.
+type overlayDB struct {
+	mtx    sync.Mutex
+	parent dbm.DB
+	dirty  map[string]cValue
+}
+
+var _ dbm.DB = (*overlayDB)(nil)
+
+// NewOverlayDB returns a db reading through to the parent and keeping all the writes
+// in memory. Nothing is ever written to the parent, closing the overlay doesn't close it.
+func NewOverlayDB(parent dbm.DB) dbm.DB {
+	return &overlayDB{
+		parent: parent,
+		dirty:  make(map[string]cValue),
+	}
+}
+
+// Implements DB.
+func (db *overlayDB) Get(key []byte) []byte {
+	db.mtx.Lock()
+	defer db.mtx.Unlock()
+
+	if value, ok := db.dirty[string(key)]; ok {
+		return value.value
+	}
+	return db.parent.Get(key)
+}
+
+// Implements DB.
+func (db *overlayDB) Has(key []byte) bool {
+	return db.Get(key) != nil
+}
+
+// Implements DB.
+func (db *overlayDB) Set(key []byte, value []byte) {
+	db.mtx.Lock()
+	defer db.mtx.Unlock()
+
+	db.set(key, value)
+}
+
+// Implements DB.
+func (db *overlayDB) SetSync(key []byte, value []byte) {
+	db.Set(key, value)
+}
+
+// Implements DB.
+func (db *overlayDB) Delete(key []byte) {
+	db.mtx.Lock()
+	defer db.mtx.Unlock()
+
+	db.delete(key)
+}
+
+// Implements DB.
+func (db *overlayDB) DeleteSync(key []byte) {
+	db.Delete(key)
+}
+
+func (db *overlayDB) set(key []byte, value []byte) {
+	if value == nil {
+		panic("value is nil")
+	}
+	db.dirty[string(key)] = cValue{value: cp(value), dirty: true}
+}
+
+func (db *overlayDB) delete(key []byte) {
+	db.dirty[string(key)] = cValue{deleted: true, dirty: true}
+}
+
+// Implements DB.
+func (db *overlayDB) Iterator(start, end []byte) dbm.Iterator {
+	return db.iterator(start, end, true)
+}
+
+// Implements DB.
+func (db *overlayDB) ReverseIterator(start, end []byte) dbm.Iterator {
+	return db.iterator(start, end, false)
+}
+
+func (db *overlayDB) iterator(start, end []byte, ascending bool) dbm.Iterator {
+	db.mtx.Lock()
+	defer db.mtx.Unlock()
+
+	var parent dbm.Iterator
+	if ascending {
+		parent = db.parent.Iterator(start, end)
+	} else {
+		parent = db.parent.ReverseIterator(start, end)
+	}
+
+	items := make([]cmn.KVPair, 0)
+	for key, value := range db.dirty {
+		if dbm.IsKeyInDomain([]byte(key), start, end) {
+			items = append(items, cmn.KVPair{Key: []byte(key), Value: value.value})
+		}
+	}
+	sort.Slice(items, func(i, j int) bool {
+		if ascending {
+			return bytes.Compare(items[i].Key, items[j].Key) < 0
+		}
+		return bytes.Compare(items[i].Key, items[j].Key) > 0
+	})
+
+	return newCacheMergeIterator(parent, newMemIterator(start, end, items), ascending)
+}
+
+// Implements DB. The parent is left open.
+func (db *overlayDB) Close() {}
+
+// Implements DB.
+func (db *overlayDB) NewBatch() dbm.Batch {
+	return &overlayBatch{db: db}
+}
+
+// Implements DB.
+func (db *overlayDB) Print() {
+	db.mtx.Lock()
+	defer db.mtx.Unlock()
+
+	for key, value := range db.dirty {
+		if value.deleted {
+			fmt.Printf("[%X]:\tdeleted\n", []byte(key))
+		} else {
+			fmt.Printf("[%X]:\t[%X]\n", []byte(key), value.value)
+		}
+	}
+}
+
+// Implements DB.
+func (db *overlayDB) Stats() map[string]string {
+	db.mtx.Lock()
+	defer db.mtx.Unlock()
+
+	return map[string]string{
+		"database.type":  "overlayDB",
+		"database.dirty": fmt.Sprintf("%d", len(db.dirty)),
+	}
+}
+
+type overlayOp struct {
+	key, value []byte
+	deleted    bool
+}
+
+// overlayBatch applies its operations to the overlay at once on Write
+type overlayBatch struct {
+	db  *overlayDB
+	ops []overlayOp
+}
+
+var _ dbm.Batch = (*overlayBatch)(nil)
+
+// Implements Batch.
+func (b *overlayBatch) Set(key, value []byte) {
+	b.ops = append(b.ops, overlayOp{key: cp(key), value: cp(value)})
+}
+
+// Implements Batch.
+func (b *overlayBatch) Delete(key []byte) {
+	b.ops = append(b.ops, overlayOp{key: cp(key), deleted: true})
+}
+
+// Implements Batch.
+func (b *overlayBatch) Write() {
+	b.db.mtx.Lock()
+	defer b.db.mtx.Unlock()
+
+	for _, op := range b.ops {
+		if op.deleted {
+			b.db.delete(op.key)
+		} else {
+			b.db.set(op.key, op.value)
+		}
+	}
+}
+
+// Implements Batch.
+func (b *overlayBatch) WriteSync() {
+	b.Write()
+}
+
+// Implements Batch.
+func (b *overlayBatch) Close() {
+	b.ops = nil
+}
@@ -0,0 +1,95 @@
+package store
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tm-db"
+)
+
+func TestOverlayDB(t *testing.T) {
+	parent := dbm.NewMemDB()
+	parent.Set(keyFmt(1), valFmt(1))
+	parent.Set(keyFmt(2), valFmt(2))
+	parent.Set(keyFmt(3), valFmt(3))
+
+	db := NewOverlayDB(parent)
+	require.Equal(t, valFmt(1), db.Get(keyFmt(1)))
+
+	db.Set(keyFmt(1), valFmt(10))
+	db.Delete(keyFmt(2))
+	db.Set(keyFmt(4), valFmt(4))
+	require.Equal(t, valFmt(10), db.Get(keyFmt(1)))
+	require.False(t, db.Has(keyFmt(2)))
+	require.True(t, db.Has(keyFmt(4)))
+
+	batch := db.NewBatch()
+	batch.Set(keyFmt(5), valFmt(5))
+	batch.Delete(keyFmt(3))
+	require.True(t, db.Has(keyFmt(3)))
+	batch.Write()
+	batch.Close()
+	require.False(t, db.Has(keyFmt(3)))
+	require.Equal(t, valFmt(5), db.Get(keyFmt(5)))
+
+	// the iterators merge the writes with the parent
+	var keys [][]byte
+	iterator := db.Iterator(nil, nil)
+	for ; iterator.Valid(); iterator.Next() {
+		keys = append(keys, iterator.Key())
+	}
+	iterator.Close()
+	require.Equal(t, [][]byte{keyFmt(1), keyFmt(4), keyFmt(5)}, keys)
+
+	keys = nil
+	iterator = db.ReverseIterator(keyFmt(0), keyFmt(5))
+	for ; iterator.Valid(); iterator.Next() {
+		keys = append(keys, iterator.Key())
+	}
+	iterator.Close()
+	require.Equal(t, [][]byte{keyFmt(4), keyFmt(1)}, keys)
+
+	// the parent is never written
+	db.Close()
+	require.Equal(t, valFmt(1), parent.Get(keyFmt(1)))
+	require.Equal(t, valFmt(2), parent.Get(keyFmt(2)))
+	require.Equal(t, valFmt(3), parent.Get(keyFmt(3)))
+	require.False(t, parent.Has(keyFmt(4)))
+	require.False(t, parent.Has(keyFmt(5)))
+}
+
+func TestOverlayDBReplay(t *testing.T) {
+	parent := dbm.NewMemDB()
+	store := newMultiStoreWithMounts(parent)
+	require.Nil(t, store.LoadLatestVersion())
+	for i := 1; i <= 3; i++ {
+		store.getStoreByName("store1").(KVStore).Set(keyFmt(i), valFmt(i))
+		store.Commit(nil)
+	}
+	recorded, err := GetStoreHashes(parent, 3)
+	require.Nil(t, err)
+	require.Len(t, recorded, 3)
+
+	// re-executing the same writes from version 1 gives the recorded hashes
+	db := NewOverlayDB(parent)
+	store = newMultiStoreWithMounts(db)
+	require.Nil(t, store.LoadVersion(1, false))
+	for i := 2; i <= 3; i++ {
+		store.getStoreByName("store1").(KVStore).Set(keyFmt(i), valFmt(i))
+		store.Commit(nil)
+	}
+	replayed, err := GetStoreHashes(db, 3)
+	require.Nil(t, err)
+	require.Equal(t, recorded, replayed)
+
+	// diverging writes only change the hash of their store and leave the parent as it was
+	store.getStoreByName("store2").(KVStore).Set(keyFmt(4), valFmt(4))
+	store.Commit(nil)
+	replayed, err = GetStoreHashes(db, 4)
+	require.Nil(t, err)
+	require.Equal(t, recorded["store1"], replayed["store1"])
+	require.NotEqual(t, recorded["store2"], replayed["store2"])
+	_, err = GetStoreHashes(parent, 4)
+	require.NotNil(t, err)
+	require.Equal(t, int64(3), getLatestVersion(parent))
+}
@@ -0,0 +1,48 @@
+package store
+
+import (
+	"fmt"
+
+	sdk "github.com/NPC-Chain/npcchub/types"
+	"github.com/tendermint/iavl"
+	dbm "github.com/tendermint/tm-db"
+)
+
+// PruneStores deletes the versions of the IAVL stores committed to db which are not
+// kept by the pruning strategy at the latest version, and returns the number of
+// deleted store versions. As on commit, version 1 is always kept.
+func PruneStores(db dbm.DB, pruning sdk.PruningOptions) (int64, error) {
+	if err := pruning.Validate(); err != nil {
+		return 0, err
+	}
+	latest := getLatestVersion(db)
+	if latest == 0 {
+		return 0, fmt.Errorf("no committed version to prune")
+	}
+	cInfo, err := getCommitInfo(db, latest)
+	if err != nil {
+		return 0, err
+	}
+
+	var pruned int64
+	for _, si := range cInfo.StoreInfos {
+		tree := iavl.NewMutableTree(dbm.NewPrefixDB(db, storePrefix(si.Name)), defaultIAVLCacheSize)
+		treeLatest, err := tree.Load()
+		if err != nil {
+			return pruned, fmt.Errorf("failed to load store %s: %v", si.Name, err)
+		}
+
+		// the tree version lags behind the rootstore version for the stores mounted after genesis
+		offset := treeLatest - latest
+		for version := int64(2); version < treeLatest; version++ {
+			if !tree.VersionExists(version) || pruning.Keep(version-offset, latest) {
+				continue
+			}
+			if err := tree.DeleteVersion(version); err != nil {
+				return pruned, fmt.Errorf("failed to delete version %d of store %s: %v", version, si.Name, err)
+			}
+			pruned++
+		}
+	}
+	return pruned, nil
+}
@@ -0,0 +1,44 @@
+package store
+
+import (
+	"testing"
+
+	sdk "github.com/NPC-Chain/npcchub/types"
+	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tm-db"
+)
+
+func TestPruneStores(t *testing.T) {
+	db := dbm.NewMemDB()
+	store := newMultiStoreWithMounts(db)
+	store.SetPruning(sdk.PruneNothing.Options())
+	require.Nil(t, store.LoadLatestVersion())
+
+	key := store.keysByName["store1"]
+	for i := 0; i < 20; i++ {
+		store.GetKVStore(key).Set([]byte("key"), []byte{byte(i)})
+		store.Commit(nil)
+	}
+
+	pruned, err := PruneStores(db, sdk.NewPruningOptions(3, 10, 1))
+	require.Nil(t, err)
+	// versions 2-9 and 11-16 of the 3 stores
+	require.Equal(t, int64(3*14), pruned)
+
+	// the pruned db can be loaded and committed to
+	restored := newMultiStoreWithMounts(db)
+	require.Nil(t, restored.LoadLatestVersion())
+	require.Equal(t, store.LastCommitID(), restored.LastCommitID())
+
+	iavlStore := restored.GetCommitKVStore(restored.keysByName["store1"]).(*iavlStore)
+	for _, ver := range []int64{1, 10, 17, 18, 19, 20} {
+		require.True(t, iavlStore.VersionExists(ver), "Missing version %d", ver)
+	}
+	for _, ver := range []int64{2, 9, 11, 16} {
+		require.False(t, iavlStore.VersionExists(ver), "Unpruned version %d", ver)
+	}
+	require.Equal(t, int64(21), restored.Commit(nil).Version)
+
+	_, err = PruneStores(db, sdk.NewPruningOptions(3, 10, 0))
+	require.NotNil(t, err)
+}
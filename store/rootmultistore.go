@@ -24,22 +24,28 @@ const (
 type rootMultiStore struct {
 	db           dbm.DB
 	lastCommitID CommitID
-	pruning      sdk.PruningStrategy
+	pruning      sdk.PruningOptions
+	readers      *versionReaders
 	storesParams map[StoreKey]storeParams
 	stores       map[StoreKey]CommitStore
 	keysByName   map[string]StoreKey
 
 	traceWriter  io.Writer
 	traceContext TraceContext
+
+	listeners []WriteListener
 }
 
 var _ CommitMultiStore = (*rootMultiStore)(nil)
 var _ Queryable = (*rootMultiStore)(nil)
+var _ GasQueryable = (*rootMultiStore)(nil)
 
 // nolint
 func NewCommitMultiStore(db dbm.DB) *rootMultiStore {
 	return &rootMultiStore{
 		db:           db,
+		pruning:      sdk.PruneSyncable.Options(),
+		readers:      newVersionReaders(),
 		storesParams: make(map[StoreKey]storeParams),
 		stores:       make(map[StoreKey]CommitStore),
 		keysByName:   make(map[string]StoreKey),
@@ -47,7 +53,7 @@ func NewCommitMultiStore(db dbm.DB) *rootMultiStore {
 }
 
 // Implements CommitMultiStore
-func (rs *rootMultiStore) SetPruning(pruning sdk.PruningStrategy) {
+func (rs *rootMultiStore) SetPruning(pruning sdk.PruningOptions) {
 	rs.pruning = pruning
 	for _, substore := range rs.stores {
 		substore.SetPruning(pruning)
@@ -191,6 +197,27 @@ func (rs *rootMultiStore) ResetTraceContext() MultiStore {
 	return rs
 }
 
+// AddListeners adds listeners notified of every write flushed to the
+// persistent stores, either through GetKVStore or by writing a cache of the
+// rootMultiStore.
+func (rs *rootMultiStore) AddListeners(listeners ...WriteListener) {
+	rs.listeners = append(rs.listeners, listeners...)
+}
+
+// ListeningEnabled returns if any listener is notified of the writes.
+func (rs *rootMultiStore) ListeningEnabled() bool {
+	return len(rs.listeners) > 0
+}
+
+// listenStore wraps the store of the key into a ListenKVStore if listening is
+// enabled and the store is not transient.
+func (rs *rootMultiStore) listenStore(key StoreKey, store KVStore) KVStore {
+	if !rs.ListeningEnabled() || rs.storesParams[key].typ == sdk.StoreTypeTransient {
+		return store
+	}
+	return NewListenKVStore(store, key, rs.listeners)
+}
+
 //----------------------------------------
 // +CommitStore
 
@@ -244,6 +271,36 @@ func (rs *rootMultiStore) CacheMultiStore() CacheMultiStore {
 	return newCacheMultiStoreFromRMS(rs)
 }
 
+// CacheMultiStoreWithVersion implements the CommitMultiStore interface. The
+// iavl stores are read-only views of the committed version, which are safe to
+// read concurrently with the commits of new versions. Transient stores are
+// empty. The version is not pruned until the returned release is called.
+func (rs *rootMultiStore) CacheMultiStoreWithVersion(version int64) (CacheMultiStore, func(), error) {
+	stores := make(map[StoreKey]CacheWrapper, len(rs.stores))
+	err := rs.readers.acquire(version, func() error {
+		for key, store := range rs.stores {
+			switch store := store.(type) {
+			case *iavlStore:
+				immutable, err := store.GetImmutable(version)
+				if err != nil {
+					return fmt.Errorf("failed to load version %d of store %s: %v", version, key.Name(), err)
+				}
+				stores[key] = immutable
+			case *transientStore:
+				stores[key] = newTransientStore()
+			default:
+				stores[key] = store
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	release := func() { rs.readers.release(version) }
+	return newCacheMultiStore(rs.db, stores, rs.keysByName, rs.traceWriter, rs.traceContext), release, nil
+}
+
 // If the store does not exist, panics.
 func (rs *rootMultiStore) GetStore(key StoreKey) Store {
 	store := rs.stores[key]
@@ -255,10 +312,11 @@ func (rs *rootMultiStore) GetStore(key StoreKey) Store {
 
 // GetKVStore implements the MultiStore interface. If tracing is enabled on the
 // rootMultiStore, a wrapped TraceKVStore will be returned with the given
-// tracer, otherwise, the original KVStore will be returned.
+// tracer, otherwise, the original KVStore will be returned. Writes are
+// notified to the listeners of the rootMultiStore, if any.
 // If the store does not exist, panics.
 func (rs *rootMultiStore) GetKVStore(key StoreKey) KVStore {
-	store := rs.stores[key].(KVStore)
+	store := rs.listenStore(key, rs.stores[key].(KVStore))
 
 	if rs.TracingEnabled() {
 		store = NewTraceKVStore(store, rs.traceWriter, rs.traceContext)
@@ -287,8 +345,15 @@ func (rs *rootMultiStore) getStoreByName(name string) Store {
 // Query calls substore.Query with the same `req` where `req.Path` is
 // modified to remove the substore prefix.
 // Ie. `req.Path` here is `/<substore>/<path>`, and trimmed to `/<path>` for the substore.
-// TODO: add proof for `multistore -> substore`.
+// When a proof is requested, the substore proof is chained with a MultiStoreProofOp
+// proving the substore root against the commitInfo hash of the queried height.
 func (rs *rootMultiStore) Query(req abci.RequestQuery) abci.ResponseQuery {
+	return rs.QueryWithGas(req, sdk.NewInfiniteGasMeter())
+}
+
+// QueryWithGas implements the GasQueryable interface. The gas is metered by
+// the substore if it is a GasQueryable.
+func (rs *rootMultiStore) QueryWithGas(req abci.RequestQuery, meter GasMeter) abci.ResponseQuery {
 	// Query just routes this to a substore.
 	path := req.Path
 	storeName, subpath, err := parsePath(path)
@@ -309,7 +374,15 @@ func (rs *rootMultiStore) Query(req abci.RequestQuery) abci.ResponseQuery {
 
 	// trim the path and make the query
 	req.Path = subpath
-	res := queryable.Query(req)
+	var res abci.ResponseQuery
+	if gasQueryable, ok := queryable.(GasQueryable); ok {
+		res = gasQueryable.QueryWithGas(req, meter)
+	} else {
+		res = queryable.Query(req)
+	}
+	if !res.IsOK() {
+		return res
+	}
 
 	if !req.Prove || !RequireProof(subpath) {
 		return res
@@ -330,8 +403,6 @@ func (rs *rootMultiStore) Query(req abci.RequestQuery) abci.ResponseQuery {
 		NewMultiStoreProof(commitInfo.StoreInfos),
 	).ProofOp())
 
-	// TODO: handle in another TM v0.26 update PR
-	// res.Proof = buildMultiStoreProof(res.Proof, storeName, commitInfo.StoreInfos)
 	return res
 }
 
@@ -361,7 +432,7 @@ func (rs *rootMultiStore) loadCommitStoreFromParams(key sdk.StoreKey, id CommitI
 	if params.db != nil {
 		db = dbm.NewPrefixDB(params.db, []byte("s/_/"))
 	} else {
-		db = dbm.NewPrefixDB(rs.db, []byte("s/k:"+params.key.Name()+"/"))
+		db = dbm.NewPrefixDB(rs.db, storePrefix(params.key.Name()))
 	}
 	switch params.typ {
 	case sdk.StoreTypeMulti:
@@ -370,6 +441,9 @@ func (rs *rootMultiStore) loadCommitStoreFromParams(key sdk.StoreKey, id CommitI
 		// return NewCommitMultiStore(db, id)
 	case sdk.StoreTypeIAVL:
 		store, err = LoadIAVLStore(db, id, rs.pruning, overwrite)
+		if err == nil {
+			store.(*iavlStore).readers = rs.readers
+		}
 		return
 	case sdk.StoreTypeDB:
 		panic("dbm.DB is not a CommitStore")
@@ -391,7 +465,7 @@ func (rs *rootMultiStore) resetStore(key sdk.StoreKey, params storeParams) (err
 	if params.db != nil {
 		db = dbm.NewPrefixDB(params.db, []byte("s/_/"))
 	} else {
-		db = dbm.NewPrefixDB(rs.db, []byte("s/k:"+params.key.Name()+"/"))
+		db = dbm.NewPrefixDB(rs.db, storePrefix(params.key.Name()))
 	}
 	switch params.typ {
 	case sdk.StoreTypeMulti:
@@ -591,6 +665,21 @@ func getCommitInfo(db dbm.DB, ver int64) (commitInfo, error) {
 	return cInfo, nil
 }
 
+// GetStoreHashes returns the commit hash of every store at the version from the
+// commitInfo saved in the db.
+func GetStoreHashes(db dbm.DB, ver int64) (map[string][]byte, error) {
+	cInfo, err := getCommitInfo(db, ver)
+	if err != nil {
+		return nil, err
+	}
+
+	hashes := make(map[string][]byte, len(cInfo.StoreInfos))
+	for _, storeInfo := range cInfo.StoreInfos {
+		hashes[storeInfo.Name] = storeInfo.Core.CommitID.Hash
+	}
+	return hashes, nil
+}
+
 // Set a commitInfo for given version.
 func setCommitInfo(batch dbm.Batch, version int64, cInfo commitInfo) {
 	cInfoBytes := cdc.MustMarshalBinaryLengthPrefixed(cInfo)
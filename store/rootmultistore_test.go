@@ -228,6 +228,127 @@ func TestMultiStoreQuery(t *testing.T) {
 	require.Equal(t, v2, qres.Value)
 }
 
+func TestMultiStoreQueryHistoricalProof(t *testing.T) {
+	db := dbm.NewMemDB()
+	multi := newMultiStoreWithMounts(db)
+	multi.SetPruning(sdk.NewPruningOptions(1, 0, 1))
+	require.Nil(t, multi.LoadLatestVersion())
+
+	k, k2 := []byte("wind"), []byte("water")
+	store1 := multi.getStoreByName("store1").(KVStore)
+	var commitIDs []CommitID
+	for i := 0; i < 5; i++ {
+		store1.Set(k, []byte{byte(i)})
+		commitIDs = append(commitIDs, multi.Commit(nil))
+	}
+
+	prt := DefaultProofRuntime()
+	for _, height := range []int64{4, 5} {
+		query := abci.RequestQuery{Path: "/store1/key", Data: k, Height: height, Prove: true}
+		qres := multi.Query(query)
+		require.EqualValues(t, sdk.CodeOK, qres.Code)
+		require.Equal(t, []byte{byte(height - 1)}, qres.Value)
+
+		kp := merkle.KeyPath{}
+		kp = kp.AppendKey([]byte("store1"), merkle.KeyEncodingURL)
+		kp = kp.AppendKey(k, merkle.KeyEncodingURL)
+		require.Nil(t, prt.VerifyValue(qres.Proof, commitIDs[height-1].Hash, kp.String(), qres.Value))
+		// the proof doesn't verify against another height
+		require.NotNil(t, prt.VerifyValue(qres.Proof, commitIDs[0].Hash, kp.String(), qres.Value))
+
+		// absence proofs are chained as well
+		query.Data = k2
+		qres = multi.Query(query)
+		require.EqualValues(t, sdk.CodeOK, qres.Code)
+		kp = merkle.KeyPath{}
+		kp = kp.AppendKey([]byte("store1"), merkle.KeyEncodingURL)
+		kp = kp.AppendKey(k2, merkle.KeyEncodingURL)
+		require.Nil(t, prt.VerifyAbsence(qres.Proof, commitIDs[height-1].Hash, kp.String()))
+	}
+
+	// pruned heights fail with a dedicated code, with or without proof
+	for _, prove := range []bool{false, true} {
+		qres := multi.Query(abci.RequestQuery{Path: "/store1/key", Data: k, Height: 3, Prove: prove})
+		require.EqualValues(t, sdk.CodeHeightPruned, qres.Code)
+		require.EqualValues(t, sdk.CodespaceRoot, qres.Codespace)
+		require.Equal(t, int64(3), qres.Height)
+	}
+
+	// future heights are not reported as pruned
+	qres := multi.Query(abci.RequestQuery{Path: "/store1/key", Data: k, Height: 6, Prove: true})
+	require.EqualValues(t, sdk.CodeUnknownRequest, qres.Code)
+}
+
+func TestCacheMultiStoreWithVersion(t *testing.T) {
+	db := dbm.NewMemDB()
+	store := newMultiStoreWithMounts(db)
+	require.Nil(t, store.LoadLatestVersion())
+	key1 := store.keysByName["store1"]
+
+	store.GetKVStore(key1).Set([]byte("key"), []byte("value1"))
+	store.Commit(nil)
+	store.GetKVStore(key1).Set([]byte("key"), []byte("value2"))
+	store.GetKVStore(key1).Set([]byte("key2"), []byte("value2"))
+	store.Commit(nil)
+
+	view, release, err := store.CacheMultiStoreWithVersion(1)
+	require.Nil(t, err)
+	require.Equal(t, []byte("value1"), view.GetKVStore(key1).Get([]byte("key")))
+	require.False(t, view.GetKVStore(key1).Has([]byte("key2")))
+
+	// the view is not affected by the next commits
+	store.GetKVStore(key1).Set([]byte("key"), []byte("value3"))
+	store.Commit(nil)
+	require.Equal(t, []byte("value1"), view.GetKVStore(key1).Get([]byte("key")))
+	release()
+
+	view, release, err = store.CacheMultiStoreWithVersion(2)
+	require.Nil(t, err)
+	iter := view.GetKVStore(key1).Iterator(nil, nil)
+	defer iter.Close()
+	var keys []string
+	for ; iter.Valid(); iter.Next() {
+		keys = append(keys, string(iter.Key()))
+	}
+	require.Equal(t, []string{"key", "key2"}, keys)
+	release()
+
+	_, _, err = store.CacheMultiStoreWithVersion(10)
+	require.NotNil(t, err)
+}
+
+func TestCacheMultiStoreWithVersionPruning(t *testing.T) {
+	db := dbm.NewMemDB()
+	store := newMultiStoreWithMounts(db)
+	store.SetPruning(sdk.PruneEverything.Options())
+	require.Nil(t, store.LoadLatestVersion())
+	key1 := store.keysByName["store1"]
+
+	for i := 0; i < 2; i++ {
+		store.GetKVStore(key1).Set([]byte("key"), []byte{byte(i)})
+		store.Commit(nil)
+	}
+	view, release, err := store.CacheMultiStoreWithVersion(2)
+	require.Nil(t, err)
+
+	// the version read by the view is pruned once the view is released
+	store.GetKVStore(key1).Set([]byte("key"), []byte{2})
+	store.Commit(nil)
+	require.Equal(t, []byte{1}, view.GetKVStore(key1).Get([]byte("key")))
+	_, release2, err := store.CacheMultiStoreWithVersion(2)
+	require.Nil(t, err)
+	release()
+	store.Commit(nil)
+	require.Equal(t, []byte{1}, view.GetKVStore(key1).Get([]byte("key")))
+	release2()
+
+	store.Commit(nil)
+	_, _, err = store.CacheMultiStoreWithVersion(2)
+	require.NotNil(t, err)
+	_, _, err = store.CacheMultiStoreWithVersion(3)
+	require.NotNil(t, err)
+}
+
 //-----------------------------------------------------------------------
 // utils
 
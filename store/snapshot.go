@@ -0,0 +1,450 @@
+package store
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"hash"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+
+	amino "github.com/tendermint/go-amino"
+	"github.com/tendermint/tendermint/crypto/tmhash"
+	cmn "github.com/tendermint/tendermint/libs/common"
+	dbm "github.com/tendermint/tm-db"
+)
+
+const (
+	// SnapshotManifestFile is the name of the manifest written to the snapshot directory
+	SnapshotManifestFile = "manifest.json"
+	// SnapshotChunksDir is the directory of the snapshot holding the chunk files
+	SnapshotChunksDir = "chunks"
+	// DefaultSnapshotChunkSize is the default maximum size of a chunk file in bytes
+	DefaultSnapshotChunkSize = 16 * 1024 * 1024
+
+	iavlNodePrefix = 'n' // n<hash>
+	iavlRootPrefix = 'r' // r<version>
+)
+
+// SnapshotManifest describes a state snapshot of the rootMultiStore at a committed version
+type SnapshotManifest struct {
+	Height    int64           `json:"height"`
+	AppHash   cmn.HexBytes    `json:"app_hash"`
+	ChunkSize int64           `json:"chunk_size"`
+	Stores    []SnapshotStore `json:"stores"`
+}
+
+// SnapshotStore describes the exported nodes of one IAVL store. The version is the one of
+// its tree, which is behind the height for the stores not committed at every height.
+type SnapshotStore struct {
+	Name     string          `json:"name"`
+	Version  int64           `json:"version"`
+	RootHash cmn.HexBytes    `json:"root_hash"`
+	Nodes    int64           `json:"nodes"`
+	Chunks   []SnapshotChunk `json:"chunks"`
+}
+
+// SnapshotChunk describes a chunk file of a store
+type SnapshotChunk struct {
+	File     string       `json:"file"`
+	Size     int64        `json:"size"`
+	Checksum cmn.HexBytes `json:"checksum"`
+}
+
+// commitInfo rebuilds the commitInfo the manifest was exported from
+func (m SnapshotManifest) commitInfo() commitInfo {
+	storeInfos := make([]storeInfo, 0, len(m.Stores))
+	for _, s := range m.Stores {
+		si := storeInfo{}
+		si.Name = s.Name
+		si.Core.CommitID = CommitID{Version: s.Version, Hash: s.RootHash}
+		storeInfos = append(storeInfos, si)
+	}
+	return commitInfo{Version: m.Height, StoreInfos: storeInfos}
+}
+
+// ExportSnapshot writes every IAVL store committed to db at the given height
+// into chunk files under dir, along with the manifest describing them.
+// A height of 0 exports the latest committed version.
+func ExportSnapshot(db dbm.DB, height int64, dir string, chunkSize int64) (SnapshotManifest, error) {
+	if height == 0 {
+		height = getLatestVersion(db)
+	}
+	if height <= 0 {
+		return SnapshotManifest{}, fmt.Errorf("no committed version to export")
+	}
+	if chunkSize <= 0 {
+		chunkSize = DefaultSnapshotChunkSize
+	}
+
+	cInfo, err := getCommitInfo(db, height)
+	if err != nil {
+		return SnapshotManifest{}, fmt.Errorf("version %d is not available: %v", height, err)
+	}
+	if err := os.MkdirAll(filepath.Join(dir, SnapshotChunksDir), os.ModePerm); err != nil {
+		return SnapshotManifest{}, err
+	}
+
+	storeInfos := make([]storeInfo, len(cInfo.StoreInfos))
+	copy(storeInfos, cInfo.StoreInfos)
+	sort.Slice(storeInfos, func(i, j int) bool { return storeInfos[i].Name < storeInfos[j].Name })
+
+	manifest := SnapshotManifest{
+		Height:    height,
+		AppHash:   cInfo.Hash(),
+		ChunkSize: chunkSize,
+	}
+	for _, si := range storeInfos {
+		s, err := exportStore(db, si, dir, chunkSize)
+		if err != nil {
+			return SnapshotManifest{}, err
+		}
+		manifest.Stores = append(manifest.Stores, s)
+	}
+
+	bz, err := cdc.MarshalJSONIndent(manifest, "", "  ")
+	if err != nil {
+		return SnapshotManifest{}, err
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, SnapshotManifestFile), bz, 0644); err != nil {
+		return SnapshotManifest{}, err
+	}
+	return manifest, nil
+}
+
+// exportStore walks the IAVL tree of the store from its root at the version of its commit info
+// and writes the raw nodes in pre-order, so that every node is preceded by its parent
+func exportStore(db dbm.DB, si storeInfo, dir string, chunkSize int64) (SnapshotStore, error) {
+	storeDB := dbm.NewPrefixDB(db, storePrefix(si.Name))
+	version := si.Core.CommitID.Version
+	rootHash := storeDB.Get(iavlRootKey(version))
+	if rootHash == nil {
+		return SnapshotStore{}, fmt.Errorf("version %d of store %s has been pruned", version, si.Name)
+	}
+	if !bytes.Equal(rootHash, si.Core.CommitID.Hash) {
+		return SnapshotStore{}, fmt.Errorf("root hash of store %s does not match the commit info", si.Name)
+	}
+
+	s := SnapshotStore{Name: si.Name, Version: version, RootHash: rootHash}
+	w := &chunkWriter{dir: dir, store: si.Name, chunkSize: chunkSize}
+
+	var stack [][]byte
+	if len(rootHash) > 0 {
+		stack = append(stack, rootHash)
+	}
+	for len(stack) > 0 {
+		hash := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		bz := storeDB.Get(iavlNodeKey(hash))
+		if bz == nil {
+			return SnapshotStore{}, fmt.Errorf("node %X of store %s not found", hash, si.Name)
+		}
+		node, err := decodeSnapshotNode(bz)
+		if err != nil {
+			return SnapshotStore{}, fmt.Errorf("invalid node %X of store %s: %v", hash, si.Name, err)
+		}
+		if err := w.write(bz); err != nil {
+			return SnapshotStore{}, err
+		}
+		s.Nodes++
+		if !node.isLeaf() {
+			stack = append(stack, node.rightHash, node.leftHash)
+		}
+	}
+
+	chunks, err := w.close()
+	if err != nil {
+		return SnapshotStore{}, err
+	}
+	s.Chunks = chunks
+	return s, nil
+}
+
+// LoadSnapshotManifest reads the manifest of the snapshot in dir
+func LoadSnapshotManifest(dir string) (SnapshotManifest, error) {
+	var manifest SnapshotManifest
+	bz, err := ioutil.ReadFile(filepath.Join(dir, SnapshotManifestFile))
+	if err != nil {
+		return manifest, err
+	}
+	if err := cdc.UnmarshalJSON(bz, &manifest); err != nil {
+		return manifest, fmt.Errorf("invalid snapshot manifest: %v", err)
+	}
+	return manifest, nil
+}
+
+// RestoreSnapshot rebuilds the IAVL stores of the snapshot in dir into an empty db.
+// Every chunk is checked against its checksum and every node against the hash
+// referencing it, and the commit info rebuilt from the store roots must hash to
+// the app hash of the manifest. The version is only committed once all the
+// stores have been verified.
+func RestoreSnapshot(db dbm.DB, dir string) (SnapshotManifest, error) {
+	manifest, err := LoadSnapshotManifest(dir)
+	if err != nil {
+		return manifest, err
+	}
+	if manifest.Height <= 0 {
+		return manifest, fmt.Errorf("invalid snapshot height %d", manifest.Height)
+	}
+	if latest := getLatestVersion(db); latest != 0 {
+		return manifest, fmt.Errorf("application db is not empty, latest version is %d", latest)
+	}
+
+	cInfo := manifest.commitInfo()
+	if !bytes.Equal(cInfo.Hash(), manifest.AppHash) {
+		return manifest, fmt.Errorf("app hash mismatch: manifest has %X, stores hash to %X", manifest.AppHash, cInfo.Hash())
+	}
+
+	for _, s := range manifest.Stores {
+		if err := restoreStore(db, dir, s); err != nil {
+			return manifest, err
+		}
+	}
+
+	batch := db.NewBatch()
+	setCommitInfo(batch, manifest.Height, cInfo)
+	setLatestVersion(batch, manifest.Height)
+	batch.WriteSync()
+	return manifest, nil
+}
+
+func restoreStore(db dbm.DB, dir string, s SnapshotStore) error {
+	storeDB := dbm.NewPrefixDB(db, storePrefix(s.Name))
+
+	// hashes referenced by the nodes restored so far and not yet seen
+	pending := make(map[string]bool)
+	if len(s.RootHash) > 0 {
+		pending[string(s.RootHash)] = true
+	}
+
+	var nodes int64
+	for _, chunk := range s.Chunks {
+		bz, err := ioutil.ReadFile(filepath.Join(dir, SnapshotChunksDir, chunk.File))
+		if err != nil {
+			return err
+		}
+		if int64(len(bz)) != chunk.Size {
+			return fmt.Errorf("chunk %s has size %d, expected %d", chunk.File, len(bz), chunk.Size)
+		}
+		if checksum := sha256.Sum256(bz); !bytes.Equal(checksum[:], chunk.Checksum) {
+			return fmt.Errorf("chunk %s has checksum %X, expected %X", chunk.File, checksum, chunk.Checksum)
+		}
+
+		batch := storeDB.NewBatch()
+		for len(bz) > 0 {
+			nodeBz, n, err := amino.DecodeByteSlice(bz)
+			if err != nil {
+				return fmt.Errorf("invalid chunk %s: %v", chunk.File, err)
+			}
+			bz = bz[n:]
+
+			node, err := decodeSnapshotNode(nodeBz)
+			if err != nil {
+				return fmt.Errorf("invalid node in chunk %s: %v", chunk.File, err)
+			}
+			hash, err := node.hash()
+			if err != nil {
+				return err
+			}
+			if !pending[string(hash)] {
+				return fmt.Errorf("unexpected node %X in chunk %s", hash, chunk.File)
+			}
+			delete(pending, string(hash))
+			if !node.isLeaf() {
+				pending[string(node.leftHash)] = true
+				pending[string(node.rightHash)] = true
+			}
+			batch.Set(iavlNodeKey(hash), nodeBz)
+			nodes++
+		}
+		batch.Write()
+	}
+
+	if len(pending) != 0 {
+		return fmt.Errorf("store %s is incomplete, %d nodes are missing", s.Name, len(pending))
+	}
+	if nodes != s.Nodes {
+		return fmt.Errorf("store %s has %d nodes, expected %d", s.Name, nodes, s.Nodes)
+	}
+
+	rootHash := []byte(s.RootHash)
+	if rootHash == nil {
+		rootHash = []byte{}
+	}
+	storeDB.SetSync(iavlRootKey(s.Version), rootHash)
+	return nil
+}
+
+//----------------------------------------
+// chunkWriter
+
+// chunkWriter writes length-prefixed records into chunk files of at most chunkSize bytes
+type chunkWriter struct {
+	dir       string
+	store     string
+	chunkSize int64
+
+	file   *os.File
+	buf    *bufio.Writer
+	hasher hash.Hash
+	size   int64
+	chunks []SnapshotChunk
+}
+
+func (w *chunkWriter) write(record []byte) error {
+	var buf bytes.Buffer
+	if err := amino.EncodeByteSlice(&buf, record); err != nil {
+		return err
+	}
+	if w.file != nil && w.size+int64(buf.Len()) > w.chunkSize {
+		if err := w.flush(); err != nil {
+			return err
+		}
+	}
+	if w.file == nil {
+		name := fmt.Sprintf("%s.%06d", w.store, len(w.chunks))
+		file, err := os.Create(filepath.Join(w.dir, SnapshotChunksDir, name))
+		if err != nil {
+			return err
+		}
+		w.file = file
+		w.hasher = sha256.New()
+		w.buf = bufio.NewWriter(io.MultiWriter(file, w.hasher))
+		w.size = 0
+		w.chunks = append(w.chunks, SnapshotChunk{File: name})
+	}
+	n, err := w.buf.Write(buf.Bytes())
+	w.size += int64(n)
+	return err
+}
+
+func (w *chunkWriter) flush() error {
+	if err := w.buf.Flush(); err != nil {
+		return err
+	}
+	if err := w.file.Close(); err != nil {
+		return err
+	}
+	chunk := &w.chunks[len(w.chunks)-1]
+	chunk.Size = w.size
+	chunk.Checksum = w.hasher.Sum(nil)
+	w.file = nil
+	return nil
+}
+
+func (w *chunkWriter) close() ([]SnapshotChunk, error) {
+	if w.file != nil {
+		if err := w.flush(); err != nil {
+			return nil, err
+		}
+	}
+	return w.chunks, nil
+}
+
+//----------------------------------------
+// snapshotNode
+
+// snapshotNode is the decoded form of a persisted IAVL node, see iavl.MakeNode
+type snapshotNode struct {
+	height    int8
+	size      int64
+	version   int64
+	key       []byte
+	value     []byte
+	leftHash  []byte
+	rightHash []byte
+}
+
+func (node snapshotNode) isLeaf() bool {
+	return node.height == 0
+}
+
+func decodeSnapshotNode(buf []byte) (node snapshotNode, err error) {
+	var n int
+	if node.height, n, err = amino.DecodeInt8(buf); err != nil {
+		return node, err
+	}
+	buf = buf[n:]
+	if node.size, n, err = amino.DecodeVarint(buf); err != nil {
+		return node, err
+	}
+	buf = buf[n:]
+	if node.version, n, err = amino.DecodeVarint(buf); err != nil {
+		return node, err
+	}
+	buf = buf[n:]
+	if node.key, n, err = amino.DecodeByteSlice(buf); err != nil {
+		return node, err
+	}
+	buf = buf[n:]
+
+	if node.isLeaf() {
+		node.value, _, err = amino.DecodeByteSlice(buf)
+		return node, err
+	}
+	if node.leftHash, n, err = amino.DecodeByteSlice(buf); err != nil {
+		return node, err
+	}
+	buf = buf[n:]
+	if node.rightHash, _, err = amino.DecodeByteSlice(buf); err != nil {
+		return node, err
+	}
+	if len(node.leftHash) == 0 || len(node.rightHash) == 0 {
+		return node, fmt.Errorf("inner node without child hash")
+	}
+	return node, nil
+}
+
+// hash computes the node hash the same way as iavl does, child hashes included
+func (node snapshotNode) hash() ([]byte, error) {
+	var buf bytes.Buffer
+	if err := amino.EncodeInt8(&buf, node.height); err != nil {
+		return nil, err
+	}
+	if err := amino.EncodeVarint(&buf, node.size); err != nil {
+		return nil, err
+	}
+	if err := amino.EncodeVarint(&buf, node.version); err != nil {
+		return nil, err
+	}
+	if node.isLeaf() {
+		if err := amino.EncodeByteSlice(&buf, node.key); err != nil {
+			return nil, err
+		}
+		if err := amino.EncodeByteSlice(&buf, tmhash.Sum(node.value)); err != nil {
+			return nil, err
+		}
+	} else {
+		if err := amino.EncodeByteSlice(&buf, node.leftHash); err != nil {
+			return nil, err
+		}
+		if err := amino.EncodeByteSlice(&buf, node.rightHash); err != nil {
+			return nil, err
+		}
+	}
+	return tmhash.Sum(buf.Bytes()), nil
+}
+
+//----------------------------------------
+// Misc.
+
+func storePrefix(name string) []byte {
+	return []byte("s/k:" + name + "/")
+}
+
+func iavlNodeKey(hash []byte) []byte {
+	return append([]byte{iavlNodePrefix}, hash...)
+}
+
+func iavlRootKey(version int64) []byte {
+	key := make([]byte, 9)
+	key[0] = iavlRootPrefix
+	binary.BigEndian.PutUint64(key[1:], uint64(version))
+	return key
+}
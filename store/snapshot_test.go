@@ -0,0 +1,111 @@
+package store
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	sdk "github.com/NPC-Chain/npcchub/types"
+	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tm-db"
+)
+
+func TestSnapshotExportRestore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "snapshot")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	db := dbm.NewMemDB()
+	store := newMultiStoreWithMounts(db)
+	require.Nil(t, store.LoadLatestVersion())
+
+	key1 := store.keysByName["store1"]
+	key2 := store.keysByName["store2"]
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 100; j++ {
+			store.GetKVStore(key1).Set([]byte(fmt.Sprintf("key%d", j)), []byte(fmt.Sprintf("value%d-%d", i, j)))
+		}
+		store.GetKVStore(key2).Set([]byte(fmt.Sprintf("key%d", i)), []byte("value"))
+		store.Commit(nil)
+	}
+	commitID := store.LastCommitID()
+
+	// older versions can be exported as long as they are not pruned
+	manifest, err := ExportSnapshot(db, 2, filepath.Join(dir, "v2"), 1024)
+	require.Nil(t, err)
+	require.Equal(t, int64(2), manifest.Height)
+
+	manifest, err = ExportSnapshot(db, 0, dir, 1024)
+	require.Nil(t, err)
+	require.Equal(t, commitID.Version, manifest.Height)
+	require.Equal(t, commitID.Hash, []byte(manifest.AppHash))
+	require.Len(t, manifest.Stores, 3)
+	require.True(t, len(manifest.Stores[0].Chunks) > 1)
+	require.Empty(t, manifest.Stores[2].Chunks)
+
+	// restore into an empty db and load the stores from it
+	restoredDB := dbm.NewMemDB()
+	_, err = RestoreSnapshot(restoredDB, dir)
+	require.Nil(t, err)
+
+	restored := newMultiStoreWithMounts(restoredDB)
+	require.Nil(t, restored.LoadLatestVersion())
+	require.Equal(t, commitID, restored.LastCommitID())
+	require.Equal(t, []byte("value2-42"), restored.GetKVStore(restored.keysByName["store1"]).Get([]byte("key42")))
+
+	// the restored store keeps committing on top of the snapshot
+	restored.GetKVStore(restored.keysByName["store3"]).Set([]byte("key"), []byte("value"))
+	require.Equal(t, commitID.Version+1, restored.Commit(nil).Version)
+
+	// a non empty db is refused
+	_, err = RestoreSnapshot(restoredDB, dir)
+	require.NotNil(t, err)
+
+	// a corrupted chunk is detected
+	chunk := filepath.Join(dir, SnapshotChunksDir, manifest.Stores[0].Chunks[0].File)
+	bz, err := ioutil.ReadFile(chunk)
+	require.Nil(t, err)
+	bz[len(bz)-1] ^= 0xff
+	require.Nil(t, ioutil.WriteFile(chunk, bz, 0644))
+	_, err = RestoreSnapshot(dbm.NewMemDB(), dir)
+	require.NotNil(t, err)
+}
+
+func TestSnapshotExportRestoreLaggingStore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "snapshot")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	db := dbm.NewMemDB()
+	store := newMultiStoreWithMounts(db)
+	require.Nil(t, store.LoadLatestVersion())
+
+	// store2 is not committed at height 2, its tree version lags behind the height
+	key1 := store.keysByName["store1"]
+	key2 := store.keysByName["store2"]
+	store.GetKVStore(key2).Set([]byte("key"), []byte("value"))
+	store.Commit(nil)
+	store.GetKVStore(key1).Set([]byte("key"), []byte("value"))
+	store.Commit([]*sdk.KVStoreKey{key1.(*sdk.KVStoreKey)})
+	store.GetKVStore(key1).Set([]byte("key"), []byte("value3"))
+	commitID := store.Commit(nil)
+
+	manifest, err := ExportSnapshot(db, 0, dir, 1024)
+	require.Nil(t, err)
+	require.Equal(t, int64(3), manifest.Height)
+	require.Equal(t, commitID.Hash, []byte(manifest.AppHash))
+	require.Equal(t, "store2", manifest.Stores[1].Name)
+	require.Equal(t, int64(2), manifest.Stores[1].Version)
+
+	restoredDB := dbm.NewMemDB()
+	_, err = RestoreSnapshot(restoredDB, dir)
+	require.Nil(t, err)
+
+	restored := newMultiStoreWithMounts(restoredDB)
+	require.Nil(t, restored.LoadLatestVersion())
+	require.Equal(t, commitID, restored.LastCommitID())
+	require.Equal(t, []byte("value"), restored.GetKVStore(restored.keysByName["store2"]).Get([]byte("key")))
+	require.Equal(t, []byte("value3"), restored.GetKVStore(restored.keysByName["store1"]).Get([]byte("key")))
+}
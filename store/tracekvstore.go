@@ -202,3 +202,30 @@ func writeOperation(w io.Writer, op operation, tc TraceContext, key, value []byt
 
 	io.WriteString(w, "\n")
 }
+
+// FilterTraceWrites copies the write and delete operations of a trace to w, dropping
+// the reads and iterations, which gives the changes made to the traced stores.
+func FilterTraceWrites(r io.Reader, w io.Writer) error {
+	decoder := json.NewDecoder(r)
+	for {
+		var traceOp traceOperation
+		err := decoder.Decode(&traceOp)
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if traceOp.Operation != writeOp && traceOp.Operation != deleteOp {
+			continue
+		}
+
+		raw, err := json.Marshal(traceOp)
+		if err != nil {
+			return err
+		}
+		if _, err := w.Write(append(raw, '\n')); err != nil {
+			return err
+		}
+	}
+}
@@ -281,3 +281,24 @@ func TestTraceKVStoreCacheWrapWithTrace(t *testing.T) {
 	store := newEmptyTraceKVStore(nil)
 	require.Panics(t, func() { store.CacheWrapWithTrace(nil, nil) })
 }
+
+func TestFilterTraceWrites(t *testing.T) {
+	var buf bytes.Buffer
+	store := newTraceKVStore(&buf)
+	store.Get(kvPairs[0].Key)
+	store.Delete(kvPairs[1].Key)
+	iterator := store.Iterator(nil, nil)
+	for ; iterator.Valid(); iterator.Next() {
+		iterator.Key()
+	}
+	iterator.Close()
+
+	var out bytes.Buffer
+	require.NoError(t, FilterTraceWrites(&buf, &out))
+	require.Equal(t, "{\"operation\":\"write\",\"key\":\"a2V5MDAwMDAwMDE=\",\"value\":\"dmFsdWUwMDAwMDAwMQ==\",\"metadata\":{\"blockHeight\":64}}\n"+
+		"{\"operation\":\"write\",\"key\":\"a2V5MDAwMDAwMDI=\",\"value\":\"dmFsdWUwMDAwMDAwMg==\",\"metadata\":{\"blockHeight\":64}}\n"+
+		"{\"operation\":\"write\",\"key\":\"a2V5MDAwMDAwMDM=\",\"value\":\"dmFsdWUwMDAwMDAwMw==\",\"metadata\":{\"blockHeight\":64}}\n"+
+		"{\"operation\":\"delete\",\"key\":\"a2V5MDAwMDAwMDI=\",\"value\":\"\",\"metadata\":{\"blockHeight\":64}}\n", out.String())
+
+	require.Error(t, FilterTraceWrites(bytes.NewBufferString("{"), &out))
+}
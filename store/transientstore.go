@@ -31,7 +31,7 @@ func (ts *transientStore) CommitWithVersion([]*sdk.KVStoreKey, int64) (id Commit
 }
 
 // Implements CommitStore
-func (ts *transientStore) SetPruning(pruning PruningStrategy) {
+func (ts *transientStore) SetPruning(pruning PruningOptions) {
 }
 
 // Implements CommitStore
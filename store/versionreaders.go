@@ -0,0 +1,57 @@
+package store
+
+import (
+	"sync"
+)
+
+// versionReaders counts the readers of each committed version of the
+// rootMultiStore, i.e. the queries running concurrently with the commits.
+// The versions being read are not pruned until their readers are done.
+type versionReaders struct {
+	mtx    sync.Mutex
+	counts map[int64]int
+}
+
+func newVersionReaders() *versionReaders {
+	return &versionReaders{
+		counts: make(map[int64]int),
+	}
+}
+
+// acquire loads the version and counts a reader of it if the load succeeds.
+// It is serialized with the prunings, so that a version isn't deleted while it is loaded.
+func (vr *versionReaders) acquire(version int64, load func() error) error {
+	vr.mtx.Lock()
+	defer vr.mtx.Unlock()
+	if err := load(); err != nil {
+		return err
+	}
+	vr.counts[version]++
+	return nil
+}
+
+// release uncounts a reader of the version
+func (vr *versionReaders) release(version int64) {
+	vr.mtx.Lock()
+	defer vr.mtx.Unlock()
+	if vr.counts[version] <= 1 {
+		delete(vr.counts, version)
+		return
+	}
+	vr.counts[version]--
+}
+
+// prune runs the deletion of the version unless it has readers, and returns whether it ran
+func (vr *versionReaders) prune(version int64, del func()) bool {
+	if vr == nil {
+		del()
+		return true
+	}
+	vr.mtx.Lock()
+	defer vr.mtx.Unlock()
+	if vr.counts[version] > 0 {
+		return false
+	}
+	del()
+	return true
+}
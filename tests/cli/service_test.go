@@ -77,6 +77,7 @@ func TestIrisCLIService(t *testing.T) {
 	sdStr = fmt.Sprintf("iriscli service bind %v", flags)
 	sdStr += fmt.Sprintf(" --service-name=%s", serviceName)
 	sdStr += fmt.Sprintf(" --def-chain-id=%s", chainID)
+	sdStr += fmt.Sprintf(" --def-version=%s", "1.0.0")
 	sdStr += fmt.Sprintf(" --bind-type=%s", "Local")
 	sdStr += fmt.Sprintf(" --deposit=%s", "10iris")
 	sdStr += fmt.Sprintf(" --prices=%s", "1iris")
@@ -161,6 +162,7 @@ func TestIrisCLIService(t *testing.T) {
 	caStr := fmt.Sprintf("iriscli service call %v", flags)
 	caStr += fmt.Sprintf(" --def-chain-id=%s", chainID)
 	caStr += fmt.Sprintf(" --service-name=%s", serviceName)
+	caStr += fmt.Sprintf(" --def-version=%s", "1.0.0")
 	caStr += fmt.Sprintf(" --bind-chain-id=%s", chainID)
 	caStr += fmt.Sprintf(" --method-id=%d", 1)
 	caStr += fmt.Sprintf(" --provider=%s", fooAddr.String())
@@ -7,6 +7,7 @@ import (
 
 	abci "github.com/tendermint/tendermint/abci/types"
 
+	"github.com/NPC-Chain/npcchub/app/protocol"
 	"github.com/NPC-Chain/npcchub/mock"
 	"github.com/NPC-Chain/npcchub/mock/simulation"
 	"github.com/NPC-Chain/npcchub/modules/bank"
@@ -63,6 +64,7 @@ func TestGovWithRandomMessages(t *testing.T) {
 		distrKeeper,
 		guardianKeeper,
 		stakeKeeper,
+		protocol.NewRouter(),
 		gov.DefaultCodespace,
 		gov.NopMetrics(),
 	)
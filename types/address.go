@@ -7,9 +7,9 @@ import (
 	"errors"
 	"fmt"
 
+	ccrypto "github.com/NPC-Chain/npcchub/crypto"
 	"github.com/tendermint/tendermint/crypto"
-	"github.com/tendermint/tendermint/crypto/encoding/amino"
-
+	"github.com/tendermint/tendermint/crypto/ed25519"
 	"github.com/tendermint/tendermint/libs/bech32"
 )
 
@@ -438,7 +438,7 @@ func GetAccPubKeyBech32(pubkey string) (pk crypto.PubKey, err error) {
 		return nil, err
 	}
 
-	pk, err = cryptoAmino.PubKeyFromBytes(bz)
+	pk, err = ccrypto.PubKeyFromBytes(bz)
 	if err != nil {
 		return nil, err
 	}
@@ -466,7 +466,7 @@ func GetValPubKeyBech32(pubkey string) (pk crypto.PubKey, err error) {
 		return nil, err
 	}
 
-	pk, err = cryptoAmino.PubKeyFromBytes(bz)
+	pk, err = ccrypto.PubKeyFromBytes(bz)
 	if err != nil {
 		return nil, err
 	}
@@ -487,6 +487,7 @@ func MustGetValPubKeyBech32(pubkey string) (pk crypto.PubKey) {
 
 // GetConsPubKeyBech32 creates a PubKey for a consensus node with a given public
 // key string using the Bech32 Bech32PrefixConsPub prefix.
+// Only ed25519 keys are accepted, as tendermint validators sign with them.
 func GetConsPubKeyBech32(pubkey string) (pk crypto.PubKey, err error) {
 	bech32PrefixConsPub := GetConfig().GetBech32ConsensusPubPrefix()
 	bz, err := GetFromBech32(pubkey, bech32PrefixConsPub)
@@ -494,10 +495,13 @@ func GetConsPubKeyBech32(pubkey string) (pk crypto.PubKey, err error) {
 		return nil, err
 	}
 
-	pk, err = cryptoAmino.PubKeyFromBytes(bz)
+	pk, err = ccrypto.PubKeyFromBytes(bz)
 	if err != nil {
 		return nil, err
 	}
+	if _, ok := pk.(ed25519.PubKeyEd25519); !ok {
+		return nil, fmt.Errorf("invalid consensus pubkey type %T, expected an ed25519 pubkey", pk)
+	}
 
 	return pk, nil
 }
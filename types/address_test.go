@@ -8,7 +8,9 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/tendermint/tendermint/crypto/ed25519"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
 
+	"github.com/NPC-Chain/npcchub/crypto/secp256r1"
 	"github.com/NPC-Chain/npcchub/types"
 )
 
@@ -72,6 +74,16 @@ func TestRandBech32PubkeyConsistency(t *testing.T) {
 		require.Equal(t, valPub, accPub)
 		require.Equal(t, valPub, consPub)
 	}
+
+	// the consensus pubkeys are ed25519 only
+	k1ConsPub, err := types.Bech32ifyConsPub(secp256k1.GenPrivKey().PubKey())
+	require.Nil(t, err)
+	_, err = types.GetConsPubKeyBech32(k1ConsPub)
+	require.NotNil(t, err)
+	r1ConsPub, err := types.Bech32ifyConsPub(secp256r1.GenPrivKey().PubKey())
+	require.Nil(t, err)
+	_, err = types.GetConsPubKeyBech32(r1ConsPub)
+	require.NotNil(t, err)
 }
 
 func TestRandBech32AccAddrConsistency(t *testing.T) {
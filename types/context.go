@@ -37,6 +37,8 @@ type Context struct {
 	coinFlowTrigger string
 	coinFlowTags    CoinFlowTags
 	validTxCounter  *ValidTxCounter
+	pendingTxs      *PendingTxCounter
+	priority        int64
 }
 
 // Read-only accessors
@@ -55,6 +57,8 @@ func (c Context) MinimumFees() Coins              { return c.minimumFee }
 func (c Context) CoinFlowTags() CoinFlowTags      { return c.coinFlowTags }
 func (c Context) CoinFlowTrigger() string         { return c.coinFlowTrigger }
 func (c Context) ValidTxCounter() *ValidTxCounter { return c.validTxCounter }
+func (c Context) PendingTxs() *PendingTxCounter   { return c.pendingTxs }
+func (c Context) Priority() int64                 { return c.priority }
 
 // clone the header before returning
 func (c Context) BlockHeader() abci.Header {
@@ -82,6 +86,7 @@ func NewContext(ms MultiStore, header abci.Header, isCheckTx bool, logger log.Lo
 		coinFlowTrigger: "",
 		coinFlowTags:    NewCoinFlowRecord(false),
 		validTxCounter:  NewValidTxCounter(),
+		pendingTxs:      NewPendingTxCounter(0),
 	}
 }
 
@@ -131,6 +136,18 @@ func (c Context) WithCheckValidNum(txCounter *ValidTxCounter) Context {
 	return c
 }
 
+// WithPendingTxs sets the counter of the txs accepted by CheckTx in the check state
+func (c Context) WithPendingTxs(pendingTxs *PendingTxCounter) Context {
+	c.pendingTxs = pendingTxs
+	return c
+}
+
+// WithPriority sets the mempool priority of the tx, computed by the ante handler in CheckTx
+func (c Context) WithPriority(priority int64) Context {
+	c.priority = priority
+	return c
+}
+
 func (c Context) WithTxBytes(txBytes []byte) Context {
 	c.txBytes = txBytes
 	return c
@@ -17,3 +17,36 @@ func NewValidTxCounter() *ValidTxCounter {
 		count: 0,
 	}
 }
+
+// PendingTxCounter counts the txs of each sender accepted by CheckTx since the last commit,
+// i.e. the txs of the sender pending in the local mempool. The check state gets a new
+// counter on commit, which the recheck of the txs left in the mempool fills again.
+type PendingTxCounter struct {
+	limit  uint64
+	counts map[string]uint64
+}
+
+// NewPendingTxCounter creates a counter allowing limit pending txs per sender, 0 for no limit
+func NewPendingTxCounter(limit uint64) *PendingTxCounter {
+	return &PendingTxCounter{
+		limit:  limit,
+		counts: make(map[string]uint64),
+	}
+}
+
+func (ptc *PendingTxCounter) Limit() uint64 {
+	return ptc.limit
+}
+
+func (ptc *PendingTxCounter) Count(sender AccAddress) uint64 {
+	return ptc.counts[string(sender)]
+}
+
+// Full returns whether the sender reached the limit of pending txs
+func (ptc *PendingTxCounter) Full(sender AccAddress) bool {
+	return ptc.limit > 0 && ptc.Count(sender) >= ptc.limit
+}
+
+func (ptc *PendingTxCounter) Incr(sender AccAddress) {
+	ptc.counts[string(sender)]++
+}
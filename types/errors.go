@@ -50,6 +50,8 @@ const (
 	CodeExceedsTxSize     CodeType = 21
 	CodeServiceTxLimit    CodeType = 22
 	CodePaginationParams  CodeType = 23
+	CodeHeightPruned      CodeType = 24
+	CodeTooManyPendingTxs CodeType = 25
 	// CodespaceRoot is a codespace for error codes in this file only.
 	// Notice that 0 is an "unset" codespace, which can be overridden with
 	// Error.WithDefaultCodespace().
@@ -104,6 +106,10 @@ func CodeToDefaultMsg(code CodeType) string {
 		return "invalid tx fee"
 	case CodeInvalidFeeDenom:
 		return "invalid fee denom"
+	case CodeHeightPruned:
+		return "height pruned"
+	case CodeTooManyPendingTxs:
+		return "too many pending txs"
 	default:
 		return unknownCodeMsg(code)
 	}
@@ -186,6 +192,12 @@ func ErrInvalidLength(codespace CodespaceType, codeType CodeType, descriptor str
 func ErrInvalidPaginationParams(msg string) Error {
 	return newErrorWithRootCodespace(CodePaginationParams, msg)
 }
+func ErrHeightPruned(msg string) Error {
+	return newErrorWithRootCodespace(CodeHeightPruned, msg)
+}
+func ErrTooManyPendingTxs(msg string) Error {
+	return newErrorWithRootCodespace(CodeTooManyPendingTxs, msg)
+}
 
 //----------------------------------------
 // Error & sdkError
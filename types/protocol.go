@@ -8,6 +8,8 @@ import (
 
 const (
 	AppVersionTag = "app_version"
+	// the app exports the genesis for zero height once the block with this tag is committed
+	ExportGenesisTag = "export_genesis"
 	MainStore     = "main"
 )
 
@@ -1,6 +1,8 @@
 package types
 
 import (
+	"fmt"
+
 	abci "github.com/tendermint/tendermint/abci/types"
 )
 
@@ -31,6 +33,55 @@ func GetSkipCount(page uint64, size uint16) uint64 {
 	return uint64(int(page-1) * int(size))
 }
 
+// PageBounds returns the bounds [start, end) of the page in a list of numItems items.
+// A size of 0 selects all the items, for the queries sent without pagination params.
+func PageBounds(numItems int, page uint64, size uint16) (start, end int) {
+	if size == 0 {
+		return 0, numItems
+	}
+	skip := GetSkipCount(page, size)
+	if skip >= uint64(numItems) {
+		return numItems, numItems
+	}
+	start = int(skip)
+	end = start + int(size)
+	if end > numItems {
+		end = numItems
+	}
+	return start, end
+}
+
+// PaginatedIterator returns an iterator over the items of the page only, skipping the
+// items of the previous pages. A size of 0 selects all the items.
+func PaginatedIterator(iterator Iterator, page uint64, size uint16) Iterator {
+	if size == 0 {
+		return iterator
+	}
+	for skip := GetSkipCount(page, size); skip > 0 && iterator.Valid(); skip-- {
+		iterator.Next()
+	}
+	return &paginatedIterator{Iterator: iterator, remaining: size}
+}
+
+type paginatedIterator struct {
+	Iterator
+	remaining uint16
+}
+
+func (pi *paginatedIterator) Valid() bool {
+	return pi.remaining > 0 && pi.Iterator.Valid()
+}
+
+func (pi *paginatedIterator) Next() {
+	pi.remaining--
+	pi.Iterator.Next()
+}
+
+// QueryGasInfo formats the gas consumed by a query into the info of its response
+func QueryGasInfo(gasUsed Gas) string {
+	return fmt.Sprintf("gas_used:%d", gasUsed)
+}
+
 func MarshalResultErr(err error) Error {
 	return ErrInternal(AppendMsgToErr("could not marshal result to JSON", err.Error()))
 }
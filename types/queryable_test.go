@@ -0,0 +1,50 @@
+package types
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tm-db"
+)
+
+func TestPageBounds(t *testing.T) {
+	cases := []struct {
+		numItems   int
+		page       uint64
+		size       uint16
+		start, end int
+	}{
+		{10, 0, 0, 0, 10},
+		{10, 0, 3, 0, 3},
+		{10, 1, 3, 0, 3},
+		{10, 4, 3, 9, 10},
+		{10, 5, 3, 10, 10},
+		{0, 1, 3, 0, 0},
+	}
+	for i, tc := range cases {
+		start, end := PageBounds(tc.numItems, tc.page, tc.size)
+		require.Equal(t, tc.start, start, "case %d", i)
+		require.Equal(t, tc.end, end, "case %d", i)
+	}
+}
+
+func TestPaginatedIterator(t *testing.T) {
+	db := dbm.NewMemDB()
+	for i := 0; i < 10; i++ {
+		db.Set([]byte(fmt.Sprintf("key%d", i)), []byte("value"))
+	}
+	keys := func(page uint64, size uint16) (keys []string) {
+		iterator := PaginatedIterator(db.Iterator(nil, nil), page, size)
+		defer iterator.Close()
+		for ; iterator.Valid(); iterator.Next() {
+			keys = append(keys, string(iterator.Key()))
+		}
+		return keys
+	}
+
+	require.Len(t, keys(1, 0), 10)
+	require.Equal(t, []string{"key0", "key1", "key2"}, keys(1, 3))
+	require.Equal(t, []string{"key9"}, keys(4, 3))
+	require.Empty(t, keys(5, 3))
+}
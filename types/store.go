@@ -26,6 +26,89 @@ const (
 	PruneNothing PruningStrategy = iota
 )
 
+// nolint - pruning strategy names
+const (
+	PruningSyncable   = "syncable"
+	PruningNothing    = "nothing"
+	PruningEverything = "everything"
+	PruningCustom     = "custom"
+	// PruningArchive keeps every state, like nothing, for the archive nodes serving historical queries
+	PruningArchive = "archive"
+)
+
+// Options returns the pruning options of the strategy
+func (s PruningStrategy) Options() PruningOptions {
+	switch s {
+	case PruneEverything:
+		return NewPruningOptions(0, 0, 1)
+	case PruneNothing:
+		return NewPruningOptions(0, 1, 1)
+	default:
+		return NewPruningOptions(100, 10000, 1)
+	}
+}
+
+// PruningOptions are the states kept by a pruning strategy, either one of the PruningStrategy or custom
+type PruningOptions struct {
+	// KeepRecent is the number of recent states kept
+	KeepRecent int64
+	// KeepEvery keeps every KeepEvery-th state, 0 keeps none of them
+	KeepEvery int64
+	// Interval is the number of blocks between two prunings, 1 prunes at every block
+	Interval int64
+}
+
+// NewPruningOptions creates pruning options keeping the last keepRecent states and
+// every keepEvery-th state, pruning the others every interval blocks
+func NewPruningOptions(keepRecent, keepEvery, interval int64) PruningOptions {
+	return PruningOptions{
+		KeepRecent: keepRecent,
+		KeepEvery:  keepEvery,
+		Interval:   interval,
+	}
+}
+
+// ParsePruningOptions returns the pruning options of the strategy of the given name,
+// the keepRecent, keepEvery and interval settings only apply to the custom strategy
+func ParsePruningOptions(name string, keepRecent, keepEvery, interval int64) (PruningOptions, error) {
+	switch name {
+	case PruningSyncable:
+		return PruneSyncable.Options(), nil
+	case PruningNothing, PruningArchive:
+		return PruneNothing.Options(), nil
+	case PruningEverything:
+		return PruneEverything.Options(), nil
+	case PruningCustom:
+		pruning := NewPruningOptions(keepRecent, keepEvery, interval)
+		return pruning, pruning.Validate()
+	default:
+		return PruningOptions{}, fmt.Errorf("invalid pruning strategy: %s", name)
+	}
+}
+
+// Validate checks the settings of the pruning options
+func (p PruningOptions) Validate() error {
+	if p.KeepRecent < 0 {
+		return fmt.Errorf("pruning_keep_recent must not be negative, got %d", p.KeepRecent)
+	}
+	if p.KeepEvery < 0 {
+		return fmt.Errorf("pruning_keep_every must not be negative, got %d", p.KeepEvery)
+	}
+	if p.Interval <= 0 {
+		return fmt.Errorf("pruning_interval must be positive, got %d", p.Interval)
+	}
+	return nil
+}
+
+// Keep returns whether the state of the given version is kept at the latest version
+func (p PruningOptions) Keep(version, latest int64) bool {
+	return version > latest-1-p.KeepRecent || (p.KeepEvery != 0 && version%p.KeepEvery == 0)
+}
+
+func (p PruningOptions) String() string {
+	return fmt.Sprintf("keep_recent=%d keep_every=%d interval=%d", p.KeepRecent, p.KeepEvery, p.Interval)
+}
+
 type Store interface { //nolint
 	GetStoreType() StoreType
 	CacheWrapper
@@ -36,7 +119,7 @@ type Committer interface {
 	Commit([]*KVStoreKey) CommitID
 	CommitWithVersion([]*KVStoreKey, int64) CommitID
 	LastCommitID() CommitID
-	SetPruning(PruningStrategy)
+	SetPruning(PruningOptions)
 }
 
 // Stores of MultiStore must implement CommitStore.
@@ -53,6 +136,13 @@ type Queryable interface {
 	Query(abci.RequestQuery) abci.ResponseQuery
 }
 
+// GasQueryable is a Queryable metering the cost of its queries. The gas meter
+// panics with ErrorOutOfGas once its limit is reached.
+type GasQueryable interface {
+	Queryable
+	QueryWithGas(req abci.RequestQuery, meter GasMeter) abci.ResponseQuery
+}
+
 //----------------------------------------
 // MultiStore
 
@@ -115,6 +205,24 @@ type CommitMultiStore interface {
 	// the next commit after loading must be idempotent (return the
 	// same commit id).  Otherwise the behavior is undefined.
 	LoadVersion(ver int64, overwrite bool) error
+
+	// CacheMultiStoreWithVersion cache wraps a read-only view of a committed
+	// version, which can be read concurrently with the next commits. The
+	// version is not pruned until release is called.
+	CacheMultiStoreWithVersion(version int64) (ms CacheMultiStore, release func(), err error)
+
+	// AddListeners adds listeners notified of every write flushed to the
+	// persistent stores. Transient stores are not listened to.
+	AddListeners(listeners ...WriteListener)
+
+	// ListeningEnabled returns if any listener is notified of the writes.
+	ListeningEnabled() bool
+}
+
+// WriteListener is notified of the writes made to the persistent stores of a
+// CommitMultiStore. A nil value with delete set to true reports a deletion.
+type WriteListener interface {
+	OnWrite(storeKey StoreKey, key []byte, value []byte, delete bool)
 }
 
 //---------subsp-------------------------------